@@ -0,0 +1,218 @@
+package goopenzwave
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Errors describing why a ValueAddress could not be resolved. They are
+// returned in a ValueAddressError.
+var (
+	ErrAddressNotFound  = errors.New("no value at address")
+	ErrAddressAmbiguous = errors.New("address matches more than one value")
+)
+
+// ValueAddressError is returned when a ValueAddress could not be resolved.
+type ValueAddressError struct {
+	Address ValueAddress
+	Err     error // One of the ErrAddress variables.
+}
+
+func (e *ValueAddressError) Error() string {
+	return fmt.Sprintf("value address %s: %s", e.Address, e.Err)
+}
+
+// Unwrap returns the reason for the error.
+func (e *ValueAddressError) Unwrap() error {
+	return e.Err
+}
+
+// ValueAddress is a stable, human-readable reference to a value, for use in
+// configuration files, MQTT topics and logs. Unlike the ValueID's ID it does
+// not depend on the genre or type of the value.
+//
+// An address is either numeric, written as home/node/cc/instance/index, e.g.
+// "0xf00dbeef/4/0x26/1/0", or named, written as node-name/Label, e.g.
+// "Hall Lamp/Level". Named addresses are only valid while the node name is
+// unique on the network and the label is unique on the node. A '/' or '%' in
+// a name or label is escaped as in a URL.
+type ValueAddress struct {
+	HomeID         uint32
+	NodeID         uint8
+	CommandClassID uint8
+	Instance       uint8
+	Index          uint8
+
+	// NodeName and Label are set for a named address, in which case the
+	// numeric fields are not used.
+	NodeName string
+	Label    string
+}
+
+// IsNamed returns true if the address is a node-name/Label address.
+func (a ValueAddress) IsNamed() bool {
+	return a.NodeName != ""
+}
+
+// String returns the address in its canonical form, which ParseValueAddress
+// reads back.
+func (a ValueAddress) String() string {
+	if a.IsNamed() {
+		return escapeAddressPart(a.NodeName) + "/" + escapeAddressPart(a.Label)
+	}
+	return fmt.Sprintf("0x%08x/%d/0x%02x/%d/%d", a.HomeID, a.NodeID, a.CommandClassID, a.Instance, a.Index)
+}
+
+// MarshalText encodes the address as by String.
+func (a ValueAddress) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText decodes the address as by ParseValueAddress.
+func (a *ValueAddress) UnmarshalText(text []byte) error {
+	parsed, err := ParseValueAddress(string(text))
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// ParseValueAddress parses a numeric or named address. The numbers of a numeric
+// address may be decimal or hexadecimal with a 0x prefix.
+func ParseValueAddress(s string) (ValueAddress, error) {
+	parts := strings.Split(s, "/")
+	switch len(parts) {
+	case 2:
+		name, err := url.PathUnescape(parts[0])
+		if err != nil {
+			return ValueAddress{}, fmt.Errorf("invalid value address %q: %s", s, err)
+		}
+		label, err := url.PathUnescape(parts[1])
+		if err != nil {
+			return ValueAddress{}, fmt.Errorf("invalid value address %q: %s", s, err)
+		}
+		if name == "" || label == "" {
+			return ValueAddress{}, fmt.Errorf("invalid value address %q: missing node name or label", s)
+		}
+		return ValueAddress{NodeName: name, Label: label}, nil
+
+	case 5:
+		var numbers [5]uint64
+		for i, part := range parts {
+			bits := 8
+			if i == 0 {
+				bits = 32
+			}
+			n, err := strconv.ParseUint(part, 0, bits)
+			if err != nil {
+				return ValueAddress{}, fmt.Errorf("invalid value address %q: bad number %q", s, part)
+			}
+			numbers[i] = n
+		}
+		return ValueAddress{
+			HomeID:         uint32(numbers[0]),
+			NodeID:         uint8(numbers[1]),
+			CommandClassID: uint8(numbers[2]),
+			Instance:       uint8(numbers[3]),
+			Index:          uint8(numbers[4]),
+		}, nil
+	}
+	return ValueAddress{}, fmt.Errorf("invalid value address %q: want home/node/cc/instance/index or node-name/Label", s)
+}
+
+// escapeAddressPart escapes the characters which would break up a named
+// address.
+func escapeAddressPart(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	return strings.ReplaceAll(s, "/", "%2F")
+}
+
+// Address returns the numeric address of the value.
+func (v *ValueID) Address() ValueAddress {
+	return ValueAddress{
+		HomeID:         v.HomeID,
+		NodeID:         v.NodeID,
+		CommandClassID: v.CommandClassID,
+		Instance:       v.Instance,
+		Index:          v.Index,
+	}
+}
+
+// Address returns the named address of the value if its node name and label
+// are unique, otherwise its numeric address. It returns false if the Store
+// does not hold the value.
+func (s *Store) Address(homeID uint32, valueID uint64) (ValueAddress, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var node *storeNode
+	var value *ValueState
+	for key, n := range s.nodes {
+		if key.homeID != homeID {
+			continue
+		}
+		if v, ok := n.values[valueID]; ok {
+			node, value = n, v
+			break
+		}
+	}
+	if value == nil {
+		return ValueAddress{}, false
+	}
+
+	address := value.ValueID.Address()
+	name := node.state.Name
+	if name == "" || value.Label == "" {
+		return address, true
+	}
+	for _, n := range s.nodes {
+		if n != node && n.state.Name == name {
+			return address, true
+		}
+	}
+	for _, v := range node.values {
+		if v != value && v.Label == value.Label {
+			return address, true
+		}
+	}
+	return ValueAddress{NodeName: name, Label: value.Label}, true
+}
+
+// Resolve returns the value at the address. It returns a *ValueAddressError
+// with ErrAddressNotFound if the Store does not hold such a value, or with
+// ErrAddressAmbiguous if a named address matches more than one value.
+func (s *Store) Resolve(address ValueAddress) (ValueState, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var found *ValueState
+	for key, node := range s.nodes {
+		if address.IsNamed() {
+			if node.state.Name != address.NodeName {
+				continue
+			}
+		} else if key.homeID != address.HomeID || key.nodeID != address.NodeID {
+			continue
+		}
+		for _, value := range node.values {
+			v := value.ValueID
+			if address.IsNamed() {
+				if value.Label != address.Label {
+					continue
+				}
+			} else if v.CommandClassID != address.CommandClassID || v.Instance != address.Instance || v.Index != address.Index {
+				continue
+			}
+			if found != nil {
+				return ValueState{}, &ValueAddressError{Address: address, Err: ErrAddressAmbiguous}
+			}
+			found = value
+		}
+	}
+	if found == nil {
+		return ValueState{}, &ValueAddressError{Address: address, Err: ErrAddressNotFound}
+	}
+	return found.snapshot(), nil
+}
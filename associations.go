@@ -0,0 +1,282 @@
+package goopenzwave
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// DesiredAssociations is the association state wanted for a network: for each
+// node ID, the exact members wanted in each of its groups. Groups which are
+// not listed for a node are left alone, while a listed group with no members
+// is emptied. It can be decoded from JSON or YAML, for example:
+//
+//	2:
+//	  1: [{nodeId: 1}]
+//	3:
+//	  1: [{nodeId: 1}]
+//	  2: [{nodeId: 2}, {nodeId: 4, instance: 1}]
+type DesiredAssociations map[uint8]map[uint8][]Association
+
+// LifelineAssociations returns the DesiredAssociations which point the
+// lifeline (group 1) of each of the nodes at the controller, and nothing else.
+func LifelineAssociations(controllerNodeID uint8, nodeIDs ...uint8) DesiredAssociations {
+	desired := make(DesiredAssociations)
+	for _, nodeID := range nodeIDs {
+		desired[nodeID] = map[uint8][]Association{
+			1: {{NodeID: controllerNodeID}},
+		}
+	}
+	return desired
+}
+
+// AssociationChange is a single association to add to, or remove from, a group
+// of a node.
+type AssociationChange struct {
+	NodeID      uint8
+	GroupIDx    uint8
+	Association Association
+	Add         bool // The association is added if true, otherwise it is removed.
+}
+
+func (c AssociationChange) String() string {
+	op := "remove"
+	if c.Add {
+		op = "add"
+	}
+	return fmt.Sprintf("<Node: %d, Group: %d, %s NodeID: %d, Instance: %d>", c.NodeID, c.GroupIDx, op, c.Association.NodeID, c.Association.Instance)
+}
+
+// AssociationReport is the outcome of AssociationReconciler.Reconcile.
+type AssociationReport struct {
+	Applied []AssociationChange // The changes which were sent to the nodes.
+	Drift   []AssociationChange // The changes still needed after reconciling.
+}
+
+// AssociationReconciler keeps the association groups of the nodes in a
+// network in line with a DesiredAssociations. The Manager must be started, as
+// the reconciler relies on notifications to know when sleeping nodes wake up
+// and when their groups have changed.
+type AssociationReconciler struct {
+	manager *Manager
+	homeID  uint32
+	desired DesiredAssociations
+}
+
+// NewAssociationReconciler returns an AssociationReconciler for the network
+// with the Home ID.
+func (m *Manager) NewAssociationReconciler(homeID uint32, desired DesiredAssociations) *AssociationReconciler {
+	return &AssociationReconciler{
+		manager: m,
+		homeID:  homeID,
+		desired: desired,
+	}
+}
+
+// Diff returns the changes needed to bring the live groups of every node in
+// the DesiredAssociations to the desired state. It returns nil if there is no
+// drift.
+func (r *AssociationReconciler) Diff() []AssociationChange {
+	var changes []AssociationChange
+	for _, nodeID := range r.nodeIDs() {
+		changes = append(changes, r.nodeDiff(nodeID)...)
+	}
+	return changes
+}
+
+// Reconcile applies the changes returned by Diff, one node at a time. A node
+// which is asleep is only sent its changes once it wakes up, so Reconcile may
+// wait for a long time and should be given a ctx with a suitable deadline.
+// The changes to each node are confirmed by the Group notifications sent for
+// them, after which the node is checked for drift again.
+//
+// If ctx is done before every node has been reconciled, ctx.Err() is returned
+// along with the report so far; the nodes which were not reached are reported
+// as drift. An error is also returned if a node does not have one of the
+// groups to be changed, according to GetNumGroups.
+func (r *AssociationReconciler) Reconcile(ctx context.Context) (*AssociationReport, error) {
+	report := &AssociationReport{}
+	if err := r.manager.checkStarted("Reconcile"); err != nil {
+		return report, err
+	}
+	nodeIDs := r.nodeIDs()
+	for i, nodeID := range nodeIDs {
+		applied, err := r.reconcileNode(ctx, nodeID)
+		report.Applied = append(report.Applied, applied...)
+		if err != nil {
+			for _, id := range nodeIDs[i:] {
+				report.Drift = append(report.Drift, r.nodeDiff(id)...)
+			}
+			return report, err
+		}
+		report.Drift = append(report.Drift, r.nodeDiff(nodeID)...)
+	}
+	return report, nil
+}
+
+// Watch reports drift until ctx is done. Each time a Group notification is
+// received for a node in the DesiredAssociations, the node is checked and
+// drift is passed to the handler, which may call Reconcile to correct it.
+// Watch returns ctx.Err(), or an error if the Manager is not started or is
+// destroyed.
+func (r *AssociationReconciler) Watch(ctx context.Context, handler func(drift []AssociationChange)) error {
+	if err := r.manager.checkStarted("Watch"); err != nil {
+		return err
+	}
+	sub := r.manager.SubscribeChan(NotificationFilter{
+		HomeID: r.homeID,
+		Types:  []NotificationType{NotificationTypeGroup},
+	})
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case notification, ok := <-sub.C:
+			if ok == false {
+				return &StateError{Op: "Watch", Err: ErrManagerDestroyed}
+			}
+			if _, ok := r.desired[notification.NodeID]; ok == false {
+				continue
+			}
+			if drift := r.nodeDiff(notification.NodeID); len(drift) > 0 {
+				handler(drift)
+			}
+		}
+	}
+}
+
+// nodeIDs returns the IDs of the nodes in the DesiredAssociations in order.
+func (r *AssociationReconciler) nodeIDs() []uint8 {
+	nodeIDs := make([]uint8, 0, len(r.desired))
+	for nodeID := range r.desired {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	sort.Slice(nodeIDs, func(i, j int) bool {
+		return nodeIDs[i] < nodeIDs[j]
+	})
+	return nodeIDs
+}
+
+// nodeDiff returns the changes needed to bring the live groups of the node to
+// the desired state. Removals are listed before additions, so that a full
+// group has room for its new members.
+func (r *AssociationReconciler) nodeDiff(nodeID uint8) []AssociationChange {
+	groups := r.desired[nodeID]
+	groupIDxs := make([]uint8, 0, len(groups))
+	for groupIDx := range groups {
+		groupIDxs = append(groupIDxs, groupIDx)
+	}
+	sort.Slice(groupIDxs, func(i, j int) bool {
+		return groupIDxs[i] < groupIDxs[j]
+	})
+
+	var removes, adds []AssociationChange
+	for _, groupIDx := range groupIDxs {
+		want := groups[groupIDx]
+		live := r.manager.GetAssociations(r.homeID, nodeID, groupIDx)
+		for _, member := range live {
+			if containsAssociation(want, member) == false {
+				removes = append(removes, AssociationChange{NodeID: nodeID, GroupIDx: groupIDx, Association: member})
+			}
+		}
+		for _, member := range want {
+			if containsAssociation(live, member) == false {
+				adds = append(adds, AssociationChange{NodeID: nodeID, GroupIDx: groupIDx, Association: member, Add: true})
+			}
+		}
+	}
+	return append(removes, adds...)
+}
+
+// reconcileNode waits for the node to be awake, sends the changes it needs and
+// waits for a Group notification for each group changed. Waiting stops early
+// if a message to the node times out or the node is found dead, leaving the
+// drift to be reported.
+func (r *AssociationReconciler) reconcileNode(ctx context.Context, nodeID uint8) ([]AssociationChange, error) {
+	changes := r.nodeDiff(nodeID)
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	// A Group notification never arrives for a group the node does not have.
+	numGroups := r.manager.GetNumGroups(r.homeID, nodeID)
+	for _, change := range changes {
+		if change.GroupIDx == 0 || change.GroupIDx > numGroups {
+			return nil, fmt.Errorf("node %d has no group %d, it has %d groups", nodeID, change.GroupIDx, numGroups)
+		}
+	}
+
+	sub := r.manager.SubscribeChan(NotificationFilter{
+		HomeID: r.homeID,
+		NodeID: nodeID,
+		Types:  []NotificationType{NotificationTypeNotification, NotificationTypeGroup},
+	})
+	defer sub.Unsubscribe()
+
+	awake := r.manager.IsNodeListeningDevice(r.homeID, nodeID) ||
+		r.manager.IsNodeFrequentListeningDevice(r.homeID, nodeID) ||
+		r.manager.IsNodeAwake(r.homeID, nodeID)
+	for awake == false {
+		notification, err := r.next(ctx, sub)
+		if err != nil {
+			return nil, err
+		}
+		if notification.Notification != nil && *notification.Notification == NotificationCodeAwake {
+			awake = true
+		}
+	}
+
+	// The node may have been changed by someone else while it was asleep.
+	changes = r.nodeDiff(nodeID)
+	pending := make(map[uint8]bool)
+	for _, change := range changes {
+		if change.Add {
+			r.manager.AddAssociation(r.homeID, nodeID, change.GroupIDx, change.Association.NodeID, change.Association.Instance)
+		} else {
+			r.manager.RemoveAssociation(r.homeID, nodeID, change.GroupIDx, change.Association.NodeID, change.Association.Instance)
+		}
+		pending[change.GroupIDx] = true
+	}
+
+	for len(pending) > 0 {
+		notification, err := r.next(ctx, sub)
+		if err != nil {
+			return changes, err
+		}
+		switch {
+		case notification.Type == NotificationTypeGroup && notification.GroupIDX != nil:
+			delete(pending, *notification.GroupIDX)
+		case notification.Notification != nil:
+			switch *notification.Notification {
+			case NotificationCodeTimeout, NotificationCodeDead:
+				return changes, nil
+			}
+		}
+	}
+	return changes, nil
+}
+
+// next returns the next notification from the subscription.
+func (r *AssociationReconciler) next(ctx context.Context, sub *Subscription) (*Notification, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case notification, ok := <-sub.C:
+		if ok == false {
+			return nil, &StateError{Op: "Reconcile", Err: ErrManagerDestroyed}
+		}
+		return notification, nil
+	}
+}
+
+// containsAssociation returns true if the association is in the slice.
+func containsAssociation(associations []Association, association Association) bool {
+	for _, a := range associations {
+		if a == association {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,318 @@
+package goopenzwave
+
+// Backend defines the full set of operations the goopenzwave package needs from
+// the underlying Z-Wave implementation. It mirrors the OpenZWave Manager and
+// Options classes (as wrapped by gzw_manager.h and gzw_options.h) using plain
+// Go types.
+//
+// When the package is built with cgo the OpenZWave library is used as the
+// default Backend. Other implementations, such as a simulated network, can be
+// installed with SetBackend so that the package can be used without
+// libopenzwave or a Z-Wave controller, for example when building with
+// CGO_ENABLED=0.
+//
+// Methods returning a trailing bool report whether the underlying call
+// succeeded, in the same way as the OpenZWave library does. The package level
+// functions convert these into errors where appropriate.
+type Backend interface {
+	//
+	// Construction.
+	//
+
+	CreateManager() bool
+	DestroyManager()
+	GetVersionAsString() string
+	GetVersionLongAsString() string
+	GetVersion() (major uint16, minor uint16)
+
+	//
+	// Options.
+	//
+
+	CreateOptions(configPath, userPath, commandLine string) bool
+	DestroyOptions() bool
+	LockOptions() bool
+	AreOptionsLocked() bool
+	AddOptionBool(name string, value bool) bool
+	AddOptionInt(name string, value int32) bool
+	AddOptionLogLevel(name string, value LogLevel) bool
+	AddOptionString(name string, value string, append bool) bool
+	GetOptionAsBool(name string) (bool, bool)
+	GetOptionAsInt(name string) (bool, int32)
+	GetOptionAsString(name string) (bool, string)
+
+	//
+	// Configuration.
+	//
+
+	WriteConfig(homeID uint32)
+
+	//
+	// Drivers.
+	//
+
+	AddDriver(controllerPath string) bool
+	RemoveDriver(controllerPath string) bool
+	GetControllerNodeID(homeID uint32) uint8
+	GetSUCNodeID(homeID uint32) uint8
+	IsPrimaryController(homeID uint32) bool
+	IsStaticUpdateController(homeID uint32) bool
+	IsBridgeController(homeID uint32) bool
+	GetLibraryVersion(homeID uint32) string
+	GetLibraryTypeName(homeID uint32) string
+	GetSendQueueCount(homeID uint32) int32
+	LogDriverStatistics(homeID uint32)
+	GetDriverStatistics(homeID uint32) DriverStatistics
+	GetControllerPath(homeID uint32) string
+
+	//
+	// Polling Z-Wave devices.
+	//
+
+	GetPollInterval() int32
+	SetPollInterval(milliseconds int32, intervalBetweenPolls bool)
+	EnablePoll(homeID uint32, valueID uint64, intensity uint8) bool
+	DisablePoll(homeID uint32, valueID uint64) bool
+	IsPolled(homeID uint32, valueID uint64) bool
+	SetPollIntensity(homeID uint32, valueID uint64, intensity uint8)
+	GetPollIntensity(homeID uint32, valueID uint64) uint8
+
+	//
+	// Node information.
+	//
+
+	RefreshNodeInfo(homeID uint32, nodeID uint8) bool
+	RequestNodeState(homeID uint32, nodeID uint8) bool
+	RequestNodeDynamic(homeID uint32, nodeID uint8) bool
+	IsNodeListeningDevice(homeID uint32, nodeID uint8) bool
+	IsNodeFrequentListeningDevice(homeID uint32, nodeID uint8) bool
+	IsNodeBeamingDevice(homeID uint32, nodeID uint8) bool
+	IsNodeRoutingDevice(homeID uint32, nodeID uint8) bool
+	IsNodeSecurityDevice(homeID uint32, nodeID uint8) bool
+	GetNodeMaxBaudRate(homeID uint32, nodeID uint8) uint32
+	GetNodeVersion(homeID uint32, nodeID uint8) uint8
+	GetNodeSecurity(homeID uint32, nodeID uint8) uint8
+	IsNodeZWavePlus(homeID uint32, nodeID uint8) bool
+	GetNodeBasicType(homeID uint32, nodeID uint8) uint8
+	GetNodeGenericType(homeID uint32, nodeID uint8) uint8
+	GetNodeSpecificType(homeID uint32, nodeID uint8) uint8
+	GetNodeType(homeID uint32, nodeID uint8) string
+	GetNodeNeighbors(homeID uint32, nodeID uint8) []uint8
+	GetNodeManufacturerName(homeID uint32, nodeID uint8) string
+	GetNodeProductName(homeID uint32, nodeID uint8) string
+	GetNodeName(homeID uint32, nodeID uint8) string
+	GetNodeLocation(homeID uint32, nodeID uint8) string
+	GetNodeManufacturerID(homeID uint32, nodeID uint8) string
+	GetNodeProductType(homeID uint32, nodeID uint8) string
+	GetNodeProductID(homeID uint32, nodeID uint8) string
+	SetNodeManufacturerName(homeID uint32, nodeID uint8, manufacturerName string)
+	SetNodeProductName(homeID uint32, nodeID uint8, productName string)
+	SetNodeName(homeID uint32, nodeID uint8, nodeName string)
+	SetNodeLocation(homeID uint32, nodeID uint8, location string)
+	SetNodeOn(homeID uint32, nodeID uint8)
+	SetNodeOff(homeID uint32, nodeID uint8)
+	SetNodeLevel(homeID uint32, nodeID uint8, level uint8)
+	IsNodeInfoReceived(homeID uint32, nodeID uint8) bool
+	GetNodeClassInformation(homeID uint32, nodeID uint8, commandClassID uint8) (bool, string, uint8)
+	IsNodeAwake(homeID uint32, nodeID uint8) bool
+	IsNodeFailed(homeID uint32, nodeID uint8) bool
+	GetNodeQueryStage(homeID uint32, nodeID uint8) string
+	GetNodeDeviceType(homeID uint32, nodeID uint8) uint16
+	GetNodeDeviceTypeString(homeID uint32, nodeID uint8) string
+	GetNodeRole(homeID uint32, nodeID uint8) uint8
+	GetNodeRoleString(homeID uint32, nodeID uint8) string
+	GetNodePlusType(homeID uint32, nodeID uint8) uint8
+	GetNodePlusTypeString(homeID uint32, nodeID uint8) string
+
+	//
+	// Values.
+	//
+
+	GetValueLabel(homeID uint32, valueID uint64) string
+	SetValueLabel(homeID uint32, valueID uint64, value string)
+	GetValueUnits(homeID uint32, valueID uint64) string
+	SetValueUnits(homeID uint32, valueID uint64, value string)
+	GetValueHelp(homeID uint32, valueID uint64) string
+	SetValueHelp(homeID uint32, valueID uint64, value string)
+	GetValueMin(homeID uint32, valueID uint64) int32
+	GetValueMax(homeID uint32, valueID uint64) int32
+	IsValueReadOnly(homeID uint32, valueID uint64) bool
+	IsValueWriteOnly(homeID uint32, valueID uint64) bool
+	IsValueSet(homeID uint32, valueID uint64) bool
+	IsValuePolled(homeID uint32, valueID uint64) bool
+	GetValueAsBool(homeID uint32, valueID uint64) (bool, bool)
+	GetValueAsByte(homeID uint32, valueID uint64) (byte, bool)
+	GetValueAsFloat(homeID uint32, valueID uint64) (float32, bool)
+	GetValueAsInt(homeID uint32, valueID uint64) (int32, bool)
+	GetValueAsShort(homeID uint32, valueID uint64) (int16, bool)
+	GetValueAsString(homeID uint32, valueID uint64) (string, bool)
+	GetValueAsRaw(homeID uint32, valueID uint64) ([]byte, bool)
+	GetValueListSelectionAsString(homeID uint32, valueID uint64) (string, bool)
+	GetValueListSelectionAsInt32(homeID uint32, valueID uint64) (int32, bool)
+	GetValueListItems(homeID uint32, valueID uint64) ([]string, bool)
+	GetValueFloatPrecision(homeID uint32, valueID uint64) (uint8, bool)
+	GetValueAsBitSet(homeID uint32, valueID uint64, pos uint8) (bool, bool)
+	GetBitMask(homeID uint32, valueID uint64) (int32, bool)
+	GetBitSetSize(homeID uint32, valueID uint64) (uint8, bool)
+	SetValueBool(homeID uint32, valueID uint64, value bool) bool
+	SetValueUint8(homeID uint32, valueID uint64, value uint8) bool
+	SetValueFloat(homeID uint32, valueID uint64, value float32) bool
+	SetValueInt32(homeID uint32, valueID uint64, value int32) bool
+	SetValueInt16(homeID uint32, valueID uint64, value int16) bool
+	SetValueBytes(homeID uint32, valueID uint64, value []byte) bool
+	SetValueString(homeID uint32, valueID uint64, value string) bool
+	SetValueListSelection(homeID uint32, valueID uint64, selection string) bool
+	SetValueBitSet(homeID uint32, valueID uint64, pos uint8, value bool) bool
+	SetBitMask(homeID uint32, valueID uint64, mask uint32) bool
+	RefreshValue(homeID uint32, valueID uint64) bool
+	SetChangeVerified(homeID uint32, valueID uint64, verify bool)
+	GetChangeVerified(homeID uint32, valueID uint64) bool
+	PressButton(homeID uint32, valueID uint64) bool
+	ReleaseButton(homeID uint32, valueID uint64) bool
+
+	//
+	// Climate control schedules.
+	//
+
+	GetNumSwitchPoints(homeID uint32, valueID uint64) uint8
+	SetSwitchPoint(homeID uint32, valueID uint64, hours, minutes uint8, setback int8) bool
+	RemoveSwitchPoint(homeID uint32, valueID uint64, hours, minutes uint8) bool
+	ClearSwitchPoints(homeID uint32, valueID uint64)
+	GetSwitchPoint(homeID uint32, valueID uint64, idx uint8) (uint8, uint8, int8, bool)
+
+	//
+	// Switch all.
+	//
+
+	SwitchAllOn(homeID uint32)
+	SwitchAllOff(homeID uint32)
+
+	//
+	// Configuration parameters.
+	//
+
+	SetNodeConfigParam(homeID uint32, nodeID uint8, param uint8, value int32, size uint8) bool
+	RequestNodeConfigParam(homeID uint32, nodeID uint8, param uint8)
+	RequestNodeAllConfigParam(homeID uint32, nodeID uint8)
+	GetNodeStatistics(homeID uint32, nodeID uint8) NodeStatistics
+
+	//
+	// Groups.
+	//
+
+	GetNumGroups(homeID uint32, nodeID uint8) uint8
+	GetAssociations(homeID uint32, nodeID uint8, groupIDx uint8) []Association
+	GetMaxAssociations(homeID uint32, nodeID uint8, groupIDx uint8) uint8
+	GetGroupLabel(homeID uint32, nodeID uint8, groupIDx uint8) string
+	AddAssociation(homeID uint32, nodeID uint8, groupIDx uint8, targetNodeID uint8, instance uint8)
+	RemoveAssociation(homeID uint32, nodeID uint8, groupIDx uint8, targetNodeID uint8, instance uint8)
+
+	//
+	// Notifications.
+	//
+
+	// AddWatcher installs the watcher which must then be called for every
+	// notification the backend generates, until RemoveWatcher is called.
+	AddWatcher(watcher NotificationHandler) bool
+	RemoveWatcher() bool
+
+	//
+	// Logging.
+	//
+
+	// SetLogHandler replaces the OpenZWave log file with the handler, which
+	// must then be called for every line logged at or below the SaveLogLevel
+	// option. A nil handler discards the log.
+	SetLogHandler(handler LogHandler) bool
+
+	//
+	// Controller commands.
+	//
+
+	ResetController(homeID uint32)
+	SoftReset(homeID uint32)
+	CancelControllerCommand(homeID uint32) bool
+
+	//
+	// Network commands.
+	//
+
+	TestNetworkNode(homeID uint32, nodeID uint8, count uint32)
+	TestNetwork(homeID uint32, count uint32)
+	HealNetworkNode(homeID uint32, nodeID uint8, doRR bool)
+	HealNetwork(homeID uint32, doRR bool)
+	AddNode(homeID uint32, doSecurity bool) bool
+	RemoveNode(homeID uint32) bool
+	RemoveFailedNode(homeID uint32, nodeID uint8) bool
+	HasNodeFailed(homeID uint32, nodeID uint8) bool
+	RequestNodeNeighborUpdate(homeID uint32, nodeID uint8) bool
+	AssignReturnRoute(homeID uint32, nodeID uint8) bool
+	DeleteAllReturnRoutes(homeID uint32, nodeID uint8) bool
+	SendNodeInformation(homeID uint32, nodeID uint8) bool
+	CreateNewPrimary(homeID uint32) bool
+	ReceiveConfiguration(homeID uint32) bool
+	ReplaceFailedNode(homeID uint32, nodeID uint8) bool
+	TransferPrimaryRole(homeID uint32) bool
+	RequestNetworkUpdate(homeID uint32, nodeID uint8) bool
+	ReplicationSend(homeID uint32, nodeID uint8) bool
+	CreateButton(homeID uint32, nodeID uint8, buttonID uint8) bool
+	DeleteButton(homeID uint32, nodeID uint8, buttonID uint8) bool
+
+	//
+	// Scene commands.
+	//
+
+	GetNumScenes() uint8
+	GetAllScenes() []uint8
+	RemoveAllScenes(homeID uint32)
+	CreateScene() uint8
+	RemoveScene(sceneID uint8) bool
+	AddSceneValueBool(sceneID uint8, homeID uint32, valueID uint64, value bool) bool
+	AddSceneValueUint8(sceneID uint8, homeID uint32, valueID uint64, value uint8) bool
+	AddSceneValueFloat(sceneID uint8, homeID uint32, valueID uint64, value float32) bool
+	AddSceneValueInt32(sceneID uint8, homeID uint32, valueID uint64, value int32) bool
+	AddSceneValueInt16(sceneID uint8, homeID uint32, valueID uint64, value int16) bool
+	AddSceneValueString(sceneID uint8, homeID uint32, valueID uint64, value string) bool
+	AddSceneValueListSelectionString(sceneID uint8, homeID uint32, valueID uint64, value string) bool
+	AddSceneValueListSelectionInt32(sceneID uint8, homeID uint32, valueID uint64, value int32) bool
+	RemoveSceneValue(sceneID uint8, homeID uint32, valueID uint64) bool
+	SceneGetValues(sceneID uint8) []*ValueID
+	GetSceneValueAsBool(sceneID uint8, homeID uint32, valueID uint64) (bool, bool)
+	GetSceneValueAsByte(sceneID uint8, homeID uint32, valueID uint64) (byte, bool)
+	GetSceneValueAsFloat(sceneID uint8, homeID uint32, valueID uint64) (float32, bool)
+	GetSceneValueAsInt(sceneID uint8, homeID uint32, valueID uint64) (int32, bool)
+	GetSceneValueAsShort(sceneID uint8, homeID uint32, valueID uint64) (int16, bool)
+	GetSceneValueAsString(sceneID uint8, homeID uint32, valueID uint64) (string, bool)
+	GetSceneValueListSelectionString(sceneID uint8, homeID uint32, valueID uint64) (string, bool)
+	GetSceneValueListSelectionInt32(sceneID uint8, homeID uint32, valueID uint64) (int32, bool)
+	SetSceneValueBool(sceneID uint8, homeID uint32, valueID uint64, value bool) bool
+	SetSceneValueUint8(sceneID uint8, homeID uint32, valueID uint64, value uint8) bool
+	SetSceneValueFloat(sceneID uint8, homeID uint32, valueID uint64, value float32) bool
+	SetSceneValueInt32(sceneID uint8, homeID uint32, valueID uint64, value int32) bool
+	SetSceneValueInt16(sceneID uint8, homeID uint32, valueID uint64, value int16) bool
+	SetSceneValueString(sceneID uint8, homeID uint32, valueID uint64, value string) bool
+	SetSceneValueListSelectionString(sceneID uint8, homeID uint32, valueID uint64, value string) bool
+	SetSceneValueListSelectionInt32(sceneID uint8, homeID uint32, valueID uint64, value int32) bool
+	GetSceneLabel(sceneID uint8) string
+	SetSceneLabel(sceneID uint8, value string)
+	SceneExists(sceneID uint8) bool
+	ActivateScene(sceneID uint8) bool
+}
+
+// backend is the Backend used by all of the package level functions. When
+// built with cgo it defaults to the OpenZWave library.
+var backend Backend
+
+// SetBackend installs the Backend to be used by the package level functions.
+// It must be called before the Options are created and the package is Started,
+// and must not be called again until the package has been Stopped and the
+// Options destroyed.
+func SetBackend(b Backend) {
+	backend = b
+}
+
+// GetBackend returns the Backend currently in use by the package level
+// functions, or nil if none has been installed.
+func GetBackend() Backend {
+	return backend
+}
@@ -0,0 +1,1420 @@
+package goopenzwave
+
+// #cgo pkg-config: libopenzwave
+// #cgo openzwave16 CPPFLAGS: -DGZW_OPENZWAVE_1_6
+// #include "gzw_log.h"
+// #include "gzw_manager.h"
+// #include "gzw_notification.h"
+// #include "gzw_options.h"
+// #include "gzw_valueid.h"
+// #include "zwbytes.h"
+// #include "zwlist.h"
+// #include <stdlib.h>
+import "C"
+import (
+	"sync/atomic"
+	"time"
+	"unsafe"
+)
+
+// cgoBackend is the Backend implemented by the C++ OpenZWave library, via the
+// C wrappers in gzw_manager.h and gzw_options.h.
+type cgoBackend struct {
+	manager C.manager_t
+	options C.options_t
+
+	// logInstalled is true once the OpenZWave log has been pointed at
+	// goLogCB. OpenZWave removes it when the Manager is destroyed.
+	logInstalled bool
+}
+
+// cgoWatcher is the NotificationHandler installed by cgoBackend.AddWatcher. It
+// is called by goNotificationCB for every notification from OpenZWave. It is
+// atomic as the callbacks run on the OpenZWave threads.
+var cgoWatcher atomic.Pointer[NotificationHandler]
+
+// cgoLogHandler is the LogHandler installed by cgoBackend.SetLogHandler. It is
+// called by goLogCB for every line OpenZWave logs.
+var cgoLogHandler atomic.Pointer[LogHandler]
+
+func init() {
+	backend = &cgoBackend{}
+}
+
+// withValueID creates a C valueid_t for the duration of fn.
+func withValueID(homeID uint32, valueID uint64, fn func(cvalueid C.valueid_t)) {
+	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
+	defer C.valueid_free(cvalueid)
+	fn(cvalueid)
+}
+
+// goStringFree converts the C string to a Go string and frees the C string.
+func goStringFree(cstr *C.char) string {
+	defer C.free(unsafe.Pointer(cstr))
+	return C.GoString(cstr)
+}
+
+//
+// Construction.
+//
+
+func (b *cgoBackend) CreateManager() bool {
+	b.manager = C.manager_create()
+	return b.manager != nil
+}
+
+func (b *cgoBackend) DestroyManager() {
+	C.manager_destroy()
+	b.manager = nil
+	b.logInstalled = false
+	cgoLogHandler.Store(nil)
+}
+
+func (b *cgoBackend) GetVersionAsString() string {
+	return goStringFree(C.manager_getVersionAsString())
+}
+
+func (b *cgoBackend) GetVersionLongAsString() string {
+	return goStringFree(C.manager_getVersionLongAsString())
+}
+
+func (b *cgoBackend) GetVersion() (uint16, uint16) {
+	var cMajor C.uint16_t
+	var cMinor C.uint16_t
+	C.manager_getVersion(&cMajor, &cMinor)
+	return uint16(cMajor), uint16(cMinor)
+}
+
+//
+// Options.
+//
+
+func (b *cgoBackend) CreateOptions(configPath, userPath, commandLine string) bool {
+	cConfigPath := C.CString(configPath)
+	cUserPath := C.CString(userPath)
+	cCommandLine := C.CString(commandLine)
+	b.options = C.options_create(cConfigPath, cUserPath, cCommandLine)
+	C.free(unsafe.Pointer(cConfigPath))
+	C.free(unsafe.Pointer(cUserPath))
+	C.free(unsafe.Pointer(cCommandLine))
+	return b.options != nil
+}
+
+func (b *cgoBackend) DestroyOptions() bool {
+	if bool(C.options_destroy()) {
+		b.options = nil
+		return true
+	}
+	return false
+}
+
+func (b *cgoBackend) LockOptions() bool {
+	return bool(C.options_lock(b.options))
+}
+
+func (b *cgoBackend) AreOptionsLocked() bool {
+	return bool(C.options_areLocked(b.options))
+}
+
+func (b *cgoBackend) AddOptionBool(name string, value bool) bool {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	return bool(C.options_addOptionBool(b.options, cName, C.bool(value)))
+}
+
+func (b *cgoBackend) AddOptionInt(name string, value int32) bool {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	return bool(C.options_addOptionInt(b.options, cName, C.int32_t(value)))
+}
+
+func (b *cgoBackend) AddOptionLogLevel(name string, value LogLevel) bool {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	return bool(C.options_addOptionLogLevel(b.options, cName, C.loglevel_t(value)))
+}
+
+func (b *cgoBackend) AddOptionString(name string, value string, append bool) bool {
+	cName := C.CString(name)
+	cValue := C.CString(value)
+	defer C.free(unsafe.Pointer(cName))
+	defer C.free(unsafe.Pointer(cValue))
+	return bool(C.options_addOptionString(b.options, cName, cValue, C.bool(append)))
+}
+
+func (b *cgoBackend) GetOptionAsBool(name string) (bool, bool) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	var cValue C.bool
+	result := bool(C.options_getOptionAsBool(b.options, cName, &cValue))
+	return result, bool(cValue)
+}
+
+func (b *cgoBackend) GetOptionAsInt(name string) (bool, int32) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	var cValue C.int32_t
+	result := bool(C.options_getOptionAsInt(b.options, cName, &cValue))
+	return result, int32(cValue)
+}
+
+func (b *cgoBackend) GetOptionAsString(name string) (bool, string) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	var cstr *C.char
+	result := bool(C.options_getOptionAsString(b.options, cName, &cstr))
+	return result, goStringFree(cstr)
+}
+
+//
+// Configuration.
+//
+
+func (b *cgoBackend) WriteConfig(homeID uint32) {
+	C.manager_writeConfig(b.manager, C.uint32_t(homeID))
+}
+
+//
+// Drivers.
+//
+
+func (b *cgoBackend) AddDriver(controllerPath string) bool {
+	cControllerPath := C.CString(controllerPath)
+	defer C.free(unsafe.Pointer(cControllerPath))
+	return bool(C.manager_addDriver(b.manager, cControllerPath))
+}
+
+func (b *cgoBackend) RemoveDriver(controllerPath string) bool {
+	cControllerPath := C.CString(controllerPath)
+	defer C.free(unsafe.Pointer(cControllerPath))
+	return bool(C.manager_removeDriver(b.manager, cControllerPath))
+}
+
+func (b *cgoBackend) GetControllerNodeID(homeID uint32) uint8 {
+	return uint8(C.manager_getControllerNodeId(b.manager, C.uint32_t(homeID)))
+}
+
+func (b *cgoBackend) GetSUCNodeID(homeID uint32) uint8 {
+	return uint8(C.manager_getSUCNodeId(b.manager, C.uint32_t(homeID)))
+}
+
+func (b *cgoBackend) IsPrimaryController(homeID uint32) bool {
+	return bool(C.manager_isPrimaryController(b.manager, C.uint32_t(homeID)))
+}
+
+func (b *cgoBackend) IsStaticUpdateController(homeID uint32) bool {
+	return bool(C.manager_isStaticUpdateController(b.manager, C.uint32_t(homeID)))
+}
+
+func (b *cgoBackend) IsBridgeController(homeID uint32) bool {
+	return bool(C.manager_isBridgeController(b.manager, C.uint32_t(homeID)))
+}
+
+func (b *cgoBackend) GetLibraryVersion(homeID uint32) string {
+	return goStringFree(C.manager_getLibraryVersion(b.manager, C.uint32_t(homeID)))
+}
+
+func (b *cgoBackend) GetLibraryTypeName(homeID uint32) string {
+	return goStringFree(C.manager_getLibraryTypeName(b.manager, C.uint32_t(homeID)))
+}
+
+func (b *cgoBackend) GetSendQueueCount(homeID uint32) int32 {
+	return int32(C.manager_getSendQueueCount(b.manager, C.uint32_t(homeID)))
+}
+
+func (b *cgoBackend) LogDriverStatistics(homeID uint32) {
+	C.manager_logDriverStatistics(b.manager, C.uint32_t(homeID))
+}
+
+func (b *cgoBackend) GetDriverStatistics(homeID uint32) DriverStatistics {
+	var data C.driver_data_t
+	C.manager_getDriverStatistics(b.manager, C.uint32_t(homeID), &data)
+	return DriverStatistics{
+		SOFCount:            uint32(data.sofCnt),
+		ACKWaiting:          uint32(data.ackWaiting),
+		ReadAborts:          uint32(data.readAborts),
+		BadChecksum:         uint32(data.badChecksum),
+		ReadCount:           uint32(data.readCnt),
+		WriteCount:          uint32(data.writeCnt),
+		CANCount:            uint32(data.canCnt),
+		NAKCount:            uint32(data.nakCnt),
+		ACKCount:            uint32(data.ackCnt),
+		OOFCount:            uint32(data.oofCnt),
+		Dropped:             uint32(data.dropped),
+		Retries:             uint32(data.retries),
+		Callbacks:           uint32(data.callbacks),
+		BadRoutes:           uint32(data.badroutes),
+		NoACK:               uint32(data.noack),
+		NetBusy:             uint32(data.netbusy),
+		NotIdle:             uint32(data.notidle),
+		NonDelivery:         uint32(data.nondelivery),
+		RoutedBusy:          uint32(data.routedbusy),
+		BroadcastReadCount:  uint32(data.broadcastReadCnt),
+		BroadcastWriteCount: uint32(data.broadcastWriteCnt),
+	}
+}
+
+func (b *cgoBackend) GetControllerPath(homeID uint32) string {
+	return goStringFree(C.manager_getControllerPath(b.manager, C.uint32_t(homeID)))
+}
+
+//
+// Polling Z-Wave devices.
+//
+
+func (b *cgoBackend) GetPollInterval() int32 {
+	return int32(C.manager_getPollInterval(b.manager))
+}
+
+func (b *cgoBackend) SetPollInterval(milliseconds int32, intervalBetweenPolls bool) {
+	C.manager_setPollInterval(b.manager, C.int32_t(milliseconds), C.bool(intervalBetweenPolls))
+}
+
+func (b *cgoBackend) EnablePoll(homeID uint32, valueID uint64, intensity uint8) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		ok = bool(C.manager_enablePoll(b.manager, cvalueid, C.uint8_t(intensity)))
+	})
+	return
+}
+
+func (b *cgoBackend) DisablePoll(homeID uint32, valueID uint64) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		ok = bool(C.manager_disablePoll(b.manager, cvalueid))
+	})
+	return
+}
+
+func (b *cgoBackend) IsPolled(homeID uint32, valueID uint64) (polled bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		polled = bool(C.manager_isPolled(b.manager, cvalueid))
+	})
+	return
+}
+
+func (b *cgoBackend) SetPollIntensity(homeID uint32, valueID uint64, intensity uint8) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		C.manager_setPollIntensity(b.manager, cvalueid, C.uint8_t(intensity))
+	})
+}
+
+func (b *cgoBackend) GetPollIntensity(homeID uint32, valueID uint64) (intensity uint8) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		intensity = uint8(C.manager_getPollIntensity(b.manager, cvalueid))
+	})
+	return
+}
+
+//
+// Node information.
+//
+
+func (b *cgoBackend) RefreshNodeInfo(homeID uint32, nodeID uint8) bool {
+	return bool(C.manager_refreshNodeInfo(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) RequestNodeState(homeID uint32, nodeID uint8) bool {
+	return bool(C.manager_requestNodeState(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) RequestNodeDynamic(homeID uint32, nodeID uint8) bool {
+	return bool(C.manager_requestNodeDynamic(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) IsNodeListeningDevice(homeID uint32, nodeID uint8) bool {
+	return bool(C.manager_isNodeListeningDevice(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) IsNodeFrequentListeningDevice(homeID uint32, nodeID uint8) bool {
+	return bool(C.manager_isNodeFrequentListeningDevice(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) IsNodeBeamingDevice(homeID uint32, nodeID uint8) bool {
+	return bool(C.manager_isNodeBeamingDevice(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) IsNodeRoutingDevice(homeID uint32, nodeID uint8) bool {
+	return bool(C.manager_isNodeRoutingDevice(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) IsNodeSecurityDevice(homeID uint32, nodeID uint8) bool {
+	return bool(C.manager_isNodeSecurityDevice(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) GetNodeMaxBaudRate(homeID uint32, nodeID uint8) uint32 {
+	return uint32(C.manager_getNodeMaxBaudRate(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) GetNodeVersion(homeID uint32, nodeID uint8) uint8 {
+	return uint8(C.manager_getNodeVersion(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) GetNodeSecurity(homeID uint32, nodeID uint8) uint8 {
+	return uint8(C.manager_getNodeSecurity(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) IsNodeZWavePlus(homeID uint32, nodeID uint8) bool {
+	return bool(C.manager_isNodeZWavePlus(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) GetNodeBasicType(homeID uint32, nodeID uint8) uint8 {
+	return uint8(C.manager_getNodeBasic(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) GetNodeGenericType(homeID uint32, nodeID uint8) uint8 {
+	return uint8(C.manager_getNodeGeneric(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) GetNodeSpecificType(homeID uint32, nodeID uint8) uint8 {
+	return uint8(C.manager_getNodeSpecific(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) GetNodeType(homeID uint32, nodeID uint8) string {
+	return goStringFree(C.manager_getNodeType(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) GetNodeNeighbors(homeID uint32, nodeID uint8) []uint8 {
+	zwbytes := C.zwbytes_new()
+	defer C.zwbytes_free(zwbytes)
+	C.manager_getNodeNeighbors(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID), zwbytes)
+	if zwbytes.size == 0 {
+		return nil
+	}
+	return C.GoBytes(unsafe.Pointer(zwbytes.data), C.int(zwbytes.size))
+}
+
+func (b *cgoBackend) GetNodeManufacturerName(homeID uint32, nodeID uint8) string {
+	return goStringFree(C.manager_getNodeManufacturerName(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) GetNodeProductName(homeID uint32, nodeID uint8) string {
+	return goStringFree(C.manager_getNodeProductName(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) GetNodeName(homeID uint32, nodeID uint8) string {
+	return goStringFree(C.manager_getNodeName(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) GetNodeLocation(homeID uint32, nodeID uint8) string {
+	return goStringFree(C.manager_getNodeLocation(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) GetNodeManufacturerID(homeID uint32, nodeID uint8) string {
+	return goStringFree(C.manager_getNodeManufacturerId(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) GetNodeProductType(homeID uint32, nodeID uint8) string {
+	return goStringFree(C.manager_getNodeProductType(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) GetNodeProductID(homeID uint32, nodeID uint8) string {
+	return goStringFree(C.manager_getNodeProductId(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) SetNodeManufacturerName(homeID uint32, nodeID uint8, manufacturerName string) {
+	cstr := C.CString(manufacturerName)
+	defer C.free(unsafe.Pointer(cstr))
+	C.manager_setNodeManufacturerName(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID), cstr)
+}
+
+func (b *cgoBackend) SetNodeProductName(homeID uint32, nodeID uint8, productName string) {
+	cstr := C.CString(productName)
+	defer C.free(unsafe.Pointer(cstr))
+	C.manager_setNodeProductName(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID), cstr)
+}
+
+func (b *cgoBackend) SetNodeName(homeID uint32, nodeID uint8, nodeName string) {
+	cstr := C.CString(nodeName)
+	defer C.free(unsafe.Pointer(cstr))
+	C.manager_setNodeName(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID), cstr)
+}
+
+func (b *cgoBackend) SetNodeLocation(homeID uint32, nodeID uint8, location string) {
+	cstr := C.CString(location)
+	defer C.free(unsafe.Pointer(cstr))
+	C.manager_setNodeLocation(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID), cstr)
+}
+
+func (b *cgoBackend) SetNodeOn(homeID uint32, nodeID uint8) {
+	C.manager_setNodeOn(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID))
+}
+
+func (b *cgoBackend) SetNodeOff(homeID uint32, nodeID uint8) {
+	C.manager_setNodeOff(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID))
+}
+
+func (b *cgoBackend) SetNodeLevel(homeID uint32, nodeID uint8, level uint8) {
+	C.manager_setNodeLevel(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID), C.uint8_t(level))
+}
+
+func (b *cgoBackend) IsNodeInfoReceived(homeID uint32, nodeID uint8) bool {
+	return bool(C.manager_isNodeInfoReceived(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) GetNodeClassInformation(homeID uint32, nodeID uint8, commandClassID uint8) (bool, string, uint8) {
+	var cClassName *C.char
+	var cClassVersion C.uint8_t
+	result := bool(C.manager_getNodeClassInformation(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID), C.uint8_t(commandClassID), &cClassName, &cClassVersion))
+	return result, goStringFree(cClassName), uint8(cClassVersion)
+}
+
+func (b *cgoBackend) IsNodeAwake(homeID uint32, nodeID uint8) bool {
+	return bool(C.manager_isNodeAwake(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) IsNodeFailed(homeID uint32, nodeID uint8) bool {
+	return bool(C.manager_isNodeFailed(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) GetNodeQueryStage(homeID uint32, nodeID uint8) string {
+	return goStringFree(C.manager_getNodeQueryStage(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) GetNodeDeviceType(homeID uint32, nodeID uint8) uint16 {
+	return uint16(C.manager_getNodeDeviceType(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) GetNodeDeviceTypeString(homeID uint32, nodeID uint8) string {
+	return goStringFree(C.manager_getNodeDeviceTypeString(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) GetNodeRole(homeID uint32, nodeID uint8) uint8 {
+	return uint8(C.manager_getNodeRole(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) GetNodeRoleString(homeID uint32, nodeID uint8) string {
+	return goStringFree(C.manager_getNodeRoleString(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) GetNodePlusType(homeID uint32, nodeID uint8) uint8 {
+	return uint8(C.manager_getNodePlusType(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) GetNodePlusTypeString(homeID uint32, nodeID uint8) string {
+	return goStringFree(C.manager_getNodePlusTypeString(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+//
+// Values.
+//
+
+func (b *cgoBackend) GetValueLabel(homeID uint32, valueID uint64) (label string) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		label = goStringFree(C.manager_getValueLabel(b.manager, cvalueid))
+	})
+	return
+}
+
+func (b *cgoBackend) SetValueLabel(homeID uint32, valueID uint64, value string) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		cstr := C.CString(value)
+		defer C.free(unsafe.Pointer(cstr))
+		C.manager_setValueLabel(b.manager, cvalueid, cstr)
+	})
+}
+
+func (b *cgoBackend) GetValueUnits(homeID uint32, valueID uint64) (units string) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		units = goStringFree(C.manager_getValueUnits(b.manager, cvalueid))
+	})
+	return
+}
+
+func (b *cgoBackend) SetValueUnits(homeID uint32, valueID uint64, value string) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		cstr := C.CString(value)
+		defer C.free(unsafe.Pointer(cstr))
+		C.manager_setValueUnits(b.manager, cvalueid, cstr)
+	})
+}
+
+func (b *cgoBackend) GetValueHelp(homeID uint32, valueID uint64) (help string) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		help = goStringFree(C.manager_getValueHelp(b.manager, cvalueid))
+	})
+	return
+}
+
+func (b *cgoBackend) SetValueHelp(homeID uint32, valueID uint64, value string) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		cstr := C.CString(value)
+		defer C.free(unsafe.Pointer(cstr))
+		C.manager_setValueHelp(b.manager, cvalueid, cstr)
+	})
+}
+
+func (b *cgoBackend) GetValueMin(homeID uint32, valueID uint64) (min int32) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		min = int32(C.manager_getValueMin(b.manager, cvalueid))
+	})
+	return
+}
+
+func (b *cgoBackend) GetValueMax(homeID uint32, valueID uint64) (max int32) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		max = int32(C.manager_getValueMax(b.manager, cvalueid))
+	})
+	return
+}
+
+func (b *cgoBackend) IsValueReadOnly(homeID uint32, valueID uint64) (readOnly bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		readOnly = bool(C.manager_isValueReadOnly(b.manager, cvalueid))
+	})
+	return
+}
+
+func (b *cgoBackend) IsValueWriteOnly(homeID uint32, valueID uint64) (writeOnly bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		writeOnly = bool(C.manager_isValueWriteOnly(b.manager, cvalueid))
+	})
+	return
+}
+
+func (b *cgoBackend) IsValueSet(homeID uint32, valueID uint64) (set bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		set = bool(C.manager_isValueSet(b.manager, cvalueid))
+	})
+	return
+}
+
+func (b *cgoBackend) IsValuePolled(homeID uint32, valueID uint64) (polled bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		polled = bool(C.manager_isValuePolled(b.manager, cvalueid))
+	})
+	return
+}
+
+func (b *cgoBackend) GetValueAsBool(homeID uint32, valueID uint64) (value bool, ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		var cbool C.bool
+		ok = bool(C.manager_getValueAsBool(b.manager, cvalueid, &cbool))
+		value = bool(cbool)
+	})
+	return
+}
+
+func (b *cgoBackend) GetValueAsByte(homeID uint32, valueID uint64) (value byte, ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		var cbyte C.uint8_t
+		ok = bool(C.manager_getValueAsByte(b.manager, cvalueid, &cbyte))
+		value = byte(cbyte)
+	})
+	return
+}
+
+func (b *cgoBackend) GetValueAsFloat(homeID uint32, valueID uint64) (value float32, ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		var cfloat C.float
+		ok = bool(C.manager_getValueAsFloat(b.manager, cvalueid, &cfloat))
+		value = float32(cfloat)
+	})
+	return
+}
+
+func (b *cgoBackend) GetValueAsInt(homeID uint32, valueID uint64) (value int32, ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		var cint C.int32_t
+		ok = bool(C.manager_getValueAsInt(b.manager, cvalueid, &cint))
+		value = int32(cint)
+	})
+	return
+}
+
+func (b *cgoBackend) GetValueAsShort(homeID uint32, valueID uint64) (value int16, ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		var cshort C.int16_t
+		ok = bool(C.manager_getValueAsShort(b.manager, cvalueid, &cshort))
+		value = int16(cshort)
+	})
+	return
+}
+
+func (b *cgoBackend) GetValueAsString(homeID uint32, valueID uint64) (value string, ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		var cstr *C.char
+		ok = bool(C.manager_getValueAsString(b.manager, cvalueid, &cstr))
+		value = goStringFree(cstr)
+	})
+	return
+}
+
+func (b *cgoBackend) GetValueAsRaw(homeID uint32, valueID uint64) (value []byte, ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		zwbytes := C.zwbytes_new()
+		defer C.zwbytes_free(zwbytes)
+		ok = bool(C.manager_getValueAsRaw(b.manager, cvalueid, zwbytes))
+		if ok == false {
+			return
+		}
+		value = make([]byte, zwbytes.size)
+		for i := 0; i < int(zwbytes.size); i++ {
+			value[i] = byte(C.zwbytes_at(zwbytes, C.size_t(i)))
+		}
+	})
+	return
+}
+
+func (b *cgoBackend) GetValueListSelectionAsString(homeID uint32, valueID uint64) (value string, ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		var cstr *C.char
+		ok = bool(C.manager_getValueListSelectionAsString(b.manager, cvalueid, &cstr))
+		value = goStringFree(cstr)
+	})
+	return
+}
+
+func (b *cgoBackend) GetValueListSelectionAsInt32(homeID uint32, valueID uint64) (value int32, ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		var cint C.int32_t
+		ok = bool(C.manager_getValueListSelectionAsInt32(b.manager, cvalueid, &cint))
+		value = int32(cint)
+	})
+	return
+}
+
+func (b *cgoBackend) GetValueListItems(homeID uint32, valueID uint64) (value []string, ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		var clist *C.zwlist_t
+		ok = bool(C.manager_getValueListItems(b.manager, cvalueid, &clist))
+		if ok == false {
+			return
+		}
+		defer C.zwlist_free(clist)
+		value = make([]string, int(C.zwlist_size(clist)))
+		for i := range value {
+			value[i] = goStringFree(C.zwlist_at(clist, C.size_t(i)))
+		}
+	})
+	return
+}
+
+func (b *cgoBackend) GetValueFloatPrecision(homeID uint32, valueID uint64) (value uint8, ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		var cprecision C.uint8_t
+		ok = bool(C.manager_getValueFloatPrecision(b.manager, cvalueid, &cprecision))
+		value = uint8(cprecision)
+	})
+	return
+}
+
+func (b *cgoBackend) GetValueAsBitSet(homeID uint32, valueID uint64, pos uint8) (value bool, ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		var cbool C.bool
+		ok = bool(C.manager_getValueAsBitSet(b.manager, cvalueid, C.uint8_t(pos), &cbool))
+		value = bool(cbool)
+	})
+	return
+}
+
+func (b *cgoBackend) GetBitMask(homeID uint32, valueID uint64) (value int32, ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		var cint C.int32_t
+		ok = bool(C.manager_getBitMask(b.manager, cvalueid, &cint))
+		value = int32(cint)
+	})
+	return
+}
+
+func (b *cgoBackend) GetBitSetSize(homeID uint32, valueID uint64) (value uint8, ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		var csize C.uint8_t
+		ok = bool(C.manager_getBitSetSize(b.manager, cvalueid, &csize))
+		value = uint8(csize)
+	})
+	return
+}
+
+func (b *cgoBackend) SetValueBool(homeID uint32, valueID uint64, value bool) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		ok = bool(C.manager_setValueBool(b.manager, cvalueid, C.bool(value)))
+	})
+	return
+}
+
+func (b *cgoBackend) SetValueUint8(homeID uint32, valueID uint64, value uint8) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		ok = bool(C.manager_setValueUint8(b.manager, cvalueid, C.uint8_t(value)))
+	})
+	return
+}
+
+func (b *cgoBackend) SetValueFloat(homeID uint32, valueID uint64, value float32) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		ok = bool(C.manager_setValueFloat(b.manager, cvalueid, C.float(value)))
+	})
+	return
+}
+
+func (b *cgoBackend) SetValueInt32(homeID uint32, valueID uint64, value int32) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		ok = bool(C.manager_setValueInt32(b.manager, cvalueid, C.int32_t(value)))
+	})
+	return
+}
+
+func (b *cgoBackend) SetValueInt16(homeID uint32, valueID uint64, value int16) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		ok = bool(C.manager_setValueInt16(b.manager, cvalueid, C.int16_t(value)))
+	})
+	return
+}
+
+func (b *cgoBackend) SetValueBytes(homeID uint32, valueID uint64, value []byte) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		zwbytes := C.zwbytes_new()
+		defer C.zwbytes_free(zwbytes)
+		C.zwbytes_reserve(zwbytes, C.size_t(len(value)))
+		for i := range value {
+			C.zwbytes_set(zwbytes, C.size_t(i), C.uint8_t(value[i]))
+		}
+		ok = bool(C.manager_setValueBytes(b.manager, cvalueid, zwbytes))
+	})
+	return
+}
+
+func (b *cgoBackend) SetValueString(homeID uint32, valueID uint64, value string) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		cstr := C.CString(value)
+		defer C.free(unsafe.Pointer(cstr))
+		ok = bool(C.manager_setValueString(b.manager, cvalueid, cstr))
+	})
+	return
+}
+
+func (b *cgoBackend) SetValueListSelection(homeID uint32, valueID uint64, selection string) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		cstr := C.CString(selection)
+		defer C.free(unsafe.Pointer(cstr))
+		ok = bool(C.manager_setValueListSelection(b.manager, cvalueid, cstr))
+	})
+	return
+}
+
+func (b *cgoBackend) SetValueBitSet(homeID uint32, valueID uint64, pos uint8, value bool) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		ok = bool(C.manager_setValueBitSet(b.manager, cvalueid, C.uint8_t(pos), C.bool(value)))
+	})
+	return
+}
+
+func (b *cgoBackend) SetBitMask(homeID uint32, valueID uint64, mask uint32) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		ok = bool(C.manager_setBitMask(b.manager, cvalueid, C.uint32_t(mask)))
+	})
+	return
+}
+
+func (b *cgoBackend) RefreshValue(homeID uint32, valueID uint64) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		ok = bool(C.manager_refreshValue(b.manager, cvalueid))
+	})
+	return
+}
+
+func (b *cgoBackend) SetChangeVerified(homeID uint32, valueID uint64, verify bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		C.manager_setChangeVerified(b.manager, cvalueid, C.bool(verify))
+	})
+}
+
+func (b *cgoBackend) GetChangeVerified(homeID uint32, valueID uint64) (verify bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		verify = bool(C.manager_getChangeVerified(b.manager, cvalueid))
+	})
+	return
+}
+
+func (b *cgoBackend) PressButton(homeID uint32, valueID uint64) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		ok = bool(C.manager_pressButton(b.manager, cvalueid))
+	})
+	return
+}
+
+func (b *cgoBackend) ReleaseButton(homeID uint32, valueID uint64) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		ok = bool(C.manager_releaseButton(b.manager, cvalueid))
+	})
+	return
+}
+
+//
+// Climate control schedules.
+//
+
+func (b *cgoBackend) GetNumSwitchPoints(homeID uint32, valueID uint64) (count uint8) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		count = uint8(C.manager_getNumSwitchPoints(b.manager, cvalueid))
+	})
+	return
+}
+
+func (b *cgoBackend) SetSwitchPoint(homeID uint32, valueID uint64, hours, minutes uint8, setback int8) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		ok = bool(C.manager_setSwitchPoint(b.manager, cvalueid, C.uint8_t(hours), C.uint8_t(minutes), C.int8_t(setback)))
+	})
+	return
+}
+
+func (b *cgoBackend) RemoveSwitchPoint(homeID uint32, valueID uint64, hours, minutes uint8) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		ok = bool(C.manager_removeSwitchPoint(b.manager, cvalueid, C.uint8_t(hours), C.uint8_t(minutes)))
+	})
+	return
+}
+
+func (b *cgoBackend) ClearSwitchPoints(homeID uint32, valueID uint64) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		C.manager_clearSwitchPoints(b.manager, cvalueid)
+	})
+}
+
+func (b *cgoBackend) GetSwitchPoint(homeID uint32, valueID uint64, idx uint8) (hours uint8, minutes uint8, setback int8, ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		var chours C.uint8_t
+		var cminutes C.uint8_t
+		var csetback C.int8_t
+		ok = bool(C.manager_getSwitchPoint(b.manager, cvalueid, C.uint8_t(idx), &chours, &cminutes, &csetback))
+		hours, minutes, setback = uint8(chours), uint8(cminutes), int8(csetback)
+	})
+	return
+}
+
+//
+// Switch all.
+//
+
+func (b *cgoBackend) SwitchAllOn(homeID uint32) {
+	C.manager_switchAllOn(b.manager, C.uint32_t(homeID))
+}
+
+func (b *cgoBackend) SwitchAllOff(homeID uint32) {
+	C.manager_switchAllOff(b.manager, C.uint32_t(homeID))
+}
+
+//
+// Configuration parameters.
+//
+
+func (b *cgoBackend) SetNodeConfigParam(homeID uint32, nodeID uint8, param uint8, value int32, size uint8) bool {
+	return bool(C.manager_setConfigParam(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID), C.uint8_t(param), C.int32_t(value), C.uint8_t(size)))
+}
+
+func (b *cgoBackend) RequestNodeConfigParam(homeID uint32, nodeID uint8, param uint8) {
+	C.manager_requestConfigParam(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID), C.uint8_t(param))
+}
+
+func (b *cgoBackend) RequestNodeAllConfigParam(homeID uint32, nodeID uint8) {
+	C.manager_requestAllConfigParams(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID))
+}
+
+func (b *cgoBackend) GetNodeStatistics(homeID uint32, nodeID uint8) NodeStatistics {
+	var data C.node_data_t
+	C.manager_getNodeStatistics(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID), &data)
+	stats := NodeStatistics{
+		SentCount:           uint32(data.sentCnt),
+		SentFailed:          uint32(data.sentFailed),
+		Retries:             uint32(data.retries),
+		ReceivedCount:       uint32(data.receivedCnt),
+		ReceivedDuplicates:  uint32(data.receivedDups),
+		ReceivedUnsolicited: uint32(data.receivedUnsolicited),
+		LastSent:            parseStatisticsTime(C.GoString(&data.sentTS[0])),
+		LastReceived:        parseStatisticsTime(C.GoString(&data.receivedTS[0])),
+		LastRequestRTT:      time.Duration(data.lastRequestRTT) * time.Millisecond,
+		AverageRequestRTT:   time.Duration(data.averageRequestRTT) * time.Millisecond,
+		LastResponseRTT:     time.Duration(data.lastResponseRTT) * time.Millisecond,
+		AverageResponseRTT:  time.Duration(data.averageResponseRTT) * time.Millisecond,
+		Quality:             uint8(data.quality),
+	}
+
+	// OpenZWave copies a fixed size buffer from the start of the received
+	// ApplicationCommandHandler frame, after the SOF and frame length:
+	// type, function, status, node ID, command length, the command and then
+	// the checksum. Anything after that is left over from earlier messages.
+	message := C.GoBytes(unsafe.Pointer(&data.lastReceivedMessage[0]), C.int(len(data.lastReceivedMessage)))
+	if message[1] != 0 {
+		length := 5 + int(message[4]) + 1
+		if length > len(message) {
+			length = len(message)
+		}
+		stats.LastReceivedMessage = message[:length]
+	}
+
+	for i := 0; i < int(data.ccDataCount); i++ {
+		cc := data.ccData[i]
+		stats.CommandClasses = append(stats.CommandClasses, CommandClassStatistics{
+			CommandClassID: uint8(cc.commandClassId),
+			SentCount:      uint32(cc.sentCnt),
+			ReceivedCount:  uint32(cc.receivedCnt),
+		})
+	}
+	return stats
+}
+
+//
+// Groups.
+//
+
+func (b *cgoBackend) GetNumGroups(homeID uint32, nodeID uint8) uint8 {
+	return uint8(C.manager_getNumGroups(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) GetAssociations(homeID uint32, nodeID uint8, groupIDx uint8) []Association {
+	var cassociations *C.instanceassociation_t
+	count := int(C.manager_getInstanceAssociations(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID), C.uint8_t(groupIDx), &cassociations))
+	if cassociations == nil {
+		return nil
+	}
+	defer C.free(unsafe.Pointer(cassociations))
+	associations := make([]Association, count)
+	for i, a := range unsafe.Slice(cassociations, count) {
+		associations[i] = Association{
+			NodeID:   uint8(a.nodeId),
+			Instance: uint8(a.instance),
+		}
+	}
+	return associations
+}
+
+func (b *cgoBackend) GetMaxAssociations(homeID uint32, nodeID uint8, groupIDx uint8) uint8 {
+	return uint8(C.manager_getMaxAssociations(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID), C.uint8_t(groupIDx)))
+}
+
+func (b *cgoBackend) GetGroupLabel(homeID uint32, nodeID uint8, groupIDx uint8) string {
+	return goStringFree(C.manager_getGroupLabel(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID), C.uint8_t(groupIDx)))
+}
+
+func (b *cgoBackend) AddAssociation(homeID uint32, nodeID uint8, groupIDx uint8, targetNodeID uint8, instance uint8) {
+	C.manager_addAssociation(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID), C.uint8_t(groupIDx), C.uint8_t(targetNodeID), C.uint8_t(instance))
+}
+
+func (b *cgoBackend) RemoveAssociation(homeID uint32, nodeID uint8, groupIDx uint8, targetNodeID uint8, instance uint8) {
+	C.manager_removeAssociation(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID), C.uint8_t(groupIDx), C.uint8_t(targetNodeID), C.uint8_t(instance))
+}
+
+//
+// Notifications.
+//
+
+func (b *cgoBackend) AddWatcher(watcher NotificationHandler) bool {
+	cgoWatcher.Store(&watcher)
+	if bool(C.manager_addWatcher(b.manager, nil)) {
+		return true
+	}
+	cgoWatcher.Store(nil)
+	return false
+}
+
+func (b *cgoBackend) RemoveWatcher() bool {
+	if bool(C.manager_removeWatcher(b.manager, nil)) {
+		cgoWatcher.Store(nil)
+		return true
+	}
+	return false
+}
+
+// goNotificationCB called by the C++ OpenZWave library when there is a new
+// notification.
+//
+//export goNotificationCB
+func goNotificationCB(cnotification C.notification_t, userdata unsafe.Pointer) {
+	// This function is called by OpenZWave (via a C wrapper) when a
+	// notification is available. All data must be extracted from the
+	// notification object before we return as OpenZWave will delete the object.
+
+	// Convert the C notification_t to Go Notification.
+	notification := buildNotification(cnotification)
+
+	// Allow the installed watcher to deal with it.
+	if watcher := cgoWatcher.Load(); watcher != nil && *watcher != nil {
+		(*watcher)(notification)
+	}
+}
+
+//
+// Logging.
+//
+
+func (b *cgoBackend) SetLogHandler(handler LogHandler) bool {
+	cgoLogHandler.Store(&handler)
+	if b.logInstalled {
+		return true
+	}
+	b.logInstalled = bool(C.log_setGoLogger())
+	return b.logInstalled
+}
+
+// goLogCB called by the C++ OpenZWave library for every line it logs.
+//
+//export goLogCB
+func goLogCB(level C.loglevel_t, nodeID C.uint8_t, message *C.char) {
+	if handler := cgoLogHandler.Load(); handler != nil && *handler != nil {
+		(*handler)(LogLevel(level), uint8(nodeID), C.GoString(message))
+	}
+}
+
+//
+// Controller commands.
+//
+
+func (b *cgoBackend) ResetController(homeID uint32) {
+	C.manager_resetController(b.manager, C.uint32_t(homeID))
+}
+
+func (b *cgoBackend) SoftReset(homeID uint32) {
+	C.manager_softReset(b.manager, C.uint32_t(homeID))
+}
+
+func (b *cgoBackend) CancelControllerCommand(homeID uint32) bool {
+	return bool(C.manager_cancelControllerCommand(b.manager, C.uint32_t(homeID)))
+}
+
+//
+// Network commands.
+//
+
+func (b *cgoBackend) TestNetworkNode(homeID uint32, nodeID uint8, count uint32) {
+	C.manager_testNetworkNode(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID), C.uint32_t(count))
+}
+
+func (b *cgoBackend) TestNetwork(homeID uint32, count uint32) {
+	C.manager_testNetwork(b.manager, C.uint32_t(homeID), C.uint32_t(count))
+}
+
+func (b *cgoBackend) HealNetworkNode(homeID uint32, nodeID uint8, doRR bool) {
+	C.manager_healNetworkNode(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID), C.bool(doRR))
+}
+
+func (b *cgoBackend) HealNetwork(homeID uint32, doRR bool) {
+	C.manager_healNetwork(b.manager, C.uint32_t(homeID), C.bool(doRR))
+}
+
+func (b *cgoBackend) AddNode(homeID uint32, doSecurity bool) bool {
+	return bool(C.manager_addNode(b.manager, C.uint32_t(homeID), C.bool(doSecurity)))
+}
+
+func (b *cgoBackend) RemoveNode(homeID uint32) bool {
+	return bool(C.manager_removeNode(b.manager, C.uint32_t(homeID)))
+}
+
+func (b *cgoBackend) RemoveFailedNode(homeID uint32, nodeID uint8) bool {
+	return bool(C.manager_removeFailedNode(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) HasNodeFailed(homeID uint32, nodeID uint8) bool {
+	return bool(C.manager_hasNodeFailed(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) RequestNodeNeighborUpdate(homeID uint32, nodeID uint8) bool {
+	return bool(C.manager_requestNodeNeighborUpdate(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) AssignReturnRoute(homeID uint32, nodeID uint8) bool {
+	return bool(C.manager_assignReturnRoute(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) DeleteAllReturnRoutes(homeID uint32, nodeID uint8) bool {
+	return bool(C.manager_deleteAllReturnRoutes(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) SendNodeInformation(homeID uint32, nodeID uint8) bool {
+	return bool(C.manager_sendNodeInformation(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) CreateNewPrimary(homeID uint32) bool {
+	return bool(C.manager_createNewPrimary(b.manager, C.uint32_t(homeID)))
+}
+
+func (b *cgoBackend) ReceiveConfiguration(homeID uint32) bool {
+	return bool(C.manager_receiveConfiguration(b.manager, C.uint32_t(homeID)))
+}
+
+func (b *cgoBackend) ReplaceFailedNode(homeID uint32, nodeID uint8) bool {
+	return bool(C.manager_replaceFailedNode(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) TransferPrimaryRole(homeID uint32) bool {
+	return bool(C.manager_transferPrimaryRole(b.manager, C.uint32_t(homeID)))
+}
+
+func (b *cgoBackend) RequestNetworkUpdate(homeID uint32, nodeID uint8) bool {
+	return bool(C.manager_requestNetworkUpdate(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) ReplicationSend(homeID uint32, nodeID uint8) bool {
+	return bool(C.manager_replicationSend(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+}
+
+func (b *cgoBackend) CreateButton(homeID uint32, nodeID uint8, buttonID uint8) bool {
+	return bool(C.manager_createButton(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID), C.uint8_t(buttonID)))
+}
+
+func (b *cgoBackend) DeleteButton(homeID uint32, nodeID uint8, buttonID uint8) bool {
+	return bool(C.manager_deleteButton(b.manager, C.uint32_t(homeID), C.uint8_t(nodeID), C.uint8_t(buttonID)))
+}
+
+//
+// Scene commands.
+//
+
+func (b *cgoBackend) GetNumScenes() uint8 {
+	return uint8(C.manager_getNumScenes(b.manager))
+}
+
+func (b *cgoBackend) GetAllScenes() []uint8 {
+	var csceneIDs *C.uint8_t
+	count := int(C.manager_getAllScenes(b.manager, &csceneIDs))
+	if csceneIDs == nil {
+		return nil
+	}
+	defer C.free(unsafe.Pointer(csceneIDs))
+	sceneIDs := make([]uint8, count)
+	for i, id := range unsafe.Slice(csceneIDs, count) {
+		sceneIDs[i] = uint8(id)
+	}
+	return sceneIDs
+}
+
+func (b *cgoBackend) RemoveAllScenes(homeID uint32) {
+	C.manager_removeAllScenes(b.manager, C.uint32_t(homeID))
+}
+
+func (b *cgoBackend) CreateScene() uint8 {
+	return uint8(C.manager_createScene(b.manager))
+}
+
+func (b *cgoBackend) RemoveScene(sceneID uint8) bool {
+	return bool(C.manager_removeScene(b.manager, C.uint8_t(sceneID)))
+}
+
+func (b *cgoBackend) AddSceneValueBool(sceneID uint8, homeID uint32, valueID uint64, value bool) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		ok = bool(C.manager_addSceneValueBool(b.manager, C.uint8_t(sceneID), cvalueid, C.bool(value)))
+	})
+	return
+}
+
+func (b *cgoBackend) AddSceneValueUint8(sceneID uint8, homeID uint32, valueID uint64, value uint8) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		ok = bool(C.manager_addSceneValueUint8(b.manager, C.uint8_t(sceneID), cvalueid, C.uint8_t(value)))
+	})
+	return
+}
+
+func (b *cgoBackend) AddSceneValueFloat(sceneID uint8, homeID uint32, valueID uint64, value float32) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		ok = bool(C.manager_addSceneValueFloat(b.manager, C.uint8_t(sceneID), cvalueid, C.float(value)))
+	})
+	return
+}
+
+func (b *cgoBackend) AddSceneValueInt32(sceneID uint8, homeID uint32, valueID uint64, value int32) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		ok = bool(C.manager_addSceneValueInt32(b.manager, C.uint8_t(sceneID), cvalueid, C.int32_t(value)))
+	})
+	return
+}
+
+func (b *cgoBackend) AddSceneValueInt16(sceneID uint8, homeID uint32, valueID uint64, value int16) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		ok = bool(C.manager_addSceneValueInt16(b.manager, C.uint8_t(sceneID), cvalueid, C.int16_t(value)))
+	})
+	return
+}
+
+func (b *cgoBackend) AddSceneValueString(sceneID uint8, homeID uint32, valueID uint64, value string) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		cstr := C.CString(value)
+		defer C.free(unsafe.Pointer(cstr))
+		ok = bool(C.manager_addSceneValueString(b.manager, C.uint8_t(sceneID), cvalueid, cstr))
+	})
+	return
+}
+
+func (b *cgoBackend) AddSceneValueListSelectionString(sceneID uint8, homeID uint32, valueID uint64, value string) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		cstr := C.CString(value)
+		defer C.free(unsafe.Pointer(cstr))
+		ok = bool(C.manager_addSceneValueListSelectionString(b.manager, C.uint8_t(sceneID), cvalueid, cstr))
+	})
+	return
+}
+
+func (b *cgoBackend) AddSceneValueListSelectionInt32(sceneID uint8, homeID uint32, valueID uint64, value int32) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		ok = bool(C.manager_addSceneValueListSelectionInt32(b.manager, C.uint8_t(sceneID), cvalueid, C.int32_t(value)))
+	})
+	return
+}
+
+func (b *cgoBackend) RemoveSceneValue(sceneID uint8, homeID uint32, valueID uint64) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		ok = bool(C.manager_removeSceneValue(b.manager, C.uint8_t(sceneID), cvalueid))
+	})
+	return
+}
+
+func (b *cgoBackend) SceneGetValues(sceneID uint8) []*ValueID {
+	var cvalueids *C.valueid_t
+	count := int(C.manager_sceneGetValues(b.manager, C.uint8_t(sceneID), &cvalueids))
+	if cvalueids == nil {
+		return nil
+	}
+	defer C.free(unsafe.Pointer(cvalueids))
+	valueIDs := make([]*ValueID, count)
+	for i, cvalueid := range unsafe.Slice(cvalueids, count) {
+		valueIDs[i] = buildValueID(cvalueid)
+		C.valueid_free(cvalueid)
+	}
+	return valueIDs
+}
+
+func (b *cgoBackend) GetSceneValueAsBool(sceneID uint8, homeID uint32, valueID uint64) (value bool, ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		var cbool C.bool
+		ok = bool(C.manager_sceneGetValueAsBool(b.manager, C.uint8_t(sceneID), cvalueid, &cbool))
+		value = bool(cbool)
+	})
+	return
+}
+
+func (b *cgoBackend) GetSceneValueAsByte(sceneID uint8, homeID uint32, valueID uint64) (value byte, ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		var cbyte C.uint8_t
+		ok = bool(C.manager_sceneGetValueAsByte(b.manager, C.uint8_t(sceneID), cvalueid, &cbyte))
+		value = byte(cbyte)
+	})
+	return
+}
+
+func (b *cgoBackend) GetSceneValueAsFloat(sceneID uint8, homeID uint32, valueID uint64) (value float32, ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		var cfloat C.float
+		ok = bool(C.manager_sceneGetValueAsFloat(b.manager, C.uint8_t(sceneID), cvalueid, &cfloat))
+		value = float32(cfloat)
+	})
+	return
+}
+
+func (b *cgoBackend) GetSceneValueAsInt(sceneID uint8, homeID uint32, valueID uint64) (value int32, ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		var cint C.int32_t
+		ok = bool(C.manager_sceneGetValueAsInt(b.manager, C.uint8_t(sceneID), cvalueid, &cint))
+		value = int32(cint)
+	})
+	return
+}
+
+func (b *cgoBackend) GetSceneValueAsShort(sceneID uint8, homeID uint32, valueID uint64) (value int16, ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		var cshort C.int16_t
+		ok = bool(C.manager_sceneGetValueAsShort(b.manager, C.uint8_t(sceneID), cvalueid, &cshort))
+		value = int16(cshort)
+	})
+	return
+}
+
+func (b *cgoBackend) GetSceneValueAsString(sceneID uint8, homeID uint32, valueID uint64) (value string, ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		var cstr *C.char
+		ok = bool(C.manager_sceneGetValueAsString(b.manager, C.uint8_t(sceneID), cvalueid, &cstr))
+		value = goStringFree(cstr)
+	})
+	return
+}
+
+func (b *cgoBackend) GetSceneValueListSelectionString(sceneID uint8, homeID uint32, valueID uint64) (value string, ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		var cstr *C.char
+		ok = bool(C.manager_sceneGetValueListSelectionString(b.manager, C.uint8_t(sceneID), cvalueid, &cstr))
+		value = goStringFree(cstr)
+	})
+	return
+}
+
+func (b *cgoBackend) GetSceneValueListSelectionInt32(sceneID uint8, homeID uint32, valueID uint64) (value int32, ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		var cint C.int32_t
+		ok = bool(C.manager_sceneGetValueListSelectionInt32(b.manager, C.uint8_t(sceneID), cvalueid, &cint))
+		value = int32(cint)
+	})
+	return
+}
+
+func (b *cgoBackend) SetSceneValueBool(sceneID uint8, homeID uint32, valueID uint64, value bool) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		ok = bool(C.manager_setSceneValueBool(b.manager, C.uint8_t(sceneID), cvalueid, C.bool(value)))
+	})
+	return
+}
+
+func (b *cgoBackend) SetSceneValueUint8(sceneID uint8, homeID uint32, valueID uint64, value uint8) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		ok = bool(C.manager_setSceneValueUint8(b.manager, C.uint8_t(sceneID), cvalueid, C.uint8_t(value)))
+	})
+	return
+}
+
+func (b *cgoBackend) SetSceneValueFloat(sceneID uint8, homeID uint32, valueID uint64, value float32) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		ok = bool(C.manager_setSceneValueFloat(b.manager, C.uint8_t(sceneID), cvalueid, C.float(value)))
+	})
+	return
+}
+
+func (b *cgoBackend) SetSceneValueInt32(sceneID uint8, homeID uint32, valueID uint64, value int32) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		ok = bool(C.manager_setSceneValueInt32(b.manager, C.uint8_t(sceneID), cvalueid, C.int32_t(value)))
+	})
+	return
+}
+
+func (b *cgoBackend) SetSceneValueInt16(sceneID uint8, homeID uint32, valueID uint64, value int16) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		ok = bool(C.manager_setSceneValueInt16(b.manager, C.uint8_t(sceneID), cvalueid, C.int16_t(value)))
+	})
+	return
+}
+
+func (b *cgoBackend) SetSceneValueString(sceneID uint8, homeID uint32, valueID uint64, value string) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		cstr := C.CString(value)
+		defer C.free(unsafe.Pointer(cstr))
+		ok = bool(C.manager_setSceneValueString(b.manager, C.uint8_t(sceneID), cvalueid, cstr))
+	})
+	return
+}
+
+func (b *cgoBackend) SetSceneValueListSelectionString(sceneID uint8, homeID uint32, valueID uint64, value string) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		cstr := C.CString(value)
+		defer C.free(unsafe.Pointer(cstr))
+		ok = bool(C.manager_setSceneValueListSelectionString(b.manager, C.uint8_t(sceneID), cvalueid, cstr))
+	})
+	return
+}
+
+func (b *cgoBackend) SetSceneValueListSelectionInt32(sceneID uint8, homeID uint32, valueID uint64, value int32) (ok bool) {
+	withValueID(homeID, valueID, func(cvalueid C.valueid_t) {
+		ok = bool(C.manager_setSceneValueListSelectionInt32(b.manager, C.uint8_t(sceneID), cvalueid, C.int32_t(value)))
+	})
+	return
+}
+
+func (b *cgoBackend) GetSceneLabel(sceneID uint8) string {
+	return goStringFree(C.manager_getSceneLabel(b.manager, C.uint8_t(sceneID)))
+}
+
+func (b *cgoBackend) SetSceneLabel(sceneID uint8, value string) {
+	cstr := C.CString(value)
+	defer C.free(unsafe.Pointer(cstr))
+	C.manager_setSceneLabel(b.manager, C.uint8_t(sceneID), cstr)
+}
+
+func (b *cgoBackend) SceneExists(sceneID uint8) bool {
+	return bool(C.manager_sceneExists(b.manager, C.uint8_t(sceneID)))
+}
+
+func (b *cgoBackend) ActivateScene(sceneID uint8) bool {
+	return bool(C.manager_activateScene(b.manager, C.uint8_t(sceneID)))
+}
@@ -0,0 +1,278 @@
+package goopenzwave
+
+// #include "gzw_notification.h"
+// #include "gzw_valueid.h"
+// #include <stdlib.h>
+import "C"
+
+// buildNotification builds a new Notification filled with the relevant
+// information from the OpenZWave::Notification as received from the OpenZWave
+// library.
+func buildNotification(n C.notification_t) *Notification {
+	notification := &Notification{
+		HomeID: uint32(C.notification_getHomeId(n)),
+		NodeID: uint8(C.notification_getNodeId(n)),
+	}
+
+	switch C.notification_getType(n) {
+	default:
+		notification.Type = NotificationTypeUnknown
+	case C.notification_type_valueAdded:
+		notification.Type = NotificationTypeValueAdded
+	case C.notification_type_valueRemoved:
+		notification.Type = NotificationTypeValueRemoved
+	case C.notification_type_valueChanged:
+		notification.Type = NotificationTypeValueChanged
+	case C.notification_type_valueRefreshed:
+		notification.Type = NotificationTypeValueRefreshed
+	case C.notification_type_group:
+		notification.Type = NotificationTypeGroup
+	case C.notification_type_nodeNew:
+		notification.Type = NotificationTypeNodeNew
+	case C.notification_type_nodeAdded:
+		notification.Type = NotificationTypeNodeAdded
+	case C.notification_type_nodeRemoved:
+		notification.Type = NotificationTypeNodeRemoved
+	case C.notification_type_nodeProtocolInfo:
+		notification.Type = NotificationTypeNodeProtocolInfo
+	case C.notification_type_nodeNaming:
+		notification.Type = NotificationTypeNodeNaming
+	case C.notification_type_nodeEvent:
+		notification.Type = NotificationTypeNodeEvent
+	case C.notification_type_pollingDisabled:
+		notification.Type = NotificationTypePollingDisabled
+	case C.notification_type_pollingEnabled:
+		notification.Type = NotificationTypePollingEnabled
+	case C.notification_type_sceneEvent:
+		notification.Type = NotificationTypeSceneEvent
+	case C.notification_type_createButton:
+		notification.Type = NotificationTypeCreateButton
+	case C.notification_type_deleteButton:
+		notification.Type = NotificationTypeDeleteButton
+	case C.notification_type_buttonOn:
+		notification.Type = NotificationTypeButtonOn
+	case C.notification_type_buttonOff:
+		notification.Type = NotificationTypeButtonOff
+	case C.notification_type_driverReady:
+		notification.Type = NotificationTypeDriverReady
+	case C.notification_type_driverFailed:
+		notification.Type = NotificationTypeDriverFailed
+	case C.notification_type_driverReset:
+		notification.Type = NotificationTypeDriverReset
+	case C.notification_type_essentialNodeQueriesComplete:
+		notification.Type = NotificationTypeEssentialNodeQueriesComplete
+	case C.notification_type_nodeQueriesComplete:
+		notification.Type = NotificationTypeNodeQueriesComplete
+	case C.notification_type_awakeNodesQueried:
+		notification.Type = NotificationTypeAwakeNodesQueried
+	case C.notification_type_allNodesQueriedSomeDead:
+		notification.Type = NotificationTypeAllNodesQueriedSomeDead
+	case C.notification_type_allNodesQueried:
+		notification.Type = NotificationTypeAllNodesQueried
+	case C.notification_type_notification:
+		notification.Type = NotificationTypeNotification
+	case C.notification_type_driverRemoved:
+		notification.Type = NotificationTypeDriverRemoved
+	case C.notification_type_controllerCommand:
+		notification.Type = NotificationTypeControllerCommand
+	case C.notification_type_nodeReset:
+		notification.Type = NotificationTypeNodeReset
+	}
+
+	switch notification.Type {
+	case NotificationTypeValueAdded, NotificationTypeValueRemoved, NotificationTypeValueChanged, NotificationTypeValueRefreshed:
+		notification.ValueID = buildValueID(C.notification_getValueId(n))
+
+	case NotificationTypeGroup:
+		if notification.GroupIDX == nil {
+			notification.GroupIDX = new(uint8)
+		}
+		*(notification.GroupIDX) = uint8(C.notification_getGroupIdx(n))
+
+	case NotificationTypeNodeNew, NotificationTypeNodeAdded, NotificationTypeNodeRemoved, NotificationTypeNodeProtocolInfo, NotificationTypeNodeNaming:
+		// No notification info.
+
+	case NotificationTypeNodeEvent:
+		if notification.Event == nil {
+			notification.Event = new(uint8)
+		}
+		*(notification.Event) = uint8(C.notification_getEvent(n))
+
+	case NotificationTypePollingDisabled, NotificationTypePollingEnabled:
+		// No notification info.
+
+	case NotificationTypeSceneEvent:
+		if notification.SceneID == nil {
+			notification.SceneID = new(uint8)
+		}
+		*(notification.SceneID) = uint8(C.notification_getSceneId(n))
+
+	case NotificationTypeCreateButton, NotificationTypeDeleteButton, NotificationTypeButtonOn, NotificationTypeButtonOff:
+		if notification.ButtonID == nil {
+			notification.ButtonID = new(uint8)
+		}
+		*(notification.ButtonID) = uint8(C.notification_getButtonId(n))
+
+	case NotificationTypeDriverReady, NotificationTypeDriverFailed, NotificationTypeDriverReset:
+		// No notification info.
+
+	case NotificationTypeEssentialNodeQueriesComplete, NotificationTypeNodeQueriesComplete, NotificationTypeAwakeNodesQueried, NotificationTypeAllNodesQueriedSomeDead, NotificationTypeAllNodesQueried:
+		// No notification info.
+
+	case NotificationTypeNotification:
+		if notification.Notification == nil {
+			notification.Notification = new(NotificationCode)
+		}
+		switch C.notification_getNotification(n) {
+		default:
+			*notification.Notification = NotificationCodeUnknown
+		case C.notification_code_msgComplete:
+			*notification.Notification = NotificationCodeMsgComplete
+		case C.notification_code_timeout:
+			*notification.Notification = NotificationCodeTimeout
+		case C.notification_code_noOperation:
+			*notification.Notification = NotificationCodeNoOperation
+		case C.notification_code_awake:
+			*notification.Notification = NotificationCodeAwake
+		case C.notification_code_sleep:
+			*notification.Notification = NotificationCodeSleep
+		case C.notification_code_dead:
+			*notification.Notification = NotificationCodeDead
+		case C.notification_code_alive:
+			*notification.Notification = NotificationCodeAlive
+		}
+
+	case NotificationTypeDriverRemoved:
+		// No notification info.
+
+	case NotificationTypeControllerCommand:
+		if notification.Event == nil {
+			notification.Event = new(uint8)
+		}
+		*(notification.Event) = uint8(C.notification_getEvent(n))
+		if notification.ControllerState == nil {
+			notification.ControllerState = new(ControllerState)
+		}
+		switch C.notification_getEvent(n) {
+		default:
+			*notification.ControllerState = ControllerStateUnknown
+		case C.controller_state_normal:
+			*notification.ControllerState = ControllerStateNormal
+		case C.controller_state_starting:
+			*notification.ControllerState = ControllerStateStarting
+		case C.controller_state_cancel:
+			*notification.ControllerState = ControllerStateCancel
+		case C.controller_state_error:
+			*notification.ControllerState = ControllerStateError
+		case C.controller_state_waiting:
+			*notification.ControllerState = ControllerStateWaiting
+		case C.controller_state_sleeping:
+			*notification.ControllerState = ControllerStateSleeping
+		case C.controller_state_inProgress:
+			*notification.ControllerState = ControllerStateInProgress
+		case C.controller_state_completed:
+			*notification.ControllerState = ControllerStateCompleted
+		case C.controller_state_failed:
+			*notification.ControllerState = ControllerStateFailed
+		case C.controller_state_nodeOK:
+			*notification.ControllerState = ControllerStateNodeOK
+		case C.controller_state_nodeFailed:
+			*notification.ControllerState = ControllerStateNodeFailed
+		}
+		if notification.ControllerError == nil {
+			notification.ControllerError = new(ControllerError)
+		}
+		switch C.notification_getNotification(n) {
+		default:
+			*notification.ControllerError = ControllerErrorUnknown
+		case C.controller_error_none:
+			*notification.ControllerError = ControllerErrorNone
+		case C.controller_error_buttonNotFound:
+			*notification.ControllerError = ControllerErrorButtonNotFound
+		case C.controller_error_nodeNotFound:
+			*notification.ControllerError = ControllerErrorNodeNotFound
+		case C.controller_error_notBridge:
+			*notification.ControllerError = ControllerErrorNotBridge
+		case C.controller_error_notSUC:
+			*notification.ControllerError = ControllerErrorNotSUC
+		case C.controller_error_notSecondary:
+			*notification.ControllerError = ControllerErrorNotSecondary
+		case C.controller_error_notPrimary:
+			*notification.ControllerError = ControllerErrorNotPrimary
+		case C.controller_error_isPrimary:
+			*notification.ControllerError = ControllerErrorIsPrimary
+		case C.controller_error_notFound:
+			*notification.ControllerError = ControllerErrorNotFound
+		case C.controller_error_busy:
+			*notification.ControllerError = ControllerErrorBusy
+		case C.controller_error_failed:
+			*notification.ControllerError = ControllerErrorFailed
+		case C.controller_error_disabled:
+			*notification.ControllerError = ControllerErrorDisabled
+		case C.controller_error_overflow:
+			*notification.ControllerError = ControllerErrorOverflow
+		}
+
+	case NotificationTypeNodeReset:
+		// No notification info.
+	}
+
+	return notification
+}
+
+// buildValueID creates a new valueid.ValueID from the C valueid_t (and
+// therefore C++ ValueID) value.
+func buildValueID(v C.valueid_t) *ValueID {
+	vid := &ValueID{
+		HomeID:         uint32(C.valueid_getHomeId(v)),
+		NodeID:         uint8(C.valueid_getNodeId(v)),
+		CommandClassID: uint8(C.valueid_getCommandClassId(v)),
+		Instance:       uint8(C.valueid_getInstance(v)),
+		Index:          uint8(C.valueid_getIndex(v)),
+		ID:             uint64(C.valueid_getId(v)),
+	}
+
+	switch C.valueid_getGenre(v) {
+	default:
+		vid.Genre = ValueIDGenreUnknown
+	case C.valueid_genre_basic:
+		vid.Genre = ValueIDGenreBasic
+	case C.valueid_genre_user:
+		vid.Genre = ValueIDGenreUser
+	case C.valueid_genre_config:
+		vid.Genre = ValueIDGenreConfig
+	case C.valueid_genre_system:
+		vid.Genre = ValueIDGenreSystem
+	case C.valueid_genre_count:
+		vid.Genre = ValueIDGenreCount
+	}
+
+	switch C.valueid_getType(v) {
+	default:
+		vid.Type = ValueIDTypeUnknown
+	case C.valueid_type_bool:
+		vid.Type = ValueIDTypeBool
+	case C.valueid_type_byte:
+		vid.Type = ValueIDTypeByte
+	case C.valueid_type_decimal:
+		vid.Type = ValueIDTypeDecimal
+	case C.valueid_type_int:
+		vid.Type = ValueIDTypeInt
+	case C.valueid_type_list:
+		vid.Type = ValueIDTypeList
+	case C.valueid_type_schedule:
+		vid.Type = ValueIDTypeSchedule
+	case C.valueid_type_short:
+		vid.Type = ValueIDTypeShort
+	case C.valueid_type_string:
+		vid.Type = ValueIDTypeString
+	case C.valueid_type_button:
+		vid.Type = ValueIDTypeButton
+	case C.valueid_type_raw:
+		vid.Type = ValueIDTypeRaw
+	case C.valueid_type_bitset:
+		vid.Type = ValueIDTypeBitSet
+	}
+
+	return vid
+}
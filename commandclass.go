@@ -0,0 +1,212 @@
+package goopenzwave
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CommandClass defines a type for Z-Wave command class IDs, as found in the
+// CommandClassID of a ValueID.
+type CommandClass uint8
+
+const (
+	CommandClassNoOperation                     CommandClass = 0x00
+	CommandClassBasic                           CommandClass = 0x20
+	CommandClassControllerReplication           CommandClass = 0x21
+	CommandClassApplicationStatus               CommandClass = 0x22
+	CommandClassSwitchBinary                    CommandClass = 0x25
+	CommandClassSwitchMultilevel                CommandClass = 0x26
+	CommandClassSwitchAll                       CommandClass = 0x27
+	CommandClassSwitchToggleBinary              CommandClass = 0x28
+	CommandClassSwitchToggleMultilevel          CommandClass = 0x29
+	CommandClassSceneActivation                 CommandClass = 0x2b
+	CommandClassSensorBinary                    CommandClass = 0x30
+	CommandClassSensorMultilevel                CommandClass = 0x31
+	CommandClassMeter                           CommandClass = 0x32
+	CommandClassColor                           CommandClass = 0x33
+	CommandClassMeterPulse                      CommandClass = 0x35
+	CommandClassThermostatMode                  CommandClass = 0x40
+	CommandClassThermostatOperatingState        CommandClass = 0x42
+	CommandClassThermostatSetpoint              CommandClass = 0x43
+	CommandClassThermostatFanMode               CommandClass = 0x44
+	CommandClassThermostatFanState              CommandClass = 0x45
+	CommandClassClimateControlSchedule          CommandClass = 0x46
+	CommandClassDoorLockLogging                 CommandClass = 0x4c
+	CommandClassBasicWindowCovering             CommandClass = 0x50
+	CommandClassTransportService                CommandClass = 0x55
+	CommandClassCRC16Encap                      CommandClass = 0x56
+	CommandClassAssociationGroupInfo            CommandClass = 0x59
+	CommandClassDeviceResetLocally              CommandClass = 0x5a
+	CommandClassCentralScene                    CommandClass = 0x5b
+	CommandClassZWavePlusInfo                   CommandClass = 0x5e
+	CommandClassMultiInstance                   CommandClass = 0x60
+	CommandClassDoorLock                        CommandClass = 0x62
+	CommandClassUserCode                        CommandClass = 0x63
+	CommandClassBarrierOperator                 CommandClass = 0x66
+	CommandClassSupervision                     CommandClass = 0x6c
+	CommandClassConfiguration                   CommandClass = 0x70
+	CommandClassAlarm                           CommandClass = 0x71
+	CommandClassManufacturerSpecific            CommandClass = 0x72
+	CommandClassPowerlevel                      CommandClass = 0x73
+	CommandClassProtection                      CommandClass = 0x75
+	CommandClassLock                            CommandClass = 0x76
+	CommandClassNodeNaming                      CommandClass = 0x77
+	CommandClassFirmwareUpdateMD                CommandClass = 0x7a
+	CommandClassBattery                         CommandClass = 0x80
+	CommandClassClock                           CommandClass = 0x81
+	CommandClassHail                            CommandClass = 0x82
+	CommandClassWakeUp                          CommandClass = 0x84
+	CommandClassAssociation                     CommandClass = 0x85
+	CommandClassVersion                         CommandClass = 0x86
+	CommandClassIndicator                       CommandClass = 0x87
+	CommandClassProprietary                     CommandClass = 0x88
+	CommandClassLanguage                        CommandClass = 0x89
+	CommandClassTimeParameters                  CommandClass = 0x8b
+	CommandClassMultiInstanceAssociation        CommandClass = 0x8e
+	CommandClassMultiCmd                        CommandClass = 0x8f
+	CommandClassEnergyProduction                CommandClass = 0x90
+	CommandClassSimpleAVControl                 CommandClass = 0x94
+	CommandClassSecurity                        CommandClass = 0x98
+	CommandClassAssociationCommandConfiguration CommandClass = 0x9b
+	CommandClassSensorAlarm                     CommandClass = 0x9c
+	CommandClassSecurity2                       CommandClass = 0x9f
+)
+
+// commandClassNames holds the OpenZWave names of the command classes.
+var commandClassNames = map[CommandClass]string{
+	CommandClassNoOperation:                     "COMMAND_CLASS_NO_OPERATION",
+	CommandClassBasic:                           "COMMAND_CLASS_BASIC",
+	CommandClassControllerReplication:           "COMMAND_CLASS_CONTROLLER_REPLICATION",
+	CommandClassApplicationStatus:               "COMMAND_CLASS_APPLICATION_STATUS",
+	CommandClassSwitchBinary:                    "COMMAND_CLASS_SWITCH_BINARY",
+	CommandClassSwitchMultilevel:                "COMMAND_CLASS_SWITCH_MULTILEVEL",
+	CommandClassSwitchAll:                       "COMMAND_CLASS_SWITCH_ALL",
+	CommandClassSwitchToggleBinary:              "COMMAND_CLASS_SWITCH_TOGGLE_BINARY",
+	CommandClassSwitchToggleMultilevel:          "COMMAND_CLASS_SWITCH_TOGGLE_MULTILEVEL",
+	CommandClassSceneActivation:                 "COMMAND_CLASS_SCENE_ACTIVATION",
+	CommandClassSensorBinary:                    "COMMAND_CLASS_SENSOR_BINARY",
+	CommandClassSensorMultilevel:                "COMMAND_CLASS_SENSOR_MULTILEVEL",
+	CommandClassMeter:                           "COMMAND_CLASS_METER",
+	CommandClassColor:                           "COMMAND_CLASS_COLOR",
+	CommandClassMeterPulse:                      "COMMAND_CLASS_METER_PULSE",
+	CommandClassThermostatMode:                  "COMMAND_CLASS_THERMOSTAT_MODE",
+	CommandClassThermostatOperatingState:        "COMMAND_CLASS_THERMOSTAT_OPERATING_STATE",
+	CommandClassThermostatSetpoint:              "COMMAND_CLASS_THERMOSTAT_SETPOINT",
+	CommandClassThermostatFanMode:               "COMMAND_CLASS_THERMOSTAT_FAN_MODE",
+	CommandClassThermostatFanState:              "COMMAND_CLASS_THERMOSTAT_FAN_STATE",
+	CommandClassClimateControlSchedule:          "COMMAND_CLASS_CLIMATE_CONTROL_SCHEDULE",
+	CommandClassDoorLockLogging:                 "COMMAND_CLASS_DOOR_LOCK_LOGGING",
+	CommandClassBasicWindowCovering:             "COMMAND_CLASS_BASIC_WINDOW_COVERING",
+	CommandClassTransportService:                "COMMAND_CLASS_TRANSPORT_SERVICE",
+	CommandClassCRC16Encap:                      "COMMAND_CLASS_CRC_16_ENCAP",
+	CommandClassAssociationGroupInfo:            "COMMAND_CLASS_ASSOCIATION_GRP_INFO",
+	CommandClassDeviceResetLocally:              "COMMAND_CLASS_DEVICE_RESET_LOCALLY",
+	CommandClassCentralScene:                    "COMMAND_CLASS_CENTRAL_SCENE",
+	CommandClassZWavePlusInfo:                   "COMMAND_CLASS_ZWAVEPLUS_INFO",
+	CommandClassMultiInstance:                   "COMMAND_CLASS_MULTI_INSTANCE/CHANNEL",
+	CommandClassDoorLock:                        "COMMAND_CLASS_DOOR_LOCK",
+	CommandClassUserCode:                        "COMMAND_CLASS_USER_CODE",
+	CommandClassBarrierOperator:                 "COMMAND_CLASS_BARRIER_OPERATOR",
+	CommandClassSupervision:                     "COMMAND_CLASS_SUPERVISION",
+	CommandClassConfiguration:                   "COMMAND_CLASS_CONFIGURATION",
+	CommandClassAlarm:                           "COMMAND_CLASS_ALARM",
+	CommandClassManufacturerSpecific:            "COMMAND_CLASS_MANUFACTURER_SPECIFIC",
+	CommandClassPowerlevel:                      "COMMAND_CLASS_POWERLEVEL",
+	CommandClassProtection:                      "COMMAND_CLASS_PROTECTION",
+	CommandClassLock:                            "COMMAND_CLASS_LOCK",
+	CommandClassNodeNaming:                      "COMMAND_CLASS_NODE_NAMING",
+	CommandClassFirmwareUpdateMD:                "COMMAND_CLASS_FIRMWARE_UPDATE_MD",
+	CommandClassBattery:                         "COMMAND_CLASS_BATTERY",
+	CommandClassClock:                           "COMMAND_CLASS_CLOCK",
+	CommandClassHail:                            "COMMAND_CLASS_HAIL",
+	CommandClassWakeUp:                          "COMMAND_CLASS_WAKE_UP",
+	CommandClassAssociation:                     "COMMAND_CLASS_ASSOCIATION",
+	CommandClassVersion:                         "COMMAND_CLASS_VERSION",
+	CommandClassIndicator:                       "COMMAND_CLASS_INDICATOR",
+	CommandClassProprietary:                     "COMMAND_CLASS_PROPRIETARY",
+	CommandClassLanguage:                        "COMMAND_CLASS_LANGUAGE",
+	CommandClassTimeParameters:                  "COMMAND_CLASS_TIME_PARAMETERS",
+	CommandClassMultiInstanceAssociation:        "COMMAND_CLASS_MULTI_INSTANCE_ASSOCIATION",
+	CommandClassMultiCmd:                        "COMMAND_CLASS_MULTI_CMD",
+	CommandClassEnergyProduction:                "COMMAND_CLASS_ENERGY_PRODUCTION",
+	CommandClassSimpleAVControl:                 "COMMAND_CLASS_SIMPLE_AV_CONTROL",
+	CommandClassSecurity:                        "COMMAND_CLASS_SECURITY",
+	CommandClassAssociationCommandConfiguration: "COMMAND_CLASS_ASSOCIATION_COMMAND_CONFIGURATION",
+	CommandClassSensorAlarm:                     "COMMAND_CLASS_SENSOR_ALARM",
+	CommandClassSecurity2:                       "COMMAND_CLASS_SECURITY_2",
+}
+
+// String returns the OpenZWave name of the command class, e.g.
+// "COMMAND_CLASS_SWITCH_BINARY", or its ID in hex if it is not known.
+func (c CommandClass) String() string {
+	if name, ok := commandClassNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("COMMAND_CLASS_0x%02X", uint8(c))
+}
+
+// Known returns true if the command class is in the registry.
+func (c CommandClass) Known() bool {
+	_, ok := commandClassNames[c]
+	return ok
+}
+
+// CommandClassByName returns the command class with the OpenZWave name. The
+// COMMAND_CLASS_ prefix may be left out and case is ignored, so "switch_binary"
+// finds CommandClassSwitchBinary.
+func CommandClassByName(name string) (CommandClass, bool) {
+	name = strings.ToUpper(name)
+	if strings.HasPrefix(name, "COMMAND_CLASS_") == false {
+		name = "COMMAND_CLASS_" + name
+	}
+	for c, n := range commandClassNames {
+		if n == name {
+			return c, true
+		}
+	}
+	return 0, false
+}
+
+// NodeCommandClass is a command class supported by a node, as returned by
+// Manager.GetNodeCommandClasses.
+type NodeCommandClass struct {
+	CommandClass CommandClass
+	Name         string // As reported by OpenZWave.
+	Version      uint8
+
+	// SecurityDevice is true if the node's protocol information says it
+	// supports security, as reported by IsNodeSecurityDevice. It does not
+	// mean that the node was included securely or that this command class is
+	// encapsulated; OpenZWave does not report either, so it is the same for
+	// every command class of the node.
+	SecurityDevice bool
+}
+
+func (c NodeCommandClass) String() string {
+	return fmt.Sprintf("<CommandClass: 0x%02x, Name: %s, Version: %d, SecurityDevice: %t>", uint8(c.CommandClass), c.Name, c.Version, c.SecurityDevice)
+}
+
+// GetNodeCommandClasses returns every command class supported by the node,
+// ordered by ID. It probes each possible ID with GetNodeClassInformation, so it
+// is best called once the node's queries are complete.
+func (m *Manager) GetNodeCommandClasses(homeID uint32, nodeID uint8) []NodeCommandClass {
+	securityDevice := m.IsNodeSecurityDevice(homeID, nodeID)
+	var classes []NodeCommandClass
+	for id := 0; id <= 0xff; id++ {
+		ok, name, version := m.GetNodeClassInformation(homeID, nodeID, uint8(id))
+		if ok == false {
+			continue
+		}
+		c := CommandClass(id)
+		if name == "" {
+			name = c.String()
+		}
+		classes = append(classes, NodeCommandClass{
+			CommandClass:   c,
+			Name:           name,
+			Version:        version,
+			SecurityDevice: securityDevice,
+		})
+	}
+	return classes
+}
@@ -0,0 +1,163 @@
+package goopenzwave
+
+import (
+	"context"
+	"fmt"
+)
+
+// ControllerCommandResult is the outcome of a controller command, taken from
+// the last ControllerCommand notification received for it.
+type ControllerCommandResult struct {
+	State  ControllerState // The last state reported for the command.
+	Error  ControllerError // The error reported with the last state.
+	NodeID uint8           // The node affected by the command, or 0 if not known.
+}
+
+func (r ControllerCommandResult) String() string {
+	return fmt.Sprintf("<State: %s, Error: %s, NodeID: %d>", r.State, r.Error, r.NodeID)
+}
+
+// ControllerCommandError is returned by the Context controller command methods
+// when the command finishes without succeeding.
+type ControllerCommandError struct {
+	Op     string
+	Result ControllerCommandResult
+}
+
+func (e *ControllerCommandError) Error() string {
+	if e.Result.Error != ControllerErrorNone {
+		return fmt.Sprintf("%s: controller command %s: %s", e.Op, e.Result.State, e.Result.Error)
+	}
+	return fmt.Sprintf("%s: controller command %s", e.Op, e.Result.State)
+}
+
+// AddNodeContext starts the Inclusion Process, in the same way as AddNode, and
+// waits for it to finish. The result contains the ID of the included node,
+// taken from the NodeAdded notification sent while the command runs.
+//
+// If ctx is done before the command finishes, CancelControllerCommand is called
+// and ctx.Err() is returned along with the last result received. If the command
+// fails, is cancelled or cannot be sent an error is returned. The Manager must
+// be started, as the outcome is read from ControllerCommand notifications.
+// Only one controller command can be in progress at a time.
+func (m *Manager) AddNodeContext(ctx context.Context, homeID uint32, doSecurity bool) (ControllerCommandResult, error) {
+	return m.runControllerCommand(ctx, "AddNode", homeID, 0, NotificationTypeNodeAdded, func() bool {
+		return m.AddNode(homeID, doSecurity)
+	}, ControllerStateCompleted)
+}
+
+// RemoveNodeContext starts the Exclusion Process, in the same way as
+// RemoveNode, and waits for it to finish. The result contains the ID of the
+// excluded node, taken from the NodeRemoved notification sent while the
+// command runs. See AddNodeContext for how ctx and errors are handled.
+func (m *Manager) RemoveNodeContext(ctx context.Context, homeID uint32) (ControllerCommandResult, error) {
+	return m.runControllerCommand(ctx, "RemoveNode", homeID, 0, NotificationTypeNodeRemoved, func() bool {
+		return m.RemoveNode(homeID)
+	}, ControllerStateCompleted)
+}
+
+// RemoveFailedNodeContext removes a failed node, in the same way as
+// RemoveFailedNode, and waits for the command to finish. An error is returned
+// if the controller thinks the node is OK. See AddNodeContext for how ctx and
+// errors are handled.
+func (m *Manager) RemoveFailedNodeContext(ctx context.Context, homeID uint32, nodeID uint8) (ControllerCommandResult, error) {
+	return m.runControllerCommand(ctx, "RemoveFailedNode", homeID, nodeID, NotificationTypeUnknown, func() bool {
+		return m.RemoveFailedNode(homeID, nodeID)
+	}, ControllerStateCompleted)
+}
+
+// HasNodeFailedContext checks if the controller believes a node has failed, in
+// the same way as HasNodeFailed, and waits for the answer. The result State is
+// ControllerStateNodeOK or ControllerStateNodeFailed. See AddNodeContext for
+// how ctx and errors are handled.
+func (m *Manager) HasNodeFailedContext(ctx context.Context, homeID uint32, nodeID uint8) (ControllerCommandResult, error) {
+	return m.runControllerCommand(ctx, "HasNodeFailed", homeID, nodeID, NotificationTypeUnknown, func() bool {
+		return m.HasNodeFailed(homeID, nodeID)
+	}, ControllerStateNodeOK, ControllerStateNodeFailed)
+}
+
+// ReplaceFailedNodeContext replaces a failed node with another, in the same
+// way as ReplaceFailedNode, and waits for the command to finish. See
+// AddNodeContext for how ctx and errors are handled.
+func (m *Manager) ReplaceFailedNodeContext(ctx context.Context, homeID uint32, nodeID uint8) (ControllerCommandResult, error) {
+	return m.runControllerCommand(ctx, "ReplaceFailedNode", homeID, nodeID, NotificationTypeUnknown, func() bool {
+		return m.ReplaceFailedNode(homeID, nodeID)
+	}, ControllerStateCompleted)
+}
+
+// RequestNodeNeighborUpdateContext asks a node to update its neighbor tables,
+// in the same way as RequestNodeNeighborUpdate, and waits for the command to
+// finish. See AddNodeContext for how ctx and errors are handled.
+func (m *Manager) RequestNodeNeighborUpdateContext(ctx context.Context, homeID uint32, nodeID uint8) (ControllerCommandResult, error) {
+	return m.runControllerCommand(ctx, "RequestNodeNeighborUpdate", homeID, nodeID, NotificationTypeUnknown, func() bool {
+		return m.RequestNodeNeighborUpdate(homeID, nodeID)
+	}, ControllerStateCompleted)
+}
+
+// runControllerCommand subscribes to the ControllerCommand notifications for
+// the home, sends the command and follows the notifications until the command
+// is done. The command succeeds if it finishes in one of the success states.
+//
+// OpenZWave 1.4 sends ControllerCommand notifications without a node ID, so
+// for a command on a node only notifications for another node are ignored.
+// For a command which finds its node, such as AddNode, the node ID is taken
+// from the nodeType notifications instead. Pass NotificationTypeUnknown if
+// there are none.
+func (m *Manager) runControllerCommand(ctx context.Context, op string, homeID uint32, nodeID uint8, nodeType NotificationType, send func() bool, success ...ControllerState) (ControllerCommandResult, error) {
+	result := ControllerCommandResult{NodeID: nodeID}
+	if err := m.checkStarted(op); err != nil {
+		return result, err
+	}
+
+	types := []NotificationType{NotificationTypeControllerCommand}
+	if nodeType != NotificationTypeUnknown {
+		types = append(types, nodeType)
+	}
+	sub := m.SubscribeChan(NotificationFilter{
+		HomeID: homeID,
+		Types:  types,
+	})
+	defer sub.Unsubscribe()
+
+	if send() == false {
+		return result, fmt.Errorf("%s: failed to send controller command", op)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			m.CancelControllerCommand(homeID)
+			return result, ctx.Err()
+		case notification, ok := <-sub.C:
+			if ok == false {
+				return result, &StateError{Op: op, Err: ErrManagerDestroyed}
+			}
+			if notification.Type == nodeType {
+				result.NodeID = notification.NodeID
+				continue
+			}
+			if nodeID != 0 && notification.NodeID != 0 && notification.NodeID != nodeID {
+				// Left over from an earlier command.
+				continue
+			}
+			if notification.ControllerState != nil {
+				result.State = *notification.ControllerState
+			}
+			if notification.ControllerError != nil {
+				result.Error = *notification.ControllerError
+			}
+			if notification.NodeID != 0 {
+				result.NodeID = notification.NodeID
+			}
+			if result.State.Done() == false {
+				continue
+			}
+			for _, s := range success {
+				if result.State == s {
+					return result, nil
+				}
+			}
+			return result, &ControllerCommandError{Op: op, Result: result}
+		}
+	}
+}
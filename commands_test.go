@@ -0,0 +1,121 @@
+package goopenzwave_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jimjibone/goopenzwave"
+	"github.com/jimjibone/goopenzwave/sim"
+)
+
+// startNetwork starts a Manager with a driver on the simulated network, which
+// sends ControllerCommand notifications without a node ID as OpenZWave does.
+func startNetwork(t *testing.T, network *sim.Network) *goopenzwave.Manager {
+	t.Helper()
+	goopenzwave.SetBackend(network)
+	options := goopenzwave.CreateOptions("", "", "")
+	if err := options.Lock(); err != nil {
+		t.Fatal(err)
+	}
+	manager, err := goopenzwave.NewManager(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		manager.Destroy()
+		options.Destroy()
+	})
+	if err := manager.Start(func(notification *goopenzwave.Notification) {}); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.AddDriver(sim.DefaultControllerPath); err != nil {
+		t.Fatal(err)
+	}
+	network.Flush()
+	return manager
+}
+
+func TestNodeControllerCommands(t *testing.T) {
+	network := sim.New()
+	network.Add(sim.NewSwitch(2, "Switch"))
+	network.Add(sim.NewSwitch(3, "Dead Switch"))
+	manager := startNetwork(t, network)
+	network.SetDead(3, true)
+	network.Flush()
+	homeID := network.HomeID()
+
+	tests := []struct {
+		name  string
+		run   func(ctx context.Context) (goopenzwave.ControllerCommandResult, error)
+		state goopenzwave.ControllerState
+		fail  bool
+	}{
+		{"HasNodeFailed OK", func(ctx context.Context) (goopenzwave.ControllerCommandResult, error) {
+			return manager.HasNodeFailedContext(ctx, homeID, 2)
+		}, goopenzwave.ControllerStateNodeOK, false},
+		{"HasNodeFailed failed", func(ctx context.Context) (goopenzwave.ControllerCommandResult, error) {
+			return manager.HasNodeFailedContext(ctx, homeID, 3)
+		}, goopenzwave.ControllerStateNodeFailed, false},
+		{"RequestNodeNeighborUpdate", func(ctx context.Context) (goopenzwave.ControllerCommandResult, error) {
+			return manager.RequestNodeNeighborUpdateContext(ctx, homeID, 2)
+		}, goopenzwave.ControllerStateCompleted, false},
+		{"RemoveFailedNode OK", func(ctx context.Context) (goopenzwave.ControllerCommandResult, error) {
+			return manager.RemoveFailedNodeContext(ctx, homeID, 2)
+		}, goopenzwave.ControllerStateNodeOK, true},
+		{"RemoveFailedNode", func(ctx context.Context) (goopenzwave.ControllerCommandResult, error) {
+			return manager.RemoveFailedNodeContext(ctx, homeID, 3)
+		}, goopenzwave.ControllerStateCompleted, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			result, err := tt.run(ctx)
+			switch {
+			case err == context.DeadlineExceeded:
+				t.Fatalf("command did not finish: %v", result)
+			case tt.fail && err == nil:
+				t.Errorf("error = nil, want a ControllerCommandError")
+			case tt.fail == false && err != nil:
+				t.Errorf("error = %v, want nil", err)
+			}
+			if result.State != tt.state {
+				t.Errorf("result State = %s, want %s", result.State, tt.state)
+			}
+		})
+	}
+}
+
+func TestAddRemoveNodeContext(t *testing.T) {
+	network := sim.New()
+	manager := startNetwork(t, network)
+	homeID := network.HomeID()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// waitFor retries fn until the simulated controller is waiting for it.
+	waitFor := func(fn func() bool) {
+		for fn() == false && ctx.Err() == nil {
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	go waitFor(func() bool { return network.Include(sim.NewSwitch(5, "Switch")) })
+	result, err := manager.AddNodeContext(ctx, homeID, false)
+	if err != nil {
+		t.Fatalf("AddNodeContext error = %v", err)
+	}
+	if result.NodeID != 5 {
+		t.Errorf("AddNodeContext NodeID = %d, want 5", result.NodeID)
+	}
+
+	go waitFor(func() bool { return network.Exclude(5) })
+	result, err = manager.RemoveNodeContext(ctx, homeID)
+	if err != nil {
+		t.Fatalf("RemoveNodeContext error = %v", err)
+	}
+	if result.NodeID != 5 {
+		t.Errorf("RemoveNodeContext NodeID = %d, want 5", result.NodeID)
+	}
+}
@@ -0,0 +1,1041 @@
+package goopenzwave
+
+import (
+	"context"
+)
+
+// The package level functions below are kept for compatibility with earlier
+// versions of this package, before the Manager type was introduced. Each one
+// calls the Manager method of the same name on the default Manager, see Start.
+
+//
+// Construction.
+//
+
+// GetVersionAsString calls Manager.GetVersionAsString on the default Manager.
+func GetVersionAsString() string {
+	return defaultManager().GetVersionAsString()
+}
+
+// GetVersionLongAsString calls Manager.GetVersionLongAsString on the default
+// Manager.
+func GetVersionLongAsString() string {
+	return defaultManager().GetVersionLongAsString()
+}
+
+// GetVersion calls Manager.GetVersion on the default Manager.
+func GetVersion() Version {
+	return defaultManager().GetVersion()
+}
+
+//
+// Configuration.
+//
+
+// WriteConfig calls Manager.WriteConfig on the default Manager.
+func WriteConfig(homeID uint32) {
+	defaultManager().WriteConfig(homeID)
+}
+
+//
+// Drivers.
+//
+
+// AddDriver calls Manager.AddDriver on the default Manager.
+func AddDriver(controllerPath string) error {
+	return defaultManager().AddDriver(controllerPath)
+}
+
+// RemoveDriver calls Manager.RemoveDriver on the default Manager.
+func RemoveDriver(controllerPath string) error {
+	return defaultManager().RemoveDriver(controllerPath)
+}
+
+// GetControllerNodeID calls Manager.GetControllerNodeID on the default Manager.
+func GetControllerNodeID(homeID uint32) uint8 {
+	return defaultManager().GetControllerNodeID(homeID)
+}
+
+// GetSUCNodeID calls Manager.GetSUCNodeID on the default Manager.
+func GetSUCNodeID(homeID uint32) uint8 {
+	return defaultManager().GetSUCNodeID(homeID)
+}
+
+// IsPrimaryController calls Manager.IsPrimaryController on the default Manager.
+func IsPrimaryController(homeID uint32) bool {
+	return defaultManager().IsPrimaryController(homeID)
+}
+
+// IsStaticUpdateController calls Manager.IsStaticUpdateController on the
+// default Manager.
+func IsStaticUpdateController(homeID uint32) bool {
+	return defaultManager().IsStaticUpdateController(homeID)
+}
+
+// IsBridgeController calls Manager.IsBridgeController on the default Manager.
+func IsBridgeController(homeID uint32) bool {
+	return defaultManager().IsBridgeController(homeID)
+}
+
+// GetLibraryVersion calls Manager.GetLibraryVersion on the default Manager.
+func GetLibraryVersion(homeID uint32) string {
+	return defaultManager().GetLibraryVersion(homeID)
+}
+
+// GetLibraryTypeName calls Manager.GetLibraryTypeName on the default Manager.
+func GetLibraryTypeName(homeID uint32) string {
+	return defaultManager().GetLibraryTypeName(homeID)
+}
+
+// GetSendQueueCount calls Manager.GetSendQueueCount on the default Manager.
+func GetSendQueueCount(homeID uint32) int32 {
+	return defaultManager().GetSendQueueCount(homeID)
+}
+
+// LogDriverStatistics calls Manager.LogDriverStatistics on the default Manager.
+func LogDriverStatistics(homeID uint32) {
+	defaultManager().LogDriverStatistics(homeID)
+}
+
+// GetDriverStatistics calls Manager.GetDriverStatistics on the default Manager.
+func GetDriverStatistics(homeID uint32) DriverStatistics {
+	return defaultManager().GetDriverStatistics(homeID)
+}
+
+// GetControllerPath calls Manager.GetControllerPath on the default Manager.
+func GetControllerPath(homeID uint32) string {
+	return defaultManager().GetControllerPath(homeID)
+}
+
+//
+// Polling Z-Wave devices.
+//
+
+// GetPollInterval calls Manager.GetPollInterval on the default Manager.
+func GetPollInterval() int32 {
+	return defaultManager().GetPollInterval()
+}
+
+// SetPollInterval calls Manager.SetPollInterval on the default Manager.
+func SetPollInterval(milliseconds int32, intervalBetweenPolls bool) {
+	defaultManager().SetPollInterval(milliseconds, intervalBetweenPolls)
+}
+
+// EnablePoll calls Manager.EnablePoll on the default Manager.
+func EnablePoll(homeID uint32, valueID uint64, intensity uint8) bool {
+	return defaultManager().EnablePoll(homeID, valueID, intensity)
+}
+
+// DisablePoll calls Manager.DisablePoll on the default Manager.
+func DisablePoll(homeID uint32, valueID uint64) bool {
+	return defaultManager().DisablePoll(homeID, valueID)
+}
+
+// IsPolled calls Manager.IsPolled on the default Manager.
+func IsPolled(homeID uint32, valueID uint64) bool {
+	return defaultManager().IsPolled(homeID, valueID)
+}
+
+// SetPollIntensity calls Manager.SetPollIntensity on the default Manager.
+func SetPollIntensity(homeID uint32, valueID uint64, intensity uint8) {
+	defaultManager().SetPollIntensity(homeID, valueID, intensity)
+}
+
+// GetPollIntensity calls Manager.GetPollIntensity on the default Manager.
+func GetPollIntensity(homeID uint32, valueID uint64) uint8 {
+	return defaultManager().GetPollIntensity(homeID, valueID)
+}
+
+//
+// Node information.
+//
+
+// RefreshNodeInfo calls Manager.RefreshNodeInfo on the default Manager.
+func RefreshNodeInfo(homeID uint32, nodeID uint8) bool {
+	return defaultManager().RefreshNodeInfo(homeID, nodeID)
+}
+
+// RequestNodeState calls Manager.RequestNodeState on the default Manager.
+func RequestNodeState(homeID uint32, nodeID uint8) bool {
+	return defaultManager().RequestNodeState(homeID, nodeID)
+}
+
+// RequestNodeDynamic calls Manager.RequestNodeDynamic on the default Manager.
+func RequestNodeDynamic(homeID uint32, nodeID uint8) bool {
+	return defaultManager().RequestNodeDynamic(homeID, nodeID)
+}
+
+// IsNodeListeningDevice calls Manager.IsNodeListeningDevice on the default
+// Manager.
+func IsNodeListeningDevice(homeID uint32, nodeID uint8) bool {
+	return defaultManager().IsNodeListeningDevice(homeID, nodeID)
+}
+
+// IsNodeFrequentListeningDevice calls Manager.IsNodeFrequentListeningDevice on
+// the default Manager.
+func IsNodeFrequentListeningDevice(homeID uint32, nodeID uint8) bool {
+	return defaultManager().IsNodeFrequentListeningDevice(homeID, nodeID)
+}
+
+// IsNodeBeamingDevice calls Manager.IsNodeBeamingDevice on the default Manager.
+func IsNodeBeamingDevice(homeID uint32, nodeID uint8) bool {
+	return defaultManager().IsNodeBeamingDevice(homeID, nodeID)
+}
+
+// IsNodeRoutingDevice calls Manager.IsNodeRoutingDevice on the default Manager.
+func IsNodeRoutingDevice(homeID uint32, nodeID uint8) bool {
+	return defaultManager().IsNodeRoutingDevice(homeID, nodeID)
+}
+
+// IsNodeSecurityDevice calls Manager.IsNodeSecurityDevice on the default
+// Manager.
+func IsNodeSecurityDevice(homeID uint32, nodeID uint8) bool {
+	return defaultManager().IsNodeSecurityDevice(homeID, nodeID)
+}
+
+// GetNodeMaxBaudRate calls Manager.GetNodeMaxBaudRate on the default Manager.
+func GetNodeMaxBaudRate(homeID uint32, nodeID uint8) uint32 {
+	return defaultManager().GetNodeMaxBaudRate(homeID, nodeID)
+}
+
+// GetNodeVersion calls Manager.GetNodeVersion on the default Manager.
+func GetNodeVersion(homeID uint32, nodeID uint8) uint8 {
+	return defaultManager().GetNodeVersion(homeID, nodeID)
+}
+
+// GetNodeSecurity calls Manager.GetNodeSecurity on the default Manager.
+func GetNodeSecurity(homeID uint32, nodeID uint8) uint8 {
+	return defaultManager().GetNodeSecurity(homeID, nodeID)
+}
+
+// IsNodeZWavePlus calls Manager.IsNodeZWavePlus on the default Manager.
+func IsNodeZWavePlus(homeID uint32, nodeID uint8) bool {
+	return defaultManager().IsNodeZWavePlus(homeID, nodeID)
+}
+
+// GetNodeBasicType calls Manager.GetNodeBasicType on the default Manager.
+func GetNodeBasicType(homeID uint32, nodeID uint8) uint8 {
+	return defaultManager().GetNodeBasicType(homeID, nodeID)
+}
+
+// GetNodeGenericType calls Manager.GetNodeGenericType on the default Manager.
+func GetNodeGenericType(homeID uint32, nodeID uint8) uint8 {
+	return defaultManager().GetNodeGenericType(homeID, nodeID)
+}
+
+// GetNodeSpecificType calls Manager.GetNodeSpecificType on the default Manager.
+func GetNodeSpecificType(homeID uint32, nodeID uint8) uint8 {
+	return defaultManager().GetNodeSpecificType(homeID, nodeID)
+}
+
+// GetNodeType calls Manager.GetNodeType on the default Manager.
+func GetNodeType(homeID uint32, nodeID uint8) string {
+	return defaultManager().GetNodeType(homeID, nodeID)
+}
+
+// GetNodeNeighbors calls Manager.GetNodeNeighbors on the default Manager.
+func GetNodeNeighbors(homeID uint32, nodeID uint8) []uint8 {
+	return defaultManager().GetNodeNeighbors(homeID, nodeID)
+}
+
+// GetTopology calls Manager.Topology on the default Manager.
+func GetTopology(homeID uint32) *Topology {
+	return defaultManager().Topology(homeID)
+}
+
+// GetNodeManufacturerName calls Manager.GetNodeManufacturerName on the default
+// Manager.
+func GetNodeManufacturerName(homeID uint32, nodeID uint8) string {
+	return defaultManager().GetNodeManufacturerName(homeID, nodeID)
+}
+
+// GetNodeProductName calls Manager.GetNodeProductName on the default Manager.
+func GetNodeProductName(homeID uint32, nodeID uint8) string {
+	return defaultManager().GetNodeProductName(homeID, nodeID)
+}
+
+// GetNodeName calls Manager.GetNodeName on the default Manager.
+func GetNodeName(homeID uint32, nodeID uint8) string {
+	return defaultManager().GetNodeName(homeID, nodeID)
+}
+
+// GetNodeLocation calls Manager.GetNodeLocation on the default Manager.
+func GetNodeLocation(homeID uint32, nodeID uint8) string {
+	return defaultManager().GetNodeLocation(homeID, nodeID)
+}
+
+// GetNodeManufacturerID calls Manager.GetNodeManufacturerID on the default
+// Manager.
+func GetNodeManufacturerID(homeID uint32, nodeID uint8) string {
+	return defaultManager().GetNodeManufacturerID(homeID, nodeID)
+}
+
+// GetNodeProductType calls Manager.GetNodeProductType on the default Manager.
+func GetNodeProductType(homeID uint32, nodeID uint8) string {
+	return defaultManager().GetNodeProductType(homeID, nodeID)
+}
+
+// GetNodeProductID calls Manager.GetNodeProductID on the default Manager.
+func GetNodeProductID(homeID uint32, nodeID uint8) string {
+	return defaultManager().GetNodeProductID(homeID, nodeID)
+}
+
+// SetNodeManufacturerName calls Manager.SetNodeManufacturerName on the default
+// Manager.
+func SetNodeManufacturerName(homeID uint32, nodeID uint8, manufacturerName string) {
+	defaultManager().SetNodeManufacturerName(homeID, nodeID, manufacturerName)
+}
+
+// SetNodeProductName calls Manager.SetNodeProductName on the default Manager.
+func SetNodeProductName(homeID uint32, nodeID uint8, productName string) {
+	defaultManager().SetNodeProductName(homeID, nodeID, productName)
+}
+
+// SetNodeName calls Manager.SetNodeName on the default Manager.
+func SetNodeName(homeID uint32, nodeID uint8, nodeName string) {
+	defaultManager().SetNodeName(homeID, nodeID, nodeName)
+}
+
+// SetNodeLocation calls Manager.SetNodeLocation on the default Manager.
+func SetNodeLocation(homeID uint32, nodeID uint8, location string) {
+	defaultManager().SetNodeLocation(homeID, nodeID, location)
+}
+
+// SetNodeOn calls Manager.SetNodeOn on the default Manager.
+func SetNodeOn(homeID uint32, nodeID uint8) {
+	defaultManager().SetNodeOn(homeID, nodeID)
+}
+
+// SetNodeOff calls Manager.SetNodeOff on the default Manager.
+func SetNodeOff(homeID uint32, nodeID uint8) {
+	defaultManager().SetNodeOff(homeID, nodeID)
+}
+
+// SetNodeLevel calls Manager.SetNodeLevel on the default Manager.
+func SetNodeLevel(homeID uint32, nodeID uint8, level uint8) {
+	defaultManager().SetNodeLevel(homeID, nodeID, level)
+}
+
+// IsNodeInfoReceived calls Manager.IsNodeInfoReceived on the default Manager.
+func IsNodeInfoReceived(homeID uint32, nodeID uint8) bool {
+	return defaultManager().IsNodeInfoReceived(homeID, nodeID)
+}
+
+// GetNodeClassInformation calls Manager.GetNodeClassInformation on the default
+// Manager.
+func GetNodeClassInformation(homeID uint32, nodeID uint8, commandClassID uint8) (bool, string, uint8) {
+	return defaultManager().GetNodeClassInformation(homeID, nodeID, commandClassID)
+}
+
+// GetNodeCommandClasses calls Manager.GetNodeCommandClasses on the default
+// Manager.
+func GetNodeCommandClasses(homeID uint32, nodeID uint8) []NodeCommandClass {
+	return defaultManager().GetNodeCommandClasses(homeID, nodeID)
+}
+
+// IsNodeAwake calls Manager.IsNodeAwake on the default Manager.
+func IsNodeAwake(homeID uint32, nodeID uint8) bool {
+	return defaultManager().IsNodeAwake(homeID, nodeID)
+}
+
+// IsNodeFailed calls Manager.IsNodeFailed on the default Manager.
+func IsNodeFailed(homeID uint32, nodeID uint8) bool {
+	return defaultManager().IsNodeFailed(homeID, nodeID)
+}
+
+// GetNodeQueryStage calls Manager.GetNodeQueryStage on the default Manager.
+func GetNodeQueryStage(homeID uint32, nodeID uint8) string {
+	return defaultManager().GetNodeQueryStage(homeID, nodeID)
+}
+
+// GetNodeDeviceType calls Manager.GetNodeDeviceType on the default Manager.
+func GetNodeDeviceType(homeID uint32, nodeID uint8) uint16 {
+	return defaultManager().GetNodeDeviceType(homeID, nodeID)
+}
+
+// GetNodeDeviceTypeString calls Manager.GetNodeDeviceTypeString on the default
+// Manager.
+func GetNodeDeviceTypeString(homeID uint32, nodeID uint8) string {
+	return defaultManager().GetNodeDeviceTypeString(homeID, nodeID)
+}
+
+// GetNodeRole calls Manager.GetNodeRole on the default Manager.
+func GetNodeRole(homeID uint32, nodeID uint8) uint8 {
+	return defaultManager().GetNodeRole(homeID, nodeID)
+}
+
+// GetNodeRoleString calls Manager.GetNodeRoleString on the default Manager.
+func GetNodeRoleString(homeID uint32, nodeID uint8) string {
+	return defaultManager().GetNodeRoleString(homeID, nodeID)
+}
+
+// GetNodePlusType calls Manager.GetNodePlusType on the default Manager.
+func GetNodePlusType(homeID uint32, nodeID uint8) uint8 {
+	return defaultManager().GetNodePlusType(homeID, nodeID)
+}
+
+// GetNodePlusTypeString calls Manager.GetNodePlusTypeString on the default
+// Manager.
+func GetNodePlusTypeString(homeID uint32, nodeID uint8) string {
+	return defaultManager().GetNodePlusTypeString(homeID, nodeID)
+}
+
+// GetNodeDeviceClass calls Manager.GetNodeDeviceClass on the default Manager.
+func GetNodeDeviceClass(homeID uint32, nodeID uint8) DeviceClass {
+	return defaultManager().GetNodeDeviceClass(homeID, nodeID)
+}
+
+// SetNodeConfigParam calls Manager.SetNodeConfigParam on the default Manager.
+func SetNodeConfigParam(homeID uint32, nodeID uint8, param uint8, value int32, size uint8) bool {
+	return defaultManager().SetNodeConfigParam(homeID, nodeID, param, value, size)
+}
+
+// SwitchAllOn calls Manager.SwitchAllOn on the default Manager.
+func SwitchAllOn(homeID uint32) {
+	defaultManager().SwitchAllOn(homeID)
+}
+
+// SwitchAllOff calls Manager.SwitchAllOff on the default Manager.
+func SwitchAllOff(homeID uint32) {
+	defaultManager().SwitchAllOff(homeID)
+}
+
+// RequestNodeConfigParam calls Manager.RequestNodeConfigParam on the default
+// Manager.
+func RequestNodeConfigParam(homeID uint32, nodeID uint8, param uint8) {
+	defaultManager().RequestNodeConfigParam(homeID, nodeID, param)
+}
+
+// RequestNodeAllConfigParam calls Manager.RequestNodeAllConfigParam on the
+// default Manager.
+func RequestNodeAllConfigParam(homeID uint32, nodeID uint8) {
+	defaultManager().RequestNodeAllConfigParam(homeID, nodeID)
+}
+
+// GetNodeStatistics calls Manager.GetNodeStatistics on the default Manager.
+func GetNodeStatistics(homeID uint32, nodeID uint8) NodeStatistics {
+	return defaultManager().GetNodeStatistics(homeID, nodeID)
+}
+
+//
+// Values.
+//
+
+// GetValueLabel calls Manager.GetValueLabel on the default Manager.
+func GetValueLabel(homeID uint32, valueID uint64) string {
+	return defaultManager().GetValueLabel(homeID, valueID)
+}
+
+// SetValueLabel calls Manager.SetValueLabel on the default Manager.
+func SetValueLabel(homeID uint32, valueID uint64, value string) {
+	defaultManager().SetValueLabel(homeID, valueID, value)
+}
+
+// GetValueUnits calls Manager.GetValueUnits on the default Manager.
+func GetValueUnits(homeID uint32, valueID uint64) string {
+	return defaultManager().GetValueUnits(homeID, valueID)
+}
+
+// SetValueUnits calls Manager.SetValueUnits on the default Manager.
+func SetValueUnits(homeID uint32, valueID uint64, value string) {
+	defaultManager().SetValueUnits(homeID, valueID, value)
+}
+
+// GetValueHelp calls Manager.GetValueHelp on the default Manager.
+func GetValueHelp(homeID uint32, valueID uint64) string {
+	return defaultManager().GetValueHelp(homeID, valueID)
+}
+
+// SetValueHelp calls Manager.SetValueHelp on the default Manager.
+func SetValueHelp(homeID uint32, valueID uint64, value string) {
+	defaultManager().SetValueHelp(homeID, valueID, value)
+}
+
+// GetValueMin calls Manager.GetValueMin on the default Manager.
+func GetValueMin(homeID uint32, valueID uint64) int32 {
+	return defaultManager().GetValueMin(homeID, valueID)
+}
+
+// GetValueMax calls Manager.GetValueMax on the default Manager.
+func GetValueMax(homeID uint32, valueID uint64) int32 {
+	return defaultManager().GetValueMax(homeID, valueID)
+}
+
+// IsValueReadOnly calls Manager.IsValueReadOnly on the default Manager.
+func IsValueReadOnly(homeID uint32, valueID uint64) bool {
+	return defaultManager().IsValueReadOnly(homeID, valueID)
+}
+
+// IsValueWriteOnly calls Manager.IsValueWriteOnly on the default Manager.
+func IsValueWriteOnly(homeID uint32, valueID uint64) bool {
+	return defaultManager().IsValueWriteOnly(homeID, valueID)
+}
+
+// IsValueSet calls Manager.IsValueSet on the default Manager.
+func IsValueSet(homeID uint32, valueID uint64) bool {
+	return defaultManager().IsValueSet(homeID, valueID)
+}
+
+// IsValuePolled calls Manager.IsValuePolled on the default Manager.
+func IsValuePolled(homeID uint32, valueID uint64) bool {
+	return defaultManager().IsValuePolled(homeID, valueID)
+}
+
+// GetValueAsBool calls Manager.GetValueAsBool on the default Manager.
+func GetValueAsBool(homeID uint32, valueID uint64) (bool, error) {
+	return defaultManager().GetValueAsBool(homeID, valueID)
+}
+
+// GetValueAsByte calls Manager.GetValueAsByte on the default Manager.
+func GetValueAsByte(homeID uint32, valueID uint64) (byte, error) {
+	return defaultManager().GetValueAsByte(homeID, valueID)
+}
+
+// GetValueAsFloat calls Manager.GetValueAsFloat on the default Manager.
+func GetValueAsFloat(homeID uint32, valueID uint64) (float32, error) {
+	return defaultManager().GetValueAsFloat(homeID, valueID)
+}
+
+// GetValueAsDecimal calls Manager.GetValueAsDecimal on the default Manager.
+func GetValueAsDecimal(homeID uint32, valueID uint64) (Decimal, error) {
+	return defaultManager().GetValueAsDecimal(homeID, valueID)
+}
+
+// GetValueAsInt calls Manager.GetValueAsInt on the default Manager.
+func GetValueAsInt(homeID uint32, valueID uint64) (int32, error) {
+	return defaultManager().GetValueAsInt(homeID, valueID)
+}
+
+// GetValueAsShort calls Manager.GetValueAsShort on the default Manager.
+func GetValueAsShort(homeID uint32, valueID uint64) (int16, error) {
+	return defaultManager().GetValueAsShort(homeID, valueID)
+}
+
+// GetValueAsString calls Manager.GetValueAsString on the default Manager.
+func GetValueAsString(homeID uint32, valueID uint64) string {
+	return defaultManager().GetValueAsString(homeID, valueID)
+}
+
+// GetValueAsRaw calls Manager.GetValueAsRaw on the default Manager.
+func GetValueAsRaw(homeID uint32, valueID uint64) ([]byte, error) {
+	return defaultManager().GetValueAsRaw(homeID, valueID)
+}
+
+// GetValueListSelectionAsString calls Manager.GetValueListSelectionAsString on
+// the default Manager.
+func GetValueListSelectionAsString(homeID uint32, valueID uint64) (string, error) {
+	return defaultManager().GetValueListSelectionAsString(homeID, valueID)
+}
+
+// GetValueListSelectionAsInt32 calls Manager.GetValueListSelectionAsInt32 on
+// the default Manager.
+func GetValueListSelectionAsInt32(homeID uint32, valueID uint64) (int32, error) {
+	return defaultManager().GetValueListSelectionAsInt32(homeID, valueID)
+}
+
+// GetValueListItems calls Manager.GetValueListItems on the default Manager.
+func GetValueListItems(homeID uint32, valueID uint64) ([]string, error) {
+	return defaultManager().GetValueListItems(homeID, valueID)
+}
+
+// GetValueFloatPrecision calls Manager.GetValueFloatPrecision on the default
+// Manager.
+func GetValueFloatPrecision(homeID uint32, valueID uint64) (uint8, error) {
+	return defaultManager().GetValueFloatPrecision(homeID, valueID)
+}
+
+// GetValueAsBitSet calls Manager.GetValueAsBitSet on the default Manager.
+func GetValueAsBitSet(homeID uint32, valueID uint64, pos uint8) (bool, error) {
+	return defaultManager().GetValueAsBitSet(homeID, valueID, pos)
+}
+
+// GetBitMask calls Manager.GetBitMask on the default Manager.
+func GetBitMask(homeID uint32, valueID uint64) (uint32, error) {
+	return defaultManager().GetBitMask(homeID, valueID)
+}
+
+// GetBitSetSize calls Manager.GetBitSetSize on the default Manager.
+func GetBitSetSize(homeID uint32, valueID uint64) (uint8, error) {
+	return defaultManager().GetBitSetSize(homeID, valueID)
+}
+
+// SetValueBool calls Manager.SetValueBool on the default Manager.
+func SetValueBool(homeID uint32, valueID uint64, value bool) error {
+	return defaultManager().SetValueBool(homeID, valueID, value)
+}
+
+// SetValueUint8 calls Manager.SetValueUint8 on the default Manager.
+func SetValueUint8(homeID uint32, valueID uint64, value uint8) error {
+	return defaultManager().SetValueUint8(homeID, valueID, value)
+}
+
+// SetValueFloat calls Manager.SetValueFloat on the default Manager.
+func SetValueFloat(homeID uint32, valueID uint64, value float32) error {
+	return defaultManager().SetValueFloat(homeID, valueID, value)
+}
+
+// SetValueDecimal calls Manager.SetValueDecimal on the default Manager.
+func SetValueDecimal(homeID uint32, valueID uint64, value Decimal) error {
+	return defaultManager().SetValueDecimal(homeID, valueID, value)
+}
+
+// SetValueInt32 calls Manager.SetValueInt32 on the default Manager.
+func SetValueInt32(homeID uint32, valueID uint64, value int32) error {
+	return defaultManager().SetValueInt32(homeID, valueID, value)
+}
+
+// SetValueInt16 calls Manager.SetValueInt16 on the default Manager.
+func SetValueInt16(homeID uint32, valueID uint64, value int16) error {
+	return defaultManager().SetValueInt16(homeID, valueID, value)
+}
+
+// SetValueBytes calls Manager.SetValueBytes on the default Manager.
+func SetValueBytes(homeID uint32, valueID uint64, value []byte) error {
+	return defaultManager().SetValueBytes(homeID, valueID, value)
+}
+
+// SetValueString calls Manager.SetValueString on the default Manager.
+func SetValueString(homeID uint32, valueID uint64, value string) error {
+	return defaultManager().SetValueString(homeID, valueID, value)
+}
+
+// SetValueListSelection calls Manager.SetValueListSelection on the default
+// Manager.
+func SetValueListSelection(homeID uint32, valueID uint64, selection string) error {
+	return defaultManager().SetValueListSelection(homeID, valueID, selection)
+}
+
+// SetValueBitSet calls Manager.SetValueBitSet on the default Manager.
+func SetValueBitSet(homeID uint32, valueID uint64, pos uint8, value bool) error {
+	return defaultManager().SetValueBitSet(homeID, valueID, pos, value)
+}
+
+// SetBitMask calls Manager.SetBitMask on the default Manager.
+func SetBitMask(homeID uint32, valueID uint64, mask uint32) error {
+	return defaultManager().SetBitMask(homeID, valueID, mask)
+}
+
+// RefreshValue calls Manager.RefreshValue on the default Manager.
+func RefreshValue(homeID uint32, valueID uint64) bool {
+	return defaultManager().RefreshValue(homeID, valueID)
+}
+
+// SetChangeVerified calls Manager.SetChangeVerified on the default Manager.
+func SetChangeVerified(homeID uint32, valueID uint64, verify bool) {
+	defaultManager().SetChangeVerified(homeID, valueID, verify)
+}
+
+// GetChangeVerified calls Manager.GetChangeVerified on the default Manager.
+func GetChangeVerified(homeID uint32, valueID uint64) bool {
+	return defaultManager().GetChangeVerified(homeID, valueID)
+}
+
+// PressButton calls Manager.PressButton on the default Manager.
+func PressButton(homeID uint32, valueID uint64) error {
+	return defaultManager().PressButton(homeID, valueID)
+}
+
+// ReleaseButton calls Manager.ReleaseButton on the default Manager.
+func ReleaseButton(homeID uint32, valueID uint64) error {
+	return defaultManager().ReleaseButton(homeID, valueID)
+}
+
+// GetNumSwitchPoints calls Manager.GetNumSwitchPoints on the default Manager.
+func GetNumSwitchPoints(homeID uint32, valueID uint64) (uint8, error) {
+	return defaultManager().GetNumSwitchPoints(homeID, valueID)
+}
+
+// SetSwitchPoint calls Manager.SetSwitchPoint on the default Manager.
+func SetSwitchPoint(homeID uint32, valueID uint64, hours, minutes uint8, setback int8) error {
+	return defaultManager().SetSwitchPoint(homeID, valueID, hours, minutes, setback)
+}
+
+// RemoveSwitchPoint calls Manager.RemoveSwitchPoint on the default Manager.
+func RemoveSwitchPoint(homeID uint32, valueID uint64, hours, minutes uint8) error {
+	return defaultManager().RemoveSwitchPoint(homeID, valueID, hours, minutes)
+}
+
+// ClearSwitchPoints calls Manager.ClearSwitchPoints on the default Manager.
+func ClearSwitchPoints(homeID uint32, valueID uint64) {
+	defaultManager().ClearSwitchPoints(homeID, valueID)
+}
+
+// GetSwitchPoint calls Manager.GetSwitchPoint on the default Manager.
+func GetSwitchPoint(homeID uint32, valueID uint64, idx uint8) (uint8, uint8, int8, error) {
+	return defaultManager().GetSwitchPoint(homeID, valueID, idx)
+}
+
+//
+// Groups.
+//
+
+// GetNumGroups calls Manager.GetNumGroups on the default Manager.
+func GetNumGroups(homeID uint32, nodeID uint8) uint8 {
+	return defaultManager().GetNumGroups(homeID, nodeID)
+}
+
+// GetAssociations calls Manager.GetAssociations on the default Manager.
+func GetAssociations(homeID uint32, nodeID uint8, groupIDx uint8) []Association {
+	return defaultManager().GetAssociations(homeID, nodeID, groupIDx)
+}
+
+// GetGroups calls Manager.GetGroups on the default Manager.
+func GetGroups(homeID uint32, nodeID uint8) []Group {
+	return defaultManager().GetGroups(homeID, nodeID)
+}
+
+// GetMaxAssociations calls Manager.GetMaxAssociations on the default Manager.
+func GetMaxAssociations(homeID uint32, nodeID uint8, groupIDx uint8) uint8 {
+	return defaultManager().GetMaxAssociations(homeID, nodeID, groupIDx)
+}
+
+// GetGroupLabel calls Manager.GetGroupLabel on the default Manager.
+func GetGroupLabel(homeID uint32, nodeID uint8, groupIDx uint8) string {
+	return defaultManager().GetGroupLabel(homeID, nodeID, groupIDx)
+}
+
+// AddAssociation calls Manager.AddAssociation on the default Manager.
+func AddAssociation(homeID uint32, nodeID uint8, groupIDx uint8, targetNodeID uint8, instance uint8) {
+	defaultManager().AddAssociation(homeID, nodeID, groupIDx, targetNodeID, instance)
+}
+
+// RemoveAssociation calls Manager.RemoveAssociation on the default Manager.
+func RemoveAssociation(homeID uint32, nodeID uint8, groupIDx uint8, targetNodeID uint8, instance uint8) {
+	defaultManager().RemoveAssociation(homeID, nodeID, groupIDx, targetNodeID, instance)
+}
+
+//
+// Controller commands.
+//
+
+// ResetController calls Manager.ResetController on the default Manager.
+func ResetController(homeID uint32) {
+	defaultManager().ResetController(homeID)
+}
+
+// SoftReset calls Manager.SoftReset on the default Manager.
+func SoftReset(homeID uint32) {
+	defaultManager().SoftReset(homeID)
+}
+
+// CancelControllerCommand calls Manager.CancelControllerCommand on the default
+// Manager.
+func CancelControllerCommand(homeID uint32) {
+	defaultManager().CancelControllerCommand(homeID)
+}
+
+//
+// Network commands.
+//
+
+// TestNetworkNode calls Manager.TestNetworkNode on the default Manager.
+func TestNetworkNode(homeID uint32, nodeID uint8, count uint32) {
+	defaultManager().TestNetworkNode(homeID, nodeID, count)
+}
+
+// TestNetwork calls Manager.TestNetwork on the default Manager.
+func TestNetwork(homeID uint32, count uint32) {
+	defaultManager().TestNetwork(homeID, count)
+}
+
+// HealNetworkNode calls Manager.HealNetworkNode on the default Manager.
+func HealNetworkNode(homeID uint32, nodeID uint8, doRR bool) {
+	defaultManager().HealNetworkNode(homeID, nodeID, doRR)
+}
+
+// HealNetwork calls Manager.HealNetwork on the default Manager.
+func HealNetwork(homeID uint32, doRR bool) {
+	defaultManager().HealNetwork(homeID, doRR)
+}
+
+// AddNode calls Manager.AddNode on the default Manager.
+func AddNode(homeID uint32, doSecurity bool) bool {
+	return defaultManager().AddNode(homeID, doSecurity)
+}
+
+// RemoveNode calls Manager.RemoveNode on the default Manager.
+func RemoveNode(homeID uint32) bool {
+	return defaultManager().RemoveNode(homeID)
+}
+
+// RemoveFailedNode calls Manager.RemoveFailedNode on the default Manager.
+func RemoveFailedNode(homeID uint32, nodeID uint8) bool {
+	return defaultManager().RemoveFailedNode(homeID, nodeID)
+}
+
+// HasNodeFailed calls Manager.HasNodeFailed on the default Manager.
+func HasNodeFailed(homeID uint32, nodeID uint8) bool {
+	return defaultManager().HasNodeFailed(homeID, nodeID)
+}
+
+// RequestNodeNeighborUpdate calls Manager.RequestNodeNeighborUpdate on the
+// default Manager.
+func RequestNodeNeighborUpdate(homeID uint32, nodeID uint8) bool {
+	return defaultManager().RequestNodeNeighborUpdate(homeID, nodeID)
+}
+
+// AssignReturnRoute calls Manager.AssignReturnRoute on the default Manager.
+func AssignReturnRoute(homeID uint32, nodeID uint8) bool {
+	return defaultManager().AssignReturnRoute(homeID, nodeID)
+}
+
+// DeleteAllReturnRoutes calls Manager.DeleteAllReturnRoutes on the default
+// Manager.
+func DeleteAllReturnRoutes(homeID uint32, nodeID uint8) bool {
+	return defaultManager().DeleteAllReturnRoutes(homeID, nodeID)
+}
+
+// SendNodeInformation calls Manager.SendNodeInformation on the default Manager.
+func SendNodeInformation(homeID uint32, nodeID uint8) bool {
+	return defaultManager().SendNodeInformation(homeID, nodeID)
+}
+
+// CreateNewPrimary calls Manager.CreateNewPrimary on the default Manager.
+func CreateNewPrimary(homeID uint32) bool {
+	return defaultManager().CreateNewPrimary(homeID)
+}
+
+// ReceiveConfiguration calls Manager.ReceiveConfiguration on the default
+// Manager.
+func ReceiveConfiguration(homeID uint32) bool {
+	return defaultManager().ReceiveConfiguration(homeID)
+}
+
+// ReplaceFailedNode calls Manager.ReplaceFailedNode on the default Manager.
+func ReplaceFailedNode(homeID uint32, nodeID uint8) bool {
+	return defaultManager().ReplaceFailedNode(homeID, nodeID)
+}
+
+// TransferPrimaryRole calls Manager.TransferPrimaryRole on the default Manager.
+func TransferPrimaryRole(homeID uint32) bool {
+	return defaultManager().TransferPrimaryRole(homeID)
+}
+
+// RequestNetworkUpdate calls Manager.RequestNetworkUpdate on the default
+// Manager.
+func RequestNetworkUpdate(homeID uint32, nodeID uint8) bool {
+	return defaultManager().RequestNetworkUpdate(homeID, nodeID)
+}
+
+// ReplicationSend calls Manager.ReplicationSend on the default Manager.
+func ReplicationSend(homeID uint32, nodeID uint8) bool {
+	return defaultManager().ReplicationSend(homeID, nodeID)
+}
+
+// CreateButton calls Manager.CreateButton on the default Manager.
+func CreateButton(homeID uint32, nodeID uint8, buttonID uint8) bool {
+	return defaultManager().CreateButton(homeID, nodeID, buttonID)
+}
+
+// DeleteButton calls Manager.DeleteButton on the default Manager.
+func DeleteButton(homeID uint32, nodeID uint8, buttonID uint8) bool {
+	return defaultManager().DeleteButton(homeID, nodeID, buttonID)
+}
+
+//
+// Scene commands.
+//
+
+// GetNumScenes calls Manager.GetNumScenes on the default Manager.
+func GetNumScenes() uint8 {
+	return defaultManager().GetNumScenes()
+}
+
+// GetAllScenes calls Manager.GetAllScenes on the default Manager.
+func GetAllScenes() []uint8 {
+	return defaultManager().GetAllScenes()
+}
+
+// GetScenes calls Manager.GetScenes on the default Manager.
+func GetScenes() []*Scene {
+	return defaultManager().GetScenes()
+}
+
+// ExportScenes calls Manager.ExportScenes on the default Manager.
+func ExportScenes() *SceneExport {
+	return defaultManager().ExportScenes()
+}
+
+// ImportScenes calls Manager.ImportScenes on the default Manager.
+func ImportScenes(homeID uint32, export *SceneExport, options SceneImportOptions) (*SceneImportReport, error) {
+	return defaultManager().ImportScenes(homeID, export, options)
+}
+
+// RunScene calls Manager.RunScene on the default Manager.
+func RunScene(ctx context.Context, program *SceneProgram, progress func(SceneEvent)) error {
+	return defaultManager().RunScene(ctx, program, progress)
+}
+
+// RemoveAllScenes calls Manager.RemoveAllScenes on the default Manager.
+func RemoveAllScenes(homeID uint32) {
+	defaultManager().RemoveAllScenes(homeID)
+}
+
+// CreateScene calls Manager.CreateScene on the default Manager.
+func CreateScene() uint8 {
+	return defaultManager().CreateScene()
+}
+
+// RemoveScene calls Manager.RemoveScene on the default Manager.
+func RemoveScene(sceneID uint8) bool {
+	return defaultManager().RemoveScene(sceneID)
+}
+
+// AddSceneValueBool calls Manager.AddSceneValueBool on the default Manager.
+func AddSceneValueBool(sceneID uint8, homeID uint32, valueID uint64, value bool) bool {
+	return defaultManager().AddSceneValueBool(sceneID, homeID, valueID, value)
+}
+
+// AddSceneValueUint8 calls Manager.AddSceneValueUint8 on the default Manager.
+func AddSceneValueUint8(sceneID uint8, homeID uint32, valueID uint64, value uint8) bool {
+	return defaultManager().AddSceneValueUint8(sceneID, homeID, valueID, value)
+}
+
+// AddSceneValueFloat calls Manager.AddSceneValueFloat on the default Manager.
+func AddSceneValueFloat(sceneID uint8, homeID uint32, valueID uint64, value float32) bool {
+	return defaultManager().AddSceneValueFloat(sceneID, homeID, valueID, value)
+}
+
+// AddSceneValueInt32 calls Manager.AddSceneValueInt32 on the default Manager.
+func AddSceneValueInt32(sceneID uint8, homeID uint32, valueID uint64, value int32) bool {
+	return defaultManager().AddSceneValueInt32(sceneID, homeID, valueID, value)
+}
+
+// AddSceneValueInt16 calls Manager.AddSceneValueInt16 on the default Manager.
+func AddSceneValueInt16(sceneID uint8, homeID uint32, valueID uint64, value int16) bool {
+	return defaultManager().AddSceneValueInt16(sceneID, homeID, valueID, value)
+}
+
+// AddSceneValueString calls Manager.AddSceneValueString on the default Manager.
+func AddSceneValueString(sceneID uint8, homeID uint32, valueID uint64, value string) bool {
+	return defaultManager().AddSceneValueString(sceneID, homeID, valueID, value)
+}
+
+// AddSceneValueListSelectionString calls
+// Manager.AddSceneValueListSelectionString on the default Manager.
+func AddSceneValueListSelectionString(sceneID uint8, homeID uint32, valueID uint64, value string) bool {
+	return defaultManager().AddSceneValueListSelectionString(sceneID, homeID, valueID, value)
+}
+
+// AddSceneValueListSelectionInt32 calls Manager.AddSceneValueListSelectionInt32
+// on the default Manager.
+func AddSceneValueListSelectionInt32(sceneID uint8, homeID uint32, valueID uint64, value int32) bool {
+	return defaultManager().AddSceneValueListSelectionInt32(sceneID, homeID, valueID, value)
+}
+
+// RemoveSceneValue calls Manager.RemoveSceneValue on the default Manager.
+func RemoveSceneValue(sceneID uint8, homeID uint32, valueID uint64) bool {
+	return defaultManager().RemoveSceneValue(sceneID, homeID, valueID)
+}
+
+// SceneGetValues calls Manager.SceneGetValues on the default Manager.
+func SceneGetValues(sceneID uint8) []*ValueID {
+	return defaultManager().SceneGetValues(sceneID)
+}
+
+// GetSceneValueAsBool calls Manager.GetSceneValueAsBool on the default Manager.
+func GetSceneValueAsBool(sceneID uint8, homeID uint32, valueID uint64) (bool, error) {
+	return defaultManager().GetSceneValueAsBool(sceneID, homeID, valueID)
+}
+
+// GetSceneValueAsByte calls Manager.GetSceneValueAsByte on the default Manager.
+func GetSceneValueAsByte(sceneID uint8, homeID uint32, valueID uint64) (byte, error) {
+	return defaultManager().GetSceneValueAsByte(sceneID, homeID, valueID)
+}
+
+// GetSceneValueAsFloat calls Manager.GetSceneValueAsFloat on the default
+// Manager.
+func GetSceneValueAsFloat(sceneID uint8, homeID uint32, valueID uint64) (float32, error) {
+	return defaultManager().GetSceneValueAsFloat(sceneID, homeID, valueID)
+}
+
+// GetSceneValueAsInt calls Manager.GetSceneValueAsInt on the default Manager.
+func GetSceneValueAsInt(sceneID uint8, homeID uint32, valueID uint64) (int32, error) {
+	return defaultManager().GetSceneValueAsInt(sceneID, homeID, valueID)
+}
+
+// GetSceneValueAsShort calls Manager.GetSceneValueAsShort on the default
+// Manager.
+func GetSceneValueAsShort(sceneID uint8, homeID uint32, valueID uint64) (int16, error) {
+	return defaultManager().GetSceneValueAsShort(sceneID, homeID, valueID)
+}
+
+// GetSceneValueAsString calls Manager.GetSceneValueAsString on the default
+// Manager.
+func GetSceneValueAsString(sceneID uint8, homeID uint32, valueID uint64) (string, error) {
+	return defaultManager().GetSceneValueAsString(sceneID, homeID, valueID)
+}
+
+// GetSceneValueListSelectionString calls
+// Manager.GetSceneValueListSelectionString on the default Manager.
+func GetSceneValueListSelectionString(sceneID uint8, homeID uint32, valueID uint64) (string, error) {
+	return defaultManager().GetSceneValueListSelectionString(sceneID, homeID, valueID)
+}
+
+// GetSceneValueListSelectionInt32 calls Manager.GetSceneValueListSelectionInt32
+// on the default Manager.
+func GetSceneValueListSelectionInt32(sceneID uint8, homeID uint32, valueID uint64) (int32, error) {
+	return defaultManager().GetSceneValueListSelectionInt32(sceneID, homeID, valueID)
+}
+
+// SetSceneValueBool calls Manager.SetSceneValueBool on the default Manager.
+func SetSceneValueBool(sceneID uint8, homeID uint32, valueID uint64, value bool) error {
+	return defaultManager().SetSceneValueBool(sceneID, homeID, valueID, value)
+}
+
+// SetSceneValueUint8 calls Manager.SetSceneValueUint8 on the default Manager.
+func SetSceneValueUint8(sceneID uint8, homeID uint32, valueID uint64, value uint8) error {
+	return defaultManager().SetSceneValueUint8(sceneID, homeID, valueID, value)
+}
+
+// SetSceneValueFloat calls Manager.SetSceneValueFloat on the default Manager.
+func SetSceneValueFloat(sceneID uint8, homeID uint32, valueID uint64, value float32) error {
+	return defaultManager().SetSceneValueFloat(sceneID, homeID, valueID, value)
+}
+
+// SetSceneValueInt32 calls Manager.SetSceneValueInt32 on the default Manager.
+func SetSceneValueInt32(sceneID uint8, homeID uint32, valueID uint64, value int32) error {
+	return defaultManager().SetSceneValueInt32(sceneID, homeID, valueID, value)
+}
+
+// SetSceneValueInt16 calls Manager.SetSceneValueInt16 on the default Manager.
+func SetSceneValueInt16(sceneID uint8, homeID uint32, valueID uint64, value int16) error {
+	return defaultManager().SetSceneValueInt16(sceneID, homeID, valueID, value)
+}
+
+// SetSceneValueString calls Manager.SetSceneValueString on the default Manager.
+func SetSceneValueString(sceneID uint8, homeID uint32, valueID uint64, value string) error {
+	return defaultManager().SetSceneValueString(sceneID, homeID, valueID, value)
+}
+
+// SetSceneValueListSelectionString calls
+// Manager.SetSceneValueListSelectionString on the default Manager.
+func SetSceneValueListSelectionString(sceneID uint8, homeID uint32, valueID uint64, value string) error {
+	return defaultManager().SetSceneValueListSelectionString(sceneID, homeID, valueID, value)
+}
+
+// SetSceneValueListSelectionInt32 calls Manager.SetSceneValueListSelectionInt32
+// on the default Manager.
+func SetSceneValueListSelectionInt32(sceneID uint8, homeID uint32, valueID uint64, value int32) error {
+	return defaultManager().SetSceneValueListSelectionInt32(sceneID, homeID, valueID, value)
+}
+
+// GetSceneLabel calls Manager.GetSceneLabel on the default Manager.
+func GetSceneLabel(sceneID uint8) string {
+	return defaultManager().GetSceneLabel(sceneID)
+}
+
+// SetSceneLabel calls Manager.SetSceneLabel on the default Manager.
+func SetSceneLabel(sceneID uint8, value string) {
+	defaultManager().SetSceneLabel(sceneID, value)
+}
+
+// SceneExists calls Manager.SceneExists on the default Manager.
+func SceneExists(sceneID uint8) bool {
+	return defaultManager().SceneExists(sceneID)
+}
+
+// ActivateScene calls Manager.ActivateScene on the default Manager.
+func ActivateScene(sceneID uint8) error {
+	return defaultManager().ActivateScene(sceneID)
+}
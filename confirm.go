@@ -0,0 +1,154 @@
+package goopenzwave
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Errors returned by ValueID.SetAndConfirm.
+var (
+	ErrValueQueued = errors.New("value queued until node wakes")
+	ErrNodeDead    = errors.New("node is dead")
+	ErrNodeTimeout = errors.New("node did not respond")
+)
+
+// ValueRevertedError is returned by ValueID.SetAndConfirm when the device
+// reports a value other than the one that was set, and does not report the
+// value that was set before the deadline.
+type ValueRevertedError struct {
+	Want Value
+	Got  Value
+}
+
+func (e *ValueRevertedError) Error() string {
+	return fmt.Sprintf("value reverted: set %q, device reported %q", e.Want, e.Got)
+}
+
+// SetAndConfirm sets the value, as Set does, and waits for the device to
+// report it. Change verification is turned on for the value while waiting, and
+// the value is refreshed after it is set, so that the report comes from the
+// device rather than being assumed.
+//
+// If the node is asleep the value is queued by OpenZWave until it wakes up, and
+// ErrValueQueued is returned straight away; it is also returned if the node
+// goes to sleep before reporting. ErrNodeDead is returned without setting the
+// value if the node has failed, and also if it is found dead while waiting.
+//
+// Otherwise SetAndConfirm waits until the device reports the value that was
+// set, or until ctx is done, so ctx should have a deadline. Reports of other
+// values and messages to the node timing out do not end the wait, as a later
+// report may still confirm the value. If ctx is done first, a
+// *ValueRevertedError is returned if the device last reported a different
+// value, ErrNodeTimeout if a message to the node timed out after that, or
+// ctx.Err() if neither happened.
+//
+// Buttons do not report their state, so for a ButtonValue SetAndConfirm
+// returns once the value is set.
+func (v *ValueID) SetAndConfirm(ctx context.Context, value Value) error {
+	m := v.mgr()
+	sub := m.SubscribeChan(NotificationFilter{
+		HomeID: v.HomeID,
+		NodeID: v.NodeID,
+		Types: []NotificationType{
+			NotificationTypeValueChanged,
+			NotificationTypeValueRefreshed,
+			NotificationTypeNotification,
+		},
+	})
+	defer sub.Unsubscribe()
+
+	verified := v.GetChangeVerified()
+	if verified == false {
+		v.SetChangeVerified(true)
+		defer v.SetChangeVerified(false)
+	}
+
+	if m.IsNodeFailed(v.HomeID, v.NodeID) {
+		return ErrNodeDead
+	}
+	if err := v.Set(value); err != nil {
+		return err
+	}
+	if _, ok := value.(ButtonValue); ok {
+		return nil
+	}
+	awake := m.IsNodeListeningDevice(v.HomeID, v.NodeID) ||
+		m.IsNodeFrequentListeningDevice(v.HomeID, v.NodeID) ||
+		m.IsNodeAwake(v.HomeID, v.NodeID)
+	if awake == false {
+		return ErrValueQueued
+	}
+	v.Refresh()
+
+	// failure is the reason to give if no matching report arrives in time.
+	var failure error
+	for {
+		var notification *Notification
+		select {
+		case <-ctx.Done():
+			if failure != nil {
+				return failure
+			}
+			return ctx.Err()
+		case n, ok := <-sub.C:
+			if ok == false {
+				return &StateError{Op: "SetAndConfirm", Err: ErrManagerDestroyed}
+			}
+			notification = n
+		}
+
+		if notification.Type == NotificationTypeNotification {
+			if notification.Notification == nil {
+				continue
+			}
+			switch *notification.Notification {
+			case NotificationCodeDead:
+				return ErrNodeDead
+			case NotificationCodeTimeout:
+				failure = ErrNodeTimeout
+			case NotificationCodeSleep:
+				return ErrValueQueued
+			}
+			continue
+		}
+
+		if notification.ValueID == nil || notification.ValueID.ID != v.ID {
+			continue
+		}
+		got, err := v.Get()
+		if err != nil {
+			return err
+		}
+		if sameSetValue(value, got) == false {
+			failure = &ValueRevertedError{Want: value, Got: got}
+			continue
+		}
+		return nil
+	}
+}
+
+// sameSetValue returns true if got, as reported by the device, is the value
+// want which was set.
+func sameSetValue(want, got Value) bool {
+	switch w := want.(type) {
+	case ListValue:
+		g, ok := got.(ListValue)
+		if ok == false {
+			return false
+		}
+		if w.Selected != "" {
+			return w.Selected == g.Selected
+		}
+		return w.Index == g.Index
+	case DecimalValue:
+		g, ok := got.(DecimalValue)
+		if ok == false {
+			return false
+		}
+		// The value was rounded to the precision of the device when set.
+		rounded, err := Decimal(w).Rescale(g.Precision)
+		return err == nil && rounded == Decimal(g)
+	}
+	return sameValue(want, got)
+}
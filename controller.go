@@ -1,26 +1,23 @@
 package goopenzwave
 
-// #include "gzw_manager.h"
-import "C"
-
 // ResetController performs a hard reset on a PC Z-Wave Controller.
 //
 // Resets a controller and erases its network configuration settings. The
 // controller becomes a primary controller ready to add devices to a new
 // network.
-func ResetController(homeID uint32) {
-	C.manager_resetController(cmanager, C.uint32_t(homeID))
+func (m *Manager) ResetController(homeID uint32) {
+	m.backend.ResetController(homeID)
 }
 
 // SoftReset performs a soft reset on a PC Z-Wave Controller.
 //
 // Resets a controller without erasing its network configuration settings.
-func SoftReset(homeID uint32) {
-	C.manager_softReset(cmanager, C.uint32_t(homeID))
+func (m *Manager) SoftReset(homeID uint32) {
+	m.backend.SoftReset(homeID)
 }
 
 // CancelControllerCommand cancels any in-progress command running on a
 // controller.
-func CancelControllerCommand(homeID uint32) {
-	C.manager_cancelControllerCommand(cmanager, C.uint32_t(homeID))
+func (m *Manager) CancelControllerCommand(homeID uint32) {
+	m.backend.CancelControllerCommand(homeID)
 }
@@ -0,0 +1,179 @@
+package goopenzwave
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact decimal number, Mantissa × 10^-Precision. OpenZWave
+// holds decimal values as the digits reported by the device, with Precision
+// decimal places, so a Decimal can represent them without the rounding of a
+// float32; a meter reading of 12345.678 kWh is {12345678, 3}.
+type Decimal struct {
+	Mantissa  int64
+	Precision uint8
+}
+
+// maxDecimalPrecision is the largest Precision whose scale fits in an int64.
+const maxDecimalPrecision = 18
+
+// ParseDecimal parses a decimal number such as "-12.50", keeping the number
+// of decimal places as the Precision.
+func ParseDecimal(s string) (Decimal, error) {
+	digits := strings.TrimSpace(s)
+	negative := false
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		negative = digits[0] == '-'
+		digits = digits[1:]
+	}
+	whole, fraction, _ := strings.Cut(digits, ".")
+	if whole == "" && fraction == "" {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	if len(fraction) > maxDecimalPrecision {
+		return Decimal{}, fmt.Errorf("decimal %q has too many decimal places", s)
+	}
+	for _, c := range whole + fraction {
+		if c < '0' || c > '9' {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+		}
+	}
+	mantissa, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("decimal %q out of range", s)
+	}
+	if negative {
+		mantissa = -mantissa
+	}
+	return Decimal{Mantissa: mantissa, Precision: uint8(len(fraction))}, nil
+}
+
+// decimalFromFloat returns the shortest Decimal which reads back as the float.
+func decimalFromFloat(f float32) (Decimal, error) {
+	return ParseDecimal(strconv.FormatFloat(float64(f), 'f', -1, 32))
+}
+
+// String returns the number with exactly Precision decimal places.
+func (d Decimal) String() string {
+	digits := strconv.FormatUint(absInt64(d.Mantissa), 10)
+	if d.Precision > 0 {
+		if pad := int(d.Precision) + 1 - len(digits); pad > 0 {
+			digits = strings.Repeat("0", pad) + digits
+		}
+		split := len(digits) - int(d.Precision)
+		digits = digits[:split] + "." + digits[split:]
+	}
+	if d.Mantissa < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// Float64 returns the nearest float64 to the number.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// Rescale returns the number with the given Precision. Digits beyond the new
+// precision are rounded half away from zero. It returns an error if the number
+// does not fit at the new precision.
+func (d Decimal) Rescale(precision uint8) (Decimal, error) {
+	if precision > maxDecimalPrecision {
+		return d, fmt.Errorf("decimal precision %d out of range", precision)
+	}
+	mantissa := d.Mantissa
+	if d.Precision > precision {
+		// Rounding one digit at a time would round twice, taking 0.149 to
+		// 0.15 and then 0.2, so the digits are dropped in one division. Any
+		// digits beyond the largest scale can be truncated first, as they
+		// cannot change whether the remainder reaches a half.
+		drop := d.Precision - precision
+		for ; drop > maxDecimalPrecision; drop-- {
+			mantissa /= 10
+		}
+		scale := pow10(drop)
+		remainder := mantissa % scale
+		mantissa /= scale
+		if remainder >= scale/2 {
+			mantissa++
+		} else if remainder <= -scale/2 {
+			mantissa--
+		}
+	}
+	for p := d.Precision; p < precision; p++ {
+		if mantissa > math.MaxInt64/10 || mantissa < math.MinInt64/10 {
+			return d, fmt.Errorf("decimal %s out of range at precision %d", d, precision)
+		}
+		mantissa *= 10
+	}
+	return Decimal{Mantissa: mantissa, Precision: precision}, nil
+}
+
+// Equal returns true if the two numbers are equal, regardless of precision.
+func (d Decimal) Equal(other Decimal) bool {
+	precision := d.Precision
+	if other.Precision > precision {
+		precision = other.Precision
+	}
+	a, errA := d.Rescale(precision)
+	b, errB := other.Rescale(precision)
+	if errA != nil || errB != nil {
+		// Only one of them can be too large to rescale.
+		return false
+	}
+	return a == b
+}
+
+// cmpInt returns -1, 0 or +1 as the number is less than, equal to or greater
+// than the whole number n.
+func (d Decimal) cmpInt(n int64) int {
+	precision := d.Precision
+	if precision > maxDecimalPrecision {
+		precision = maxDecimalPrecision
+	}
+	scale := pow10(precision)
+	// The fraction has the same sign as the number and is less than one.
+	whole, fraction := d.Mantissa/scale, d.Mantissa%scale
+	switch {
+	case whole < n, whole == n && fraction < 0:
+		return -1
+	case whole > n, whole == n && fraction > 0:
+		return 1
+	}
+	return 0
+}
+
+// MarshalText encodes the number as by String.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText decodes the number as by ParseDecimal.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	parsed, err := ParseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// pow10 returns 10^n, for n up to maxDecimalPrecision.
+func pow10(n uint8) int64 {
+	result := int64(1)
+	for i := uint8(0); i < n; i++ {
+		result *= 10
+	}
+	return result
+}
+
+// absInt64 returns the absolute value of i, which may be math.MinInt64.
+func absInt64(i int64) uint64 {
+	if i < 0 {
+		return uint64(-(i + 1)) + 1
+	}
+	return uint64(i)
+}
@@ -0,0 +1,694 @@
+package goopenzwave
+
+import (
+	"fmt"
+)
+
+//
+// Basic device classes.
+//
+
+// BasicType defines a type for the basic device class of a node, as returned
+// by GetNodeBasicType.
+type BasicType uint8
+
+const (
+	BasicTypeController       BasicType = 0x01
+	BasicTypeStaticController BasicType = 0x02
+	BasicTypeSlave            BasicType = 0x03
+	BasicTypeRoutingSlave     BasicType = 0x04
+)
+
+func (t BasicType) String() string {
+	switch t {
+	case BasicTypeController:
+		return "Controller"
+	case BasicTypeStaticController:
+		return "Static Controller"
+	case BasicTypeSlave:
+		return "Slave"
+	case BasicTypeRoutingSlave:
+		return "Routing Slave"
+	}
+	return fmt.Sprintf("UNKNOWN (0x%02x)", uint8(t))
+}
+
+//
+// Generic device classes.
+//
+
+// GenericType defines a type for the generic device class of a node, as
+// returned by GetNodeGenericType.
+type GenericType uint8
+
+const (
+	GenericTypeGenericController  GenericType = 0x01
+	GenericTypeStaticController   GenericType = 0x02
+	GenericTypeAVControlPoint     GenericType = 0x03
+	GenericTypeDisplay            GenericType = 0x04
+	GenericTypeNetworkExtender    GenericType = 0x05
+	GenericTypeAppliance          GenericType = 0x06
+	GenericTypeSensorNotification GenericType = 0x07
+	GenericTypeThermostat         GenericType = 0x08
+	GenericTypeWindowCovering     GenericType = 0x09
+	GenericTypeRepeaterSlave      GenericType = 0x0f
+	GenericTypeSwitchBinary       GenericType = 0x10
+	GenericTypeSwitchMultilevel   GenericType = 0x11
+	GenericTypeSwitchRemote       GenericType = 0x12
+	GenericTypeSwitchToggle       GenericType = 0x13
+	GenericTypeZIPNode            GenericType = 0x15
+	GenericTypeVentilation        GenericType = 0x16
+	GenericTypeSecurityPanel      GenericType = 0x17
+	GenericTypeWallController     GenericType = 0x18
+	GenericTypeSensorBinary       GenericType = 0x20
+	GenericTypeSensorMultilevel   GenericType = 0x21
+	GenericTypeMeterPulse         GenericType = 0x30
+	GenericTypeMeter              GenericType = 0x31
+	GenericTypeEntryControl       GenericType = 0x40
+	GenericTypeSemiInteroperable  GenericType = 0x50
+	GenericTypeSensorAlarm        GenericType = 0xa1
+	GenericTypeNonInteroperable   GenericType = 0xff
+)
+
+var genericTypeNames = map[GenericType]string{
+	GenericTypeGenericController:  "Generic Controller",
+	GenericTypeStaticController:   "Static Controller",
+	GenericTypeAVControlPoint:     "AV Control Point",
+	GenericTypeDisplay:            "Display",
+	GenericTypeNetworkExtender:    "Network Extender",
+	GenericTypeAppliance:          "Appliance",
+	GenericTypeSensorNotification: "Notification Sensor",
+	GenericTypeThermostat:         "Thermostat",
+	GenericTypeWindowCovering:     "Window Covering",
+	GenericTypeRepeaterSlave:      "Repeater Slave",
+	GenericTypeSwitchBinary:       "Binary Switch",
+	GenericTypeSwitchMultilevel:   "Multilevel Switch",
+	GenericTypeSwitchRemote:       "Remote Switch",
+	GenericTypeSwitchToggle:       "Toggle Switch",
+	GenericTypeZIPNode:            "Z/IP Node",
+	GenericTypeVentilation:        "Ventilation",
+	GenericTypeSecurityPanel:      "Security Panel",
+	GenericTypeWallController:     "Wall Controller",
+	GenericTypeSensorBinary:       "Binary Sensor",
+	GenericTypeSensorMultilevel:   "Multilevel Sensor",
+	GenericTypeMeterPulse:         "Pulse Meter",
+	GenericTypeMeter:              "Meter",
+	GenericTypeEntryControl:       "Entry Control",
+	GenericTypeSemiInteroperable:  "Semi Interoperable",
+	GenericTypeSensorAlarm:        "Alarm Sensor",
+	GenericTypeNonInteroperable:   "Non Interoperable",
+}
+
+func (t GenericType) String() string {
+	if name, ok := genericTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("UNKNOWN (0x%02x)", uint8(t))
+}
+
+// Specific returns the specific device class with the ID within the generic
+// device class.
+func (t GenericType) Specific(specificID uint8) SpecificType {
+	return SpecificType(uint16(t)<<8 | uint16(specificID))
+}
+
+//
+// Specific device classes.
+//
+
+// SpecificType defines a type for the specific device class of a node. A
+// specific device class ID only has a meaning within its generic device class,
+// so a SpecificType holds both: the generic ID in the high byte and the
+// specific ID, as returned by GetNodeSpecificType, in the low byte.
+type SpecificType uint16
+
+const (
+	SpecificTypePortableRemoteController     SpecificType = 0x0101
+	SpecificTypePortableSceneController      SpecificType = 0x0102
+	SpecificTypePortableInstallerTool        SpecificType = 0x0103
+	SpecificTypeRemoteControlAV              SpecificType = 0x0104
+	SpecificTypeRemoteControlSimple          SpecificType = 0x0106
+	SpecificTypePCController                 SpecificType = 0x0201
+	SpecificTypeSceneController              SpecificType = 0x0202
+	SpecificTypeStaticInstallerTool          SpecificType = 0x0203
+	SpecificTypeSetTopBox                    SpecificType = 0x0204
+	SpecificTypeSubSystemController          SpecificType = 0x0205
+	SpecificTypeTV                           SpecificType = 0x0206
+	SpecificTypeGateway                      SpecificType = 0x0207
+	SpecificTypeDoorbell                     SpecificType = 0x0312
+	SpecificTypeSimpleDisplay                SpecificType = 0x0401
+	SpecificTypeSecureExtender               SpecificType = 0x0501
+	SpecificTypeGeneralAppliance             SpecificType = 0x0601
+	SpecificTypeKitchenAppliance             SpecificType = 0x0602
+	SpecificTypeLaundryAppliance             SpecificType = 0x0603
+	SpecificTypeNotificationSensor           SpecificType = 0x0701
+	SpecificTypeThermostatHeating            SpecificType = 0x0801
+	SpecificTypeThermostatGeneral            SpecificType = 0x0802
+	SpecificTypeSetbackScheduleThermostat    SpecificType = 0x0803
+	SpecificTypeSetpointThermostat           SpecificType = 0x0804
+	SpecificTypeSetbackThermostat            SpecificType = 0x0805
+	SpecificTypeThermostatGeneralV2          SpecificType = 0x0806
+	SpecificTypeSimpleWindowCovering         SpecificType = 0x0901
+	SpecificTypeRepeaterSlave                SpecificType = 0x0f01
+	SpecificTypeVirtualNode                  SpecificType = 0x0f02
+	SpecificTypePowerSwitchBinary            SpecificType = 0x1001
+	SpecificTypeColorTunableBinary           SpecificType = 0x1002
+	SpecificTypeSceneSwitchBinary            SpecificType = 0x1003
+	SpecificTypePowerStrip                   SpecificType = 0x1004
+	SpecificTypeSiren                        SpecificType = 0x1005
+	SpecificTypeValveOpenClose               SpecificType = 0x1006
+	SpecificTypeIrrigationController         SpecificType = 0x1007
+	SpecificTypePowerSwitchMultilevel        SpecificType = 0x1101
+	SpecificTypeColorTunableMultilevel       SpecificType = 0x1102
+	SpecificTypeMotorMultiposition           SpecificType = 0x1103
+	SpecificTypeSceneSwitchMultilevel        SpecificType = 0x1104
+	SpecificTypeClassAMotorControl           SpecificType = 0x1105
+	SpecificTypeClassBMotorControl           SpecificType = 0x1106
+	SpecificTypeClassCMotorControl           SpecificType = 0x1107
+	SpecificTypeFanSwitch                    SpecificType = 0x1108
+	SpecificTypeSwitchRemoteBinary           SpecificType = 0x1201
+	SpecificTypeSwitchRemoteMultilevel       SpecificType = 0x1202
+	SpecificTypeSwitchRemoteToggleBinary     SpecificType = 0x1203
+	SpecificTypeSwitchRemoteToggleMultilevel SpecificType = 0x1204
+	SpecificTypeSwitchToggleBinary           SpecificType = 0x1301
+	SpecificTypeSwitchToggleMultilevel       SpecificType = 0x1302
+	SpecificTypeZIPTunnelingNode             SpecificType = 0x1501
+	SpecificTypeZIPAdvancedNode              SpecificType = 0x1502
+	SpecificTypeResidentialHRV               SpecificType = 0x1601
+	SpecificTypeZonedSecurityPanel           SpecificType = 0x1701
+	SpecificTypeBasicWallController          SpecificType = 0x1801
+	SpecificTypeRoutingSensorBinary          SpecificType = 0x2001
+	SpecificTypeRoutingSensorMultilevel      SpecificType = 0x2101
+	SpecificTypeChimneyFan                   SpecificType = 0x2102
+	SpecificTypeSimpleMeter                  SpecificType = 0x3101
+	SpecificTypeAdvancedEnergyControl        SpecificType = 0x3102
+	SpecificTypeWholeHomeMeterSimple         SpecificType = 0x3103
+	SpecificTypeDoorLock                     SpecificType = 0x4001
+	SpecificTypeAdvancedDoorLock             SpecificType = 0x4002
+	SpecificTypeSecureKeypadDoorLock         SpecificType = 0x4003
+	SpecificTypeSecureKeypadDoorLockDeadbolt SpecificType = 0x4004
+	SpecificTypeSecureDoor                   SpecificType = 0x4005
+	SpecificTypeSecureGate                   SpecificType = 0x4006
+	SpecificTypeSecureBarrierAddon           SpecificType = 0x4007
+	SpecificTypeSecureBarrierOpenOnly        SpecificType = 0x4008
+	SpecificTypeSecureBarrierCloseOnly       SpecificType = 0x4009
+	SpecificTypeSecureLockbox                SpecificType = 0x400a
+	SpecificTypeSecureKeypad                 SpecificType = 0x400b
+	SpecificTypeEnergyProduction             SpecificType = 0x5001
+	SpecificTypeBasicRoutingAlarmSensor      SpecificType = 0xa101
+	SpecificTypeRoutingAlarmSensor           SpecificType = 0xa102
+	SpecificTypeBasicZensorAlarmSensor       SpecificType = 0xa103
+	SpecificTypeZensorAlarmSensor            SpecificType = 0xa104
+	SpecificTypeAdvancedZensorAlarmSensor    SpecificType = 0xa105
+	SpecificTypeBasicRoutingSmokeSensor      SpecificType = 0xa106
+	SpecificTypeRoutingSmokeSensor           SpecificType = 0xa107
+	SpecificTypeBasicZensorSmokeSensor       SpecificType = 0xa108
+	SpecificTypeZensorSmokeSensor            SpecificType = 0xa109
+	SpecificTypeAdvancedZensorSmokeSensor    SpecificType = 0xa10a
+	SpecificTypeAlarmSensor                  SpecificType = 0xa10b
+)
+
+var specificTypeNames = map[SpecificType]string{
+	SpecificTypePortableRemoteController:     "Portable Remote Controller",
+	SpecificTypePortableSceneController:      "Portable Scene Controller",
+	SpecificTypePortableInstallerTool:        "Portable Installer Tool",
+	SpecificTypeRemoteControlAV:              "AV Remote Control",
+	SpecificTypeRemoteControlSimple:          "Simple Remote Control",
+	SpecificTypePCController:                 "Static PC Controller",
+	SpecificTypeSceneController:              "Static Scene Controller",
+	SpecificTypeStaticInstallerTool:          "Static Installer Tool",
+	SpecificTypeSetTopBox:                    "Set Top Box",
+	SpecificTypeSubSystemController:          "Sub System Controller",
+	SpecificTypeTV:                           "TV",
+	SpecificTypeGateway:                      "Gateway",
+	SpecificTypeDoorbell:                     "Doorbell",
+	SpecificTypeSimpleDisplay:                "Simple Display",
+	SpecificTypeSecureExtender:               "Secure Extender",
+	SpecificTypeGeneralAppliance:             "General Appliance",
+	SpecificTypeKitchenAppliance:             "Kitchen Appliance",
+	SpecificTypeLaundryAppliance:             "Laundry Appliance",
+	SpecificTypeNotificationSensor:           "Notification Sensor",
+	SpecificTypeThermostatHeating:            "Heating Thermostat",
+	SpecificTypeThermostatGeneral:            "General Thermostat",
+	SpecificTypeSetbackScheduleThermostat:    "Setback Schedule Thermostat",
+	SpecificTypeSetpointThermostat:           "Setpoint Thermostat",
+	SpecificTypeSetbackThermostat:            "Setback Thermostat",
+	SpecificTypeThermostatGeneralV2:          "General Thermostat V2",
+	SpecificTypeSimpleWindowCovering:         "Simple Window Covering",
+	SpecificTypeRepeaterSlave:                "Basic Repeater Slave",
+	SpecificTypeVirtualNode:                  "Virtual Node",
+	SpecificTypePowerSwitchBinary:            "Binary Power Switch",
+	SpecificTypeColorTunableBinary:           "Binary Color Tunable Light",
+	SpecificTypeSceneSwitchBinary:            "Binary Scene Switch",
+	SpecificTypePowerStrip:                   "Power Strip",
+	SpecificTypeSiren:                        "Siren",
+	SpecificTypeValveOpenClose:               "Open/Close Valve",
+	SpecificTypeIrrigationController:         "Irrigation Controller",
+	SpecificTypePowerSwitchMultilevel:        "Multilevel Power Switch",
+	SpecificTypeColorTunableMultilevel:       "Multilevel Color Tunable Light",
+	SpecificTypeMotorMultiposition:           "Multiposition Motor",
+	SpecificTypeSceneSwitchMultilevel:        "Multilevel Scene Switch",
+	SpecificTypeClassAMotorControl:           "Motor Control Class A",
+	SpecificTypeClassBMotorControl:           "Motor Control Class B",
+	SpecificTypeClassCMotorControl:           "Motor Control Class C",
+	SpecificTypeFanSwitch:                    "Fan Switch",
+	SpecificTypeSwitchRemoteBinary:           "Binary Remote Switch",
+	SpecificTypeSwitchRemoteMultilevel:       "Multilevel Remote Switch",
+	SpecificTypeSwitchRemoteToggleBinary:     "Binary Toggle Remote Switch",
+	SpecificTypeSwitchRemoteToggleMultilevel: "Multilevel Toggle Remote Switch",
+	SpecificTypeSwitchToggleBinary:           "Binary Toggle Switch",
+	SpecificTypeSwitchToggleMultilevel:       "Multilevel Toggle Switch",
+	SpecificTypeZIPTunnelingNode:             "Z/IP Tunneling Node",
+	SpecificTypeZIPAdvancedNode:              "Z/IP Advanced Node",
+	SpecificTypeResidentialHRV:               "Residential Heat Recovery Ventilation",
+	SpecificTypeZonedSecurityPanel:           "Zoned Security Panel",
+	SpecificTypeBasicWallController:          "Basic Wall Controller",
+	SpecificTypeRoutingSensorBinary:          "Routing Binary Sensor",
+	SpecificTypeRoutingSensorMultilevel:      "Routing Multilevel Sensor",
+	SpecificTypeChimneyFan:                   "Chimney Fan",
+	SpecificTypeSimpleMeter:                  "Simple Meter",
+	SpecificTypeAdvancedEnergyControl:        "Advanced Energy Control",
+	SpecificTypeWholeHomeMeterSimple:         "Simple Whole Home Meter",
+	SpecificTypeDoorLock:                     "Door Lock",
+	SpecificTypeAdvancedDoorLock:             "Advanced Door Lock",
+	SpecificTypeSecureKeypadDoorLock:         "Secure Keypad Door Lock",
+	SpecificTypeSecureKeypadDoorLockDeadbolt: "Secure Keypad Door Lock Deadbolt",
+	SpecificTypeSecureDoor:                   "Secure Door",
+	SpecificTypeSecureGate:                   "Secure Gate",
+	SpecificTypeSecureBarrierAddon:           "Secure Barrier Add-on",
+	SpecificTypeSecureBarrierOpenOnly:        "Secure Barrier Open Only",
+	SpecificTypeSecureBarrierCloseOnly:       "Secure Barrier Close Only",
+	SpecificTypeSecureLockbox:                "Secure Lockbox",
+	SpecificTypeSecureKeypad:                 "Secure Keypad",
+	SpecificTypeEnergyProduction:             "Energy Production",
+	SpecificTypeBasicRoutingAlarmSensor:      "Basic Routing Alarm Sensor",
+	SpecificTypeRoutingAlarmSensor:           "Routing Alarm Sensor",
+	SpecificTypeBasicZensorAlarmSensor:       "Basic Zensor Alarm Sensor",
+	SpecificTypeZensorAlarmSensor:            "Zensor Alarm Sensor",
+	SpecificTypeAdvancedZensorAlarmSensor:    "Advanced Zensor Alarm Sensor",
+	SpecificTypeBasicRoutingSmokeSensor:      "Basic Routing Smoke Sensor",
+	SpecificTypeRoutingSmokeSensor:           "Routing Smoke Sensor",
+	SpecificTypeBasicZensorSmokeSensor:       "Basic Zensor Smoke Sensor",
+	SpecificTypeZensorSmokeSensor:            "Zensor Smoke Sensor",
+	SpecificTypeAdvancedZensorSmokeSensor:    "Advanced Zensor Smoke Sensor",
+	SpecificTypeAlarmSensor:                  "Alarm Sensor",
+}
+
+// Generic returns the generic device class of the specific device class.
+func (t SpecificType) Generic() GenericType {
+	return GenericType(t >> 8)
+}
+
+// ID returns the specific device class ID, as returned by GetNodeSpecificType.
+func (t SpecificType) ID() uint8 {
+	return uint8(t)
+}
+
+func (t SpecificType) String() string {
+	if name, ok := specificTypeNames[t]; ok {
+		return name
+	}
+	if t.ID() == 0 {
+		return "Not Used"
+	}
+	return fmt.Sprintf("UNKNOWN (0x%02x)", t.ID())
+}
+
+//
+// Z-Wave+ types.
+//
+
+// NodeRole defines a type for the Z-Wave+ role type of a node, as returned by
+// GetNodeRole.
+type NodeRole uint8
+
+const (
+	NodeRoleCentralStaticController     NodeRole = 0x00
+	NodeRoleSubStaticController         NodeRole = 0x01
+	NodeRolePortableController          NodeRole = 0x02
+	NodeRolePortableReportingController NodeRole = 0x03
+	NodeRolePortableSlave               NodeRole = 0x04
+	NodeRoleAlwaysOnSlave               NodeRole = 0x05
+	NodeRoleSleepingReportingSlave      NodeRole = 0x06
+	NodeRoleSleepingListeningSlave      NodeRole = 0x07
+)
+
+func (r NodeRole) String() string {
+	switch r {
+	case NodeRoleCentralStaticController:
+		return "Central Static Controller"
+	case NodeRoleSubStaticController:
+		return "Sub Static Controller"
+	case NodeRolePortableController:
+		return "Portable Controller"
+	case NodeRolePortableReportingController:
+		return "Portable Reporting Controller"
+	case NodeRolePortableSlave:
+		return "Portable Slave"
+	case NodeRoleAlwaysOnSlave:
+		return "Always On Slave"
+	case NodeRoleSleepingReportingSlave:
+		return "Sleeping Reporting Slave"
+	case NodeRoleSleepingListeningSlave:
+		return "Sleeping Listening Slave"
+	}
+	return fmt.Sprintf("UNKNOWN (0x%02x)", uint8(r))
+}
+
+// IsController returns true for the controller roles.
+func (r NodeRole) IsController() bool {
+	return r <= NodeRolePortableReportingController
+}
+
+// NodePlusType defines a type for the Z-Wave+ node type of a node, as returned
+// by GetNodePlusType.
+type NodePlusType uint8
+
+const (
+	NodePlusTypeNode      NodePlusType = 0x00
+	NodePlusTypeIPGateway NodePlusType = 0x02
+)
+
+func (t NodePlusType) String() string {
+	switch t {
+	case NodePlusTypeNode:
+		return "Z-Wave+ node"
+	case NodePlusTypeIPGateway:
+		return "Z-Wave+ for IP gateway"
+	}
+	return fmt.Sprintf("UNKNOWN (0x%02x)", uint8(t))
+}
+
+// NodeDeviceType defines a type for the Z-Wave+ installer icon type of a node,
+// as returned by GetNodeDeviceType. The high byte is the icon and the low byte
+// a variant of it, e.g. NodeDeviceTypeOnOffPowerSwitchPlugin.
+type NodeDeviceType uint16
+
+const (
+	NodeDeviceTypeUnknown                       NodeDeviceType = 0x0000
+	NodeDeviceTypeCentralController             NodeDeviceType = 0x0100
+	NodeDeviceTypeDisplaySimple                 NodeDeviceType = 0x0200
+	NodeDeviceTypeDoorLockKeypad                NodeDeviceType = 0x0300
+	NodeDeviceTypeFanSwitch                     NodeDeviceType = 0x0400
+	NodeDeviceTypeGateway                       NodeDeviceType = 0x0500
+	NodeDeviceTypeLightDimmerSwitch             NodeDeviceType = 0x0600
+	NodeDeviceTypeLightDimmerSwitchPlugin       NodeDeviceType = 0x0601
+	NodeDeviceTypeLightDimmerSwitchWallOutlet   NodeDeviceType = 0x0602
+	NodeDeviceTypeLightDimmerSwitchCeiling      NodeDeviceType = 0x0603
+	NodeDeviceTypeLightDimmerSwitchWallLamp     NodeDeviceType = 0x0604
+	NodeDeviceTypeOnOffPowerSwitch              NodeDeviceType = 0x0700
+	NodeDeviceTypeOnOffPowerSwitchPlugin        NodeDeviceType = 0x0701
+	NodeDeviceTypeOnOffPowerSwitchWallOutlet    NodeDeviceType = 0x0702
+	NodeDeviceTypeOnOffPowerSwitchCeiling       NodeDeviceType = 0x0703
+	NodeDeviceTypeOnOffPowerSwitchWallLamp      NodeDeviceType = 0x0704
+	NodeDeviceTypePowerStrip                    NodeDeviceType = 0x0800
+	NodeDeviceTypeRemoteControlAV               NodeDeviceType = 0x0900
+	NodeDeviceTypeRemoteControlMultiPurpose     NodeDeviceType = 0x0a00
+	NodeDeviceTypeRemoteControlSimple           NodeDeviceType = 0x0b00
+	NodeDeviceTypeRemoteControlSimpleKeyfob     NodeDeviceType = 0x0b01
+	NodeDeviceTypeSensorNotification            NodeDeviceType = 0x0c00
+	NodeDeviceTypeSensorNotificationSmokeAlarm  NodeDeviceType = 0x0c01
+	NodeDeviceTypeSensorNotificationCOAlarm     NodeDeviceType = 0x0c02
+	NodeDeviceTypeSensorNotificationCO2Alarm    NodeDeviceType = 0x0c03
+	NodeDeviceTypeSensorNotificationHeatAlarm   NodeDeviceType = 0x0c04
+	NodeDeviceTypeSensorNotificationWaterAlarm  NodeDeviceType = 0x0c05
+	NodeDeviceTypeSensorNotificationAccess      NodeDeviceType = 0x0c06
+	NodeDeviceTypeSensorNotificationSecurity    NodeDeviceType = 0x0c07
+	NodeDeviceTypeSensorNotificationPower       NodeDeviceType = 0x0c08
+	NodeDeviceTypeSensorNotificationSystem      NodeDeviceType = 0x0c09
+	NodeDeviceTypeSensorNotificationEmergency   NodeDeviceType = 0x0c0a
+	NodeDeviceTypeSensorNotificationClock       NodeDeviceType = 0x0c0b
+	NodeDeviceTypeSensorNotificationMultidevice NodeDeviceType = 0x0cff
+	NodeDeviceTypeSensorMultilevel              NodeDeviceType = 0x0d00
+	NodeDeviceTypeSetTopBox                     NodeDeviceType = 0x0e00
+	NodeDeviceTypeSiren                         NodeDeviceType = 0x0f00
+	NodeDeviceTypeSubEnergyMeter                NodeDeviceType = 0x1000
+	NodeDeviceTypeSubSystemController           NodeDeviceType = 0x1100
+	NodeDeviceTypeThermostatHVAC                NodeDeviceType = 0x1200
+	NodeDeviceTypeThermostatSetback             NodeDeviceType = 0x1300
+	NodeDeviceTypeTV                            NodeDeviceType = 0x1400
+	NodeDeviceTypeValveOpenClose                NodeDeviceType = 0x1500
+	NodeDeviceTypeWallController                NodeDeviceType = 0x1600
+	NodeDeviceTypeWholeHomeMeterSimple          NodeDeviceType = 0x1700
+	NodeDeviceTypeWindowCoveringNoPosition      NodeDeviceType = 0x1800
+	NodeDeviceTypeWindowCoveringEndpointAware   NodeDeviceType = 0x1900
+	NodeDeviceTypeWindowCoveringPositionAware   NodeDeviceType = 0x1a00
+)
+
+var nodeDeviceTypeNames = map[NodeDeviceType]string{
+	NodeDeviceTypeUnknown:                       "Unknown Type",
+	NodeDeviceTypeCentralController:             "Central Controller",
+	NodeDeviceTypeDisplaySimple:                 "Display Simple",
+	NodeDeviceTypeDoorLockKeypad:                "Door Lock - Keypad",
+	NodeDeviceTypeFanSwitch:                     "Fan Switch",
+	NodeDeviceTypeGateway:                       "Gateway",
+	NodeDeviceTypeLightDimmerSwitch:             "Light Dimmer Switch",
+	NodeDeviceTypeLightDimmerSwitchPlugin:       "Light Dimmer Switch - Plugin",
+	NodeDeviceTypeLightDimmerSwitchWallOutlet:   "Light Dimmer Switch - Wall Outlet",
+	NodeDeviceTypeLightDimmerSwitchCeiling:      "Light Dimmer Switch - Ceiling Outlet",
+	NodeDeviceTypeLightDimmerSwitchWallLamp:     "Light Dimmer Switch - Wall Lamp",
+	NodeDeviceTypeOnOffPowerSwitch:              "On/Off Power Switch",
+	NodeDeviceTypeOnOffPowerSwitchPlugin:        "On/Off Power Switch - Plugin",
+	NodeDeviceTypeOnOffPowerSwitchWallOutlet:    "On/Off Power Switch - Wall Outlet",
+	NodeDeviceTypeOnOffPowerSwitchCeiling:       "On/Off Power Switch - Ceiling Outlet",
+	NodeDeviceTypeOnOffPowerSwitchWallLamp:      "On/Off Power Switch - Wall Lamp",
+	NodeDeviceTypePowerStrip:                    "Power Strip",
+	NodeDeviceTypeRemoteControlAV:               "Remote Control - AV",
+	NodeDeviceTypeRemoteControlMultiPurpose:     "Remote Control - Multi Purpose",
+	NodeDeviceTypeRemoteControlSimple:           "Remote Control - Simple",
+	NodeDeviceTypeRemoteControlSimpleKeyfob:     "Remote Control - Simple - Keyfob",
+	NodeDeviceTypeSensorNotification:            "Sensor - Notification",
+	NodeDeviceTypeSensorNotificationSmokeAlarm:  "Sensor - Notification - Smoke Alarm",
+	NodeDeviceTypeSensorNotificationCOAlarm:     "Sensor - Notification - CO Alarm",
+	NodeDeviceTypeSensorNotificationCO2Alarm:    "Sensor - Notification - CO2 Alarm",
+	NodeDeviceTypeSensorNotificationHeatAlarm:   "Sensor - Notification - Heat Alarm",
+	NodeDeviceTypeSensorNotificationWaterAlarm:  "Sensor - Notification - Water Alarm",
+	NodeDeviceTypeSensorNotificationAccess:      "Sensor - Notification - Access Control",
+	NodeDeviceTypeSensorNotificationSecurity:    "Sensor - Notification - Home Security",
+	NodeDeviceTypeSensorNotificationPower:       "Sensor - Notification - Power Management",
+	NodeDeviceTypeSensorNotificationSystem:      "Sensor - Notification - System",
+	NodeDeviceTypeSensorNotificationEmergency:   "Sensor - Notification - Emergency Alarm",
+	NodeDeviceTypeSensorNotificationClock:       "Sensor - Notification - Clock",
+	NodeDeviceTypeSensorNotificationMultidevice: "Sensor - Notification - Multidevice",
+	NodeDeviceTypeSensorMultilevel:              "Sensor - Multilevel",
+	NodeDeviceTypeSetTopBox:                     "Set Top Box",
+	NodeDeviceTypeSiren:                         "Siren",
+	NodeDeviceTypeSubEnergyMeter:                "Sub Energy Meter",
+	NodeDeviceTypeSubSystemController:           "Sub System Controller",
+	NodeDeviceTypeThermostatHVAC:                "Thermostat - HVAC",
+	NodeDeviceTypeThermostatSetback:             "Thermostat - Setback",
+	NodeDeviceTypeTV:                            "TV",
+	NodeDeviceTypeValveOpenClose:                "Valve - Open/Close",
+	NodeDeviceTypeWallController:                "Wall Controller",
+	NodeDeviceTypeWholeHomeMeterSimple:          "Whole Home Meter - Simple",
+	NodeDeviceTypeWindowCoveringNoPosition:      "Window Covering - No Position/Endpoint",
+	NodeDeviceTypeWindowCoveringEndpointAware:   "Window Covering - Endpoint Aware",
+	NodeDeviceTypeWindowCoveringPositionAware:   "Window Covering - Position/Endpoint Aware",
+}
+
+// Icon returns the device type without its variant, e.g.
+// NodeDeviceTypeOnOffPowerSwitch for NodeDeviceTypeOnOffPowerSwitchPlugin.
+func (t NodeDeviceType) Icon() NodeDeviceType {
+	return t &^ 0xff
+}
+
+// String returns the name of the device type. Variants which are not known are
+// named after their icon.
+func (t NodeDeviceType) String() string {
+	if name, ok := nodeDeviceTypeNames[t]; ok {
+		return name
+	}
+	if name, ok := nodeDeviceTypeNames[t.Icon()]; ok && t.Icon() != NodeDeviceTypeUnknown {
+		return name
+	}
+	return fmt.Sprintf("UNKNOWN (0x%04x)", uint16(t))
+}
+
+//
+// Device class summary.
+//
+
+// DeviceCategory defines a broad category of device, for choosing how to show
+// and control a node.
+type DeviceCategory int
+
+const (
+	DeviceCategoryOther DeviceCategory = iota
+	DeviceCategoryController
+	DeviceCategoryRepeater
+	DeviceCategorySwitch
+	DeviceCategoryDimmer
+	DeviceCategoryWindowCovering
+	DeviceCategoryFan
+	DeviceCategorySensor
+	DeviceCategoryMeter
+	DeviceCategoryThermostat
+	DeviceCategoryLock
+	DeviceCategorySiren
+	DeviceCategoryValve
+	DeviceCategoryRemote
+)
+
+func (c DeviceCategory) String() string {
+	switch c {
+	case DeviceCategoryOther:
+		return "Other"
+	case DeviceCategoryController:
+		return "Controller"
+	case DeviceCategoryRepeater:
+		return "Repeater"
+	case DeviceCategorySwitch:
+		return "Switch"
+	case DeviceCategoryDimmer:
+		return "Dimmer"
+	case DeviceCategoryWindowCovering:
+		return "WindowCovering"
+	case DeviceCategoryFan:
+		return "Fan"
+	case DeviceCategorySensor:
+		return "Sensor"
+	case DeviceCategoryMeter:
+		return "Meter"
+	case DeviceCategoryThermostat:
+		return "Thermostat"
+	case DeviceCategoryLock:
+		return "Lock"
+	case DeviceCategorySiren:
+		return "Siren"
+	case DeviceCategoryValve:
+		return "Valve"
+	case DeviceCategoryRemote:
+		return "Remote"
+	}
+	return "UNKNOWN"
+}
+
+// genericTypeCategories maps the generic device classes to categories.
+var genericTypeCategories = map[GenericType]DeviceCategory{
+	GenericTypeGenericController:  DeviceCategoryRemote,
+	GenericTypeStaticController:   DeviceCategoryController,
+	GenericTypeNetworkExtender:    DeviceCategoryRepeater,
+	GenericTypeSensorNotification: DeviceCategorySensor,
+	GenericTypeThermostat:         DeviceCategoryThermostat,
+	GenericTypeWindowCovering:     DeviceCategoryWindowCovering,
+	GenericTypeRepeaterSlave:      DeviceCategoryRepeater,
+	GenericTypeSwitchBinary:       DeviceCategorySwitch,
+	GenericTypeSwitchMultilevel:   DeviceCategoryDimmer,
+	GenericTypeSwitchRemote:       DeviceCategoryRemote,
+	GenericTypeSwitchToggle:       DeviceCategorySwitch,
+	GenericTypeWallController:     DeviceCategoryRemote,
+	GenericTypeSensorBinary:       DeviceCategorySensor,
+	GenericTypeSensorMultilevel:   DeviceCategorySensor,
+	GenericTypeMeterPulse:         DeviceCategoryMeter,
+	GenericTypeMeter:              DeviceCategoryMeter,
+	GenericTypeEntryControl:       DeviceCategoryLock,
+	GenericTypeSensorAlarm:        DeviceCategorySensor,
+}
+
+// specificTypeCategories maps the specific device classes whose category
+// differs from that of their generic device class.
+var specificTypeCategories = map[SpecificType]DeviceCategory{
+	SpecificTypePowerStrip:             DeviceCategorySwitch,
+	SpecificTypeSiren:                  DeviceCategorySiren,
+	SpecificTypeValveOpenClose:         DeviceCategoryValve,
+	SpecificTypeMotorMultiposition:     DeviceCategoryWindowCovering,
+	SpecificTypeClassAMotorControl:     DeviceCategoryWindowCovering,
+	SpecificTypeClassBMotorControl:     DeviceCategoryWindowCovering,
+	SpecificTypeClassCMotorControl:     DeviceCategoryWindowCovering,
+	SpecificTypeFanSwitch:              DeviceCategoryFan,
+	SpecificTypeSecureBarrierAddon:     DeviceCategoryWindowCovering,
+	SpecificTypeSecureBarrierOpenOnly:  DeviceCategoryWindowCovering,
+	SpecificTypeSecureBarrierCloseOnly: DeviceCategoryWindowCovering,
+}
+
+// nodeDeviceTypeCategories maps the Z-Wave+ icons to categories.
+var nodeDeviceTypeCategories = map[NodeDeviceType]DeviceCategory{
+	NodeDeviceTypeCentralController:           DeviceCategoryController,
+	NodeDeviceTypeDoorLockKeypad:              DeviceCategoryLock,
+	NodeDeviceTypeFanSwitch:                   DeviceCategoryFan,
+	NodeDeviceTypeGateway:                     DeviceCategoryController,
+	NodeDeviceTypeLightDimmerSwitch:           DeviceCategoryDimmer,
+	NodeDeviceTypeOnOffPowerSwitch:            DeviceCategorySwitch,
+	NodeDeviceTypePowerStrip:                  DeviceCategorySwitch,
+	NodeDeviceTypeRemoteControlAV:             DeviceCategoryRemote,
+	NodeDeviceTypeRemoteControlMultiPurpose:   DeviceCategoryRemote,
+	NodeDeviceTypeRemoteControlSimple:         DeviceCategoryRemote,
+	NodeDeviceTypeSensorNotification:          DeviceCategorySensor,
+	NodeDeviceTypeSensorMultilevel:            DeviceCategorySensor,
+	NodeDeviceTypeSiren:                       DeviceCategorySiren,
+	NodeDeviceTypeSubEnergyMeter:              DeviceCategoryMeter,
+	NodeDeviceTypeSubSystemController:         DeviceCategoryController,
+	NodeDeviceTypeThermostatHVAC:              DeviceCategoryThermostat,
+	NodeDeviceTypeThermostatSetback:           DeviceCategoryThermostat,
+	NodeDeviceTypeValveOpenClose:              DeviceCategoryValve,
+	NodeDeviceTypeWallController:              DeviceCategoryRemote,
+	NodeDeviceTypeWholeHomeMeterSimple:        DeviceCategoryMeter,
+	NodeDeviceTypeWindowCoveringNoPosition:    DeviceCategoryWindowCovering,
+	NodeDeviceTypeWindowCoveringEndpointAware: DeviceCategoryWindowCovering,
+	NodeDeviceTypeWindowCoveringPositionAware: DeviceCategoryWindowCovering,
+}
+
+// DeviceClass describes what kind of device a node is, from its device
+// classes and, for Z-Wave+ nodes, its Z-Wave+ Info report.
+type DeviceClass struct {
+	Basic    BasicType
+	Generic  GenericType
+	Specific SpecificType
+
+	// ZWavePlus is true if the node supports the Z-Wave+ Info command class,
+	// in which case Role, PlusType and DeviceType are set.
+	ZWavePlus  bool
+	Role       NodeRole
+	PlusType   NodePlusType
+	DeviceType NodeDeviceType
+
+	// Category is the broad category of the device, taken from the Z-Wave+
+	// device type where there is one and from the device classes otherwise.
+	Category DeviceCategory
+}
+
+func (c DeviceClass) String() string {
+	if c.ZWavePlus {
+		return fmt.Sprintf("<Category: %s, Basic: %s, Generic: %s, Specific: %s, Role: %s, PlusType: %s, DeviceType: %s>",
+			c.Category, c.Basic, c.Generic, c.Specific, c.Role, c.PlusType, c.DeviceType)
+	}
+	return fmt.Sprintf("<Category: %s, Basic: %s, Generic: %s, Specific: %s>",
+		c.Category, c.Basic, c.Generic, c.Specific)
+}
+
+// GetNodeDeviceClass returns the device classes and Z-Wave+ types of a node.
+func (m *Manager) GetNodeDeviceClass(homeID uint32, nodeID uint8) DeviceClass {
+	generic := GenericType(m.GetNodeGenericType(homeID, nodeID))
+	c := DeviceClass{
+		Basic:    BasicType(m.GetNodeBasicType(homeID, nodeID)),
+		Generic:  generic,
+		Specific: generic.Specific(m.GetNodeSpecificType(homeID, nodeID)),
+	}
+	if ok, _, _ := m.GetNodeClassInformation(homeID, nodeID, uint8(CommandClassZWavePlusInfo)); ok {
+		c.ZWavePlus = true
+		c.Role = NodeRole(m.GetNodeRole(homeID, nodeID))
+		c.PlusType = NodePlusType(m.GetNodePlusType(homeID, nodeID))
+		c.DeviceType = NodeDeviceType(m.GetNodeDeviceType(homeID, nodeID))
+	}
+	c.Category = c.category()
+	return c
+}
+
+// category returns the broad category of the device.
+func (c DeviceClass) category() DeviceCategory {
+	if c.ZWavePlus {
+		if category, ok := nodeDeviceTypeCategories[c.DeviceType.Icon()]; ok {
+			return category
+		}
+	}
+	if category, ok := specificTypeCategories[c.Specific]; ok {
+		return category
+	}
+	if category, ok := genericTypeCategories[c.Generic]; ok {
+		return category
+	}
+	if c.Basic == BasicTypeController || c.Basic == BasicTypeStaticController {
+		return DeviceCategoryController
+	}
+	return DeviceCategoryOther
+}
@@ -1,16 +1,12 @@
 package goopenzwave
 
-// #include "gzw_manager.h"
-// #include <stdlib.h>
-import "C"
 import (
 	"fmt"
-	"unsafe"
 )
 
 // AddDriver creates a new driver for a Z-Wave controller using the path
 // specified (e.g. "/dev/ttyUSB0"). It returns an error if the controller
-// already exists.
+// already exists, or a *StateError if the Manager has not been started.
 //
 // This method creates a Driver object for handling communications with a single
 // Z-Wave controller. In the background, the driver first tries to read
@@ -20,40 +16,62 @@ import (
 // DriverReady notification callback is sent, containing the Home ID of the
 // controller. This Home ID is required by most of the OpenZWave Manager class
 // methods.
-func AddDriver(controllerPath string) error {
-	cControllerPath := C.CString(controllerPath)
-	defer C.free(unsafe.Pointer(cControllerPath))
-	ok := bool(C.manager_addDriver(cmanager, cControllerPath))
+func (m *Manager) AddDriver(controllerPath string) error {
+	m.lifecycle.Lock()
+	defer m.lifecycle.Unlock()
+	if err := m.checkStarted("AddDriver"); err != nil {
+		return err
+	}
+	ok := m.backend.AddDriver(controllerPath)
 	if ok == false {
 		return fmt.Errorf("controller already exists")
 	}
+	m.mu.Lock()
+	m.drivers[controllerPath] = true
+	m.state = ManagerStateDriversAdded
+	m.mu.Unlock()
 	return nil
 }
 
 // RemoveDriver removes the driver for a Z-Wave controller as specified, and
 // closes the controller. It returns an error if the controller could not be
-// found.
+// found, or a *StateError if no drivers have been added.
 //
 // Drivers do not need to be explicitly removed before calling Destroy - this is
 // handled automatically.
-func RemoveDriver(controllerPath string) error {
-	cControllerPath := C.CString(controllerPath)
-	defer C.free(unsafe.Pointer(cControllerPath))
-	ok := bool(C.manager_removeDriver(cmanager, cControllerPath))
+func (m *Manager) RemoveDriver(controllerPath string) error {
+	m.lifecycle.Lock()
+	defer m.lifecycle.Unlock()
+	m.mu.Lock()
+	state, drivers := m.state, len(m.drivers)
+	m.mu.Unlock()
+	switch {
+	case state == ManagerStateNotCreated, state == ManagerStateDestroyed:
+		return m.stateError("RemoveDriver")
+	case drivers == 0:
+		return &StateError{Op: "RemoveDriver", Err: ErrManagerNoDrivers}
+	}
+	ok := m.backend.RemoveDriver(controllerPath)
 	if ok == false {
 		return fmt.Errorf("controller not found")
 	}
+	m.mu.Lock()
+	delete(m.drivers, controllerPath)
+	if len(m.drivers) == 0 && m.state == ManagerStateDriversAdded {
+		m.state = ManagerStateStarted
+	}
+	m.mu.Unlock()
 	return nil
 }
 
 // GetControllerNodeID returns the node ID of the Z-Wave controller.
-func GetControllerNodeID(homeID uint32) uint8 {
-	return uint8(C.manager_getControllerNodeId(cmanager, C.uint32_t(homeID)))
+func (m *Manager) GetControllerNodeID(homeID uint32) uint8 {
+	return m.backend.GetControllerNodeID(homeID)
 }
 
 // GetSUCNodeID returns the node ID of the Static Update Controller.
-func GetSUCNodeID(homeID uint32) uint8 {
-	return uint8(C.manager_getSUCNodeId(cmanager, C.uint32_t(homeID)))
+func (m *Manager) GetSUCNodeID(homeID uint32) uint8 {
+	return m.backend.GetSUCNodeID(homeID)
 }
 
 // IsPrimaryController returns true if the controller is a primary controller.
@@ -61,8 +79,8 @@ func GetSUCNodeID(homeID uint32) uint8 {
 // The primary controller is the main device used to configure and control a
 // Z-Wave network. There can only be one primary controller - all other
 // controllers are secondary controllers.
-func IsPrimaryController(homeID uint32) bool {
-	return bool(C.manager_isPrimaryController(cmanager, C.uint32_t(homeID)))
+func (m *Manager) IsPrimaryController(homeID uint32) bool {
+	return m.backend.IsPrimaryController(homeID)
 }
 
 // IsStaticUpdateController returns true if the controller is a static update
@@ -71,8 +89,8 @@ func IsPrimaryController(homeID uint32) bool {
 // A Static Update Controller (SUC) is a controller that must never be moved in
 // normal operation and which can be used by other nodes to receive information
 // about network changes.
-func IsStaticUpdateController(homeID uint32) bool {
-	return bool(C.manager_isStaticUpdateController(cmanager, C.uint32_t(homeID)))
+func (m *Manager) IsStaticUpdateController(homeID uint32) bool {
+	return m.backend.IsStaticUpdateController(homeID)
 }
 
 // IsBridgeController returns true if the controller is using the bridge
@@ -80,16 +98,14 @@ func IsStaticUpdateController(homeID uint32) bool {
 //
 // A bridge controller is able to create virtual nodes that
 // can be associated with other controllers to enable events to be passed on.
-func IsBridgeController(homeID uint32) bool {
-	return bool(C.manager_isBridgeController(cmanager, C.uint32_t(homeID)))
+func (m *Manager) IsBridgeController(homeID uint32) bool {
+	return m.backend.IsBridgeController(homeID)
 }
 
 // GetLibraryVersion returns a string version of the Z-Wave API library used by
 // a controller.
-func GetLibraryVersion(homeID uint32) string {
-	cstr := C.manager_getLibraryVersion(cmanager, C.uint32_t(homeID))
-	defer C.free(unsafe.Pointer(cstr))
-	return C.GoString(cstr)
+func (m *Manager) GetLibraryVersion(homeID uint32) string {
+	return m.backend.GetLibraryVersion(homeID)
 }
 
 // GetLibraryTypeName returns a string containing the Z-Wave API library type
@@ -108,31 +124,56 @@ func GetLibraryVersion(homeID uint32) string {
 // The controller should never return a slave library type. For a more efficient
 // test of whether a controller is a Bridge Controller, use the
 // IsBridgeController method.
-func GetLibraryTypeName(homeID uint32) string {
-	cstr := C.manager_getLibraryTypeName(cmanager, C.uint32_t(homeID))
-	defer C.free(unsafe.Pointer(cstr))
-	return C.GoString(cstr)
+func (m *Manager) GetLibraryTypeName(homeID uint32) string {
+	return m.backend.GetLibraryTypeName(homeID)
 }
 
 // GetSendQueueCount returns the count of messages in the outgoing send queue.
-func GetSendQueueCount(homeID uint32) int32 {
-	return int32(C.manager_getSendQueueCount(cmanager, C.uint32_t(homeID)))
+func (m *Manager) GetSendQueueCount(homeID uint32) int32 {
+	return m.backend.GetSendQueueCount(homeID)
 }
 
 // LogDriverStatistics will send the current driver statistics to the log file.
-func LogDriverStatistics(homeID uint32) {
-	C.manager_logDriverStatistics(cmanager, C.uint32_t(homeID))
+func (m *Manager) LogDriverStatistics(homeID uint32) {
+	m.backend.LogDriverStatistics(homeID)
 }
 
 // GetControllerInterfaceType Obtain controller interface type.
 //TODO func GetControllerInterfaceType(homeID uint32) ...
 
 // GetControllerPath returns a string of the controller interface path.
-func GetControllerPath(homeID uint32) string {
-	cstr := C.manager_getControllerPath(cmanager, C.uint32_t(homeID))
-	defer C.free(unsafe.Pointer(cstr))
-	return C.GoString(cstr)
+func (m *Manager) GetControllerPath(homeID uint32) string {
+	return m.backend.GetControllerPath(homeID)
 }
 
-// GetDriverStatistics Retrieve statistics from driver.
-//TODO func GetDriverStatistics(...) ...
+// DriverStatistics contains the statistics of the driver for a PC Z-Wave
+// Controller, as kept by OpenZWave.
+type DriverStatistics struct {
+	SOFCount            uint32 // Number of SOF bytes received.
+	ACKWaiting          uint32 // Number of unsolicited messages received while waiting for an ACK.
+	ReadAborts          uint32 // Number of times a read was aborted due to timeouts.
+	BadChecksum         uint32 // Number of bad checksums.
+	ReadCount           uint32 // Number of messages successfully read.
+	WriteCount          uint32 // Number of messages successfully sent.
+	CANCount            uint32 // Number of CAN bytes received.
+	NAKCount            uint32 // Number of NAK bytes received.
+	ACKCount            uint32 // Number of ACK bytes received.
+	OOFCount            uint32 // Number of bytes out of framing.
+	Dropped             uint32 // Number of messages dropped and not delivered.
+	Retries             uint32 // Number of messages retransmitted.
+	Callbacks           uint32 // Number of unexpected callbacks.
+	BadRoutes           uint32 // Number of failed messages due to bad route response.
+	NoACK               uint32 // Number of no ACK returned errors.
+	NetBusy             uint32 // Number of network busy/failure messages.
+	NotIdle             uint32 // Number of RF network not idle messages.
+	NonDelivery         uint32 // Number of messages not delivered to network.
+	RoutedBusy          uint32 // Number of messages received with routed busy status.
+	BroadcastReadCount  uint32 // Number of broadcasts read.
+	BroadcastWriteCount uint32 // Number of broadcasts sent.
+}
+
+// GetDriverStatistics returns the current statistics of the driver for the
+// home. The statistics are all zero if there is no such driver.
+func (m *Manager) GetDriverStatistics(homeID uint32) DriverStatistics {
+	return m.backend.GetDriverStatistics(homeID)
+}
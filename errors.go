@@ -0,0 +1,46 @@
+package goopenzwave
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors describing why an operation was rejected by the Options or Manager
+// lifecycle. They are returned wrapped in a StateError, so use errors.Is to
+// check for them.
+var (
+	ErrNoBackend         = errors.New("no backend installed")
+	ErrOptionsNotCreated = errors.New("options not created")
+	ErrOptionsLocked     = errors.New("options already locked")
+	ErrOptionsNotLocked  = errors.New("options not locked")
+	ErrOptionsInUse      = errors.New("options in use by a manager")
+	ErrOptionsDestroyed  = errors.New("options destroyed")
+	ErrManagerNotCreated = errors.New("manager not created")
+	ErrManagerStarted    = errors.New("manager already started")
+	ErrManagerNotStarted = errors.New("manager not started")
+	ErrManagerNoDrivers  = errors.New("manager has no drivers")
+	ErrManagerDestroyed  = errors.New("manager destroyed")
+)
+
+// StateError is returned when an operation is attempted while the Options or
+// a Manager are in the wrong lifecycle state. The lifecycle is:
+//
+//	Options created -> Options locked -> Manager created -> Manager started
+//	(watcher added) -> drivers added -> Manager stopped -> Manager destroyed
+//	-> Options destroyed
+//
+// Op is the name of the rejected operation and Err is one of the Err variables
+// above, describing the reason.
+type StateError struct {
+	Op  string
+	Err error
+}
+
+func (e *StateError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Op, e.Err)
+}
+
+// Unwrap returns the reason for the error.
+func (e *StateError) Unwrap() error {
+	return e.Err
+}
@@ -70,7 +70,7 @@ func main() {
 		for i := range node.Values {
 			valueid := node.Values[i]
 
-			if valueid.CommandClassID == 0x20 {
+			if goopenzwave.CommandClass(valueid.CommandClassID) == goopenzwave.CommandClassBasic {
 				// Enable polling with "intensity" of 2. Though, this is
 				// irrelevant with only one value polled.
 				valueid.EnablePoll(2)
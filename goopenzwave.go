@@ -1,67 +1,128 @@
 package goopenzwave
 
-// #cgo pkg-config: libopenzwave
-// #include "gzw_manager.h"
-// #include "gzw_notification.h"
-// #include <stdlib.h>
-import "C"
 import (
-	"fmt"
+	"sync"
 )
 
 var (
-	started             bool
-	notificationHandler NotificationHandler
+	stdMu sync.Mutex
+	std   *Manager
 )
 
-// NotificationHandler defines the format for a function that will handle new
-// Notification's as they arrive.
-type NotificationHandler func(notification *Notification)
+// defaultManager returns the Manager used by the package level functions. This
+// is the Manager created by Start, or a Manager using the installed Backend if
+// Start has not been called.
+func defaultManager() *Manager {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std != nil {
+		return std
+	}
+	return &Manager{backend: backend}
+}
+
+// DefaultManager returns the Manager created by Start, or nil if Start has not
+// been called or the Manager has since been Stopped.
+func DefaultManager() *Manager {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	return std
+}
 
 // Start will create a new OpenZWave Manager, starting the library execution.
 // The OpenZWave Options must be created and locked before calling this. See the
 // Options struct. Also pass a NotificationHandler function to this as
 // notifications will also be started.
+//
+// The Manager becomes the default Manager used by all of the package level
+// functions. Use NewManager to create a Manager without using the package level
+// functions.
 func Start(handler NotificationHandler) error {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+
 	// Only start once.
-	if started {
-		return fmt.Errorf("already started")
+	if std != nil {
+		return &StateError{Op: "Start", Err: ErrManagerStarted}
 	}
 
 	// Create the manager.
-	err := createManager()
+	m, err := NewManager(GetOptions())
 	if err != nil {
-		return fmt.Errorf("failed to create manager: %s", err)
+		return err
 	}
 
 	// Start notifications.
-	err = startNotifications()
+	err = m.Start(handler)
 	if err != nil {
+		m.Destroy()
 		return err
 	}
 
-	// Set the notification handler.
-	notificationHandler = handler
-
+	std = m
 	return nil
 }
 
 // Stop will stop notifications and destroy the manager. Do this just before you
 // quit your app. Don't forget to destroy the Options object after calling this.
 func Stop() error {
+	stdMu.Lock()
+	defer stdMu.Unlock()
+
 	// Check we have started.
-	if started {
-		return fmt.Errorf("already started")
+	if std == nil {
+		return &StateError{Op: "Stop", Err: ErrManagerNotStarted}
 	}
 
 	// Stop notifications.
-	err := stopNotifications()
+	err := std.Stop()
 	if err != nil {
 		return err
 	}
 
 	// Destroy the manager.
-	destroyManager()
+	std.Destroy()
+	std = nil
 
 	return nil
 }
+
+// Subscribe calls Manager.Subscribe on the Manager created by Start. It
+// returns a *StateError if Start has not been called.
+func Subscribe(filter NotificationFilter, handler NotificationHandler) (*Subscription, error) {
+	m := DefaultManager()
+	if m == nil {
+		return nil, &StateError{Op: "Subscribe", Err: ErrManagerNotStarted}
+	}
+	return m.Subscribe(filter, handler), nil
+}
+
+// SubscribeChan calls Manager.SubscribeChan on the Manager created by Start. It
+// returns a *StateError if Start has not been called.
+func SubscribeChan(filter NotificationFilter) (*Subscription, error) {
+	m := DefaultManager()
+	if m == nil {
+		return nil, &StateError{Op: "SubscribeChan", Err: ErrManagerNotStarted}
+	}
+	return m.SubscribeChan(filter), nil
+}
+
+// NewStore calls Manager.NewStore on the Manager created by Start. It returns a
+// *StateError if Start has not been called.
+func NewStore() (*Store, error) {
+	m := DefaultManager()
+	if m == nil {
+		return nil, &StateError{Op: "NewStore", Err: ErrManagerNotStarted}
+	}
+	return m.NewStore(), nil
+}
+
+// SetLogHandler calls Manager.SetLogHandler on the Manager created by Start. It
+// returns a *StateError if Start has not been called.
+func SetLogHandler(handler LogHandler) error {
+	m := DefaultManager()
+	if m == nil {
+		return &StateError{Op: "SetLogHandler", Err: ErrManagerNotStarted}
+	}
+	return m.SetLogHandler(handler)
+}
@@ -1,45 +1,64 @@
 package goopenzwave
 
-// #include "gzw_manager.h"
-// #include <stdlib.h>
-import "C"
-import "unsafe"
-
 // GetNumGroups returns the number of association groups reported by this node.
 //
 // In Z-Wave, groups are numbered starting from one. For example, if a call to
 // GetNumGroups returns 4, the _groupIdx value to use in calls to
 // GetAssociations, AddAssociation and RemoveAssociation will be a number
 // between 1 and 4.
-func GetNumGroups(homeID uint32, nodeID uint8) uint8 {
-	return uint8(C.manager_getNumGroups(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) GetNumGroups(homeID uint32, nodeID uint8) uint8 {
+	return m.backend.GetNumGroups(homeID, nodeID)
 }
 
-// GetAssociations returns the associations for a group.
-//
-// Makes a copy of the list of associated nodes in the group, and returns it in
-// an array of uint8's. The caller is responsible for freeing the array memory
-// with a call to delete [].
-//TODO func GetAssociations(homeID uint32, nodeID uint8, groupIDx uint8, uint8 **o_associations) ...
+// Association is a member of an association group: a node, or an instance
+// (endpoint) of a multi-instance node. Instance is 0 for a plain association.
+type Association struct {
+	NodeID   uint8 `json:"nodeId" yaml:"nodeId"`
+	Instance uint8 `json:"instance,omitempty" yaml:"instance,omitempty"`
+}
+
+// Group describes an association group of a node.
+type Group struct {
+	Index           uint8
+	Label           string
+	MaxAssociations uint8
+	Members         []Association
+}
 
 // GetAssociations returns the associations for a group.
 //
-// Makes a copy of the list of associated nodes in the group, and returns it in
-// an array of InstanceAssociation's. The caller is responsible for freeing the
-// array memory with a call to delete [].
-//TODO func GetAssociations(homeID uint32, nodeID uint8, groupIDx uint8, InstanceAssociation **o_associations) ...
+// Makes a copy of the list of associated nodes in the group, including the
+// instance of each association for multi-instance associations. It returns nil
+// if the group has no members.
+func (m *Manager) GetAssociations(homeID uint32, nodeID uint8, groupIDx uint8) []Association {
+	return m.backend.GetAssociations(homeID, nodeID, groupIDx)
+}
+
+// GetGroups returns every association group of the node, with its label,
+// maximum number of associations and current members.
+func (m *Manager) GetGroups(homeID uint32, nodeID uint8) []Group {
+	var groups []Group
+	count := m.GetNumGroups(homeID, nodeID)
+	for groupIDx := uint8(1); groupIDx <= count && groupIDx != 0; groupIDx++ {
+		groups = append(groups, Group{
+			Index:           groupIDx,
+			Label:           m.GetGroupLabel(homeID, nodeID, groupIDx),
+			MaxAssociations: m.GetMaxAssociations(homeID, nodeID, groupIDx),
+			Members:         m.GetAssociations(homeID, nodeID, groupIDx),
+		})
+	}
+	return groups
+}
 
 // GetMaxAssociations returns the maximum number of associations for a group.
-func GetMaxAssociations(homeID uint32, nodeID uint8, groupIDx uint8) uint8 {
-	return uint8(C.manager_getMaxAssociations(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID), C.uint8_t(groupIDx)))
+func (m *Manager) GetMaxAssociations(homeID uint32, nodeID uint8, groupIDx uint8) uint8 {
+	return m.backend.GetMaxAssociations(homeID, nodeID, groupIDx)
 }
 
 // GetGroupLabel returns a label for the particular group of a node. This label
 // is populated by the device specific configuration files.
-func GetGroupLabel(homeID uint32, nodeID uint8, groupIDx uint8) string {
-	cstr := C.manager_getGroupLabel(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID), C.uint8_t(groupIDx))
-	defer C.free(unsafe.Pointer(cstr))
-	return C.GoString(cstr)
+func (m *Manager) GetGroupLabel(homeID uint32, nodeID uint8, groupIDx uint8) string {
+	return m.backend.GetGroupLabel(homeID, nodeID, groupIDx)
 }
 
 // AddAssociation adds a node to an association group.
@@ -49,8 +68,8 @@ func GetGroupLabel(homeID uint32, nodeID uint8, groupIDx uint8) string {
 // This will be reverted by a future Association message from the device if the
 // Z-Wave message actually failed to get through. Notification callbacks will be
 // sent in both cases.
-func AddAssociation(homeID uint32, nodeID uint8, groupIDx uint8, targetNodeID uint8, instance uint8) {
-	C.manager_addAssociation(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID), C.uint8_t(groupIDx), C.uint8_t(targetNodeID), C.uint8_t(instance))
+func (m *Manager) AddAssociation(homeID uint32, nodeID uint8, groupIDx uint8, targetNodeID uint8, instance uint8) {
+	m.backend.AddAssociation(homeID, nodeID, groupIDx, targetNodeID, instance)
 }
 
 // RemoveAssociation removes a node from an association group.
@@ -60,6 +79,6 @@ func AddAssociation(homeID uint32, nodeID uint8, groupIDx uint8, targetNodeID ui
 // This will be reverted by a future Association message from the device if the
 // Z-Wave message actually failed to get through. Notification callbacks will be
 // sent in both cases.
-func RemoveAssociation(homeID uint32, nodeID uint8, groupIDx uint8, targetNodeID uint8, instance uint8) {
-	C.manager_removeAssociation(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID), C.uint8_t(groupIDx), C.uint8_t(targetNodeID), C.uint8_t(instance))
+func (m *Manager) RemoveAssociation(homeID uint32, nodeID uint8, groupIDx uint8, targetNodeID uint8, instance uint8) {
+	m.backend.RemoveAssociation(homeID, nodeID, groupIDx, targetNodeID, instance)
 }
@@ -0,0 +1,53 @@
+#include "gzw_log.h"
+#include <Options.h>
+#include <platform/Log.h>
+#include <stdarg.h>
+#include <stdio.h>
+#include <stdlib.h>
+
+// GoLogImpl replaces the OpenZWave log file (or console) with goLogCB. Like
+// the OpenZWave implementation it drops lines more detailed than the
+// SaveLogLevel option, which OpenZWave updates through SetLoggingState.
+class GoLogImpl : public OpenZWave::i_LogImpl
+{
+public:
+    GoLogImpl()
+    {
+        int32_t level = OpenZWave::LogLevel_Detail;
+        OpenZWave::Options::Get()->GetOptionAsInt("SaveLogLevel", &level);
+        m_saveLevel = (OpenZWave::LogLevel)level;
+    }
+
+    virtual void Write(OpenZWave::LogLevel _level, uint8 const _nodeId, char const* _format, va_list _args)
+    {
+        if (_level > m_saveLevel && _level != OpenZWave::LogLevel_Always) {
+            return;
+        }
+        char *message = NULL;
+        if (vasprintf(&message, _format, _args) < 0) {
+            return;
+        }
+        goLogCB(loglevel_fromLogLevel(_level), _nodeId, message);
+        free(message);
+    }
+
+    virtual void QueueDump() {}
+    virtual void QueueClear() {}
+
+    virtual void SetLoggingState(OpenZWave::LogLevel _saveLevel, OpenZWave::LogLevel _queueLevel, OpenZWave::LogLevel _dumpTrigger)
+    {
+        m_saveLevel = _saveLevel;
+    }
+
+    virtual void SetLogFileName(const std::string &_filename) {}
+
+private:
+    OpenZWave::LogLevel m_saveLevel;
+};
+
+bool log_setGoLogger()
+{
+    // OpenZWave deletes the previous implementation, and deletes this one
+    // when the Manager is destroyed.
+    return OpenZWave::Log::SetLoggingClass(new GoLogImpl());
+}
@@ -0,0 +1,21 @@
+#ifndef GOOPENZWAVE_LOG
+#define GOOPENZWAVE_LOG
+
+#include <stdint.h>
+#include <stdbool.h>
+#include <stddef.h>
+#include "gzw_loglevel.h"
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+    // Forwarding the OpenZWave log.
+    extern void goLogCB(loglevel_t level, uint8_t nodeId, char *message); /*!< Must be implemented in cgo. */
+    bool log_setGoLogger(); /*!< Must be called after the Manager is created. */
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif // define GOOPENZWAVE_LOG
@@ -46,3 +46,50 @@ OpenZWave::LogLevel loglevel_toLogLevel(loglevel_t level)
     }
     return loglevel;
 }
+
+loglevel_t loglevel_fromLogLevel(OpenZWave::LogLevel level)
+{
+    loglevel_t loglevel;
+    switch (level) {
+    case OpenZWave::LogLevel_Invalid:
+        loglevel = loglevel_invalid;
+        break;
+    case OpenZWave::LogLevel_None:
+        loglevel = loglevel_none;
+        break;
+    case OpenZWave::LogLevel_Always:
+        loglevel = loglevel_always;
+        break;
+    case OpenZWave::LogLevel_Fatal:
+        loglevel = loglevel_fatal;
+        break;
+    case OpenZWave::LogLevel_Error:
+        loglevel = loglevel_error;
+        break;
+    case OpenZWave::LogLevel_Warning:
+        loglevel = loglevel_warning;
+        break;
+    case OpenZWave::LogLevel_Alert:
+        loglevel = loglevel_alert;
+        break;
+    case OpenZWave::LogLevel_Info:
+        loglevel = loglevel_info;
+        break;
+    case OpenZWave::LogLevel_Detail:
+        loglevel = loglevel_detail;
+        break;
+    case OpenZWave::LogLevel_Debug:
+        loglevel = loglevel_debug;
+        break;
+    case OpenZWave::LogLevel_StreamDetail:
+        loglevel = loglevel_streamdetail;
+        break;
+    case OpenZWave::LogLevel_Internal:
+        loglevel = loglevel_internal;
+        break;
+    default:
+        loglevel = loglevel_invalid;
+        break;
+    }
+    return loglevel;
+}
@@ -29,6 +29,7 @@ typedef enum {
 #ifdef __cplusplus
 }
 OpenZWave::LogLevel loglevel_toLogLevel(loglevel_t level);
+loglevel_t loglevel_fromLogLevel(OpenZWave::LogLevel level);
 #endif
 
 #endif // define GOOPENZWAVE_LOGLEVEL
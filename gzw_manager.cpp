@@ -1,8 +1,12 @@
 #include "gzw_manager.h"
 #include <string.h>
+#include <stdlib.h>
 #include <Manager.h>
 #include <Notification.h>
 #include <Defs.h>
+#include <Driver.h>
+#include <Node.h>
+#include <Group.h>
 
 //
 // Construction.
@@ -299,11 +303,17 @@ char* manager_getNodeType(manager_t m, uint32_t homeId, uint8_t nodeId)
 	return strdup(man->GetNodeType(homeId, nodeId).c_str());
 }
 
-//TODO uint32_t manager_getNodeNeighbors(manager_t m, uint32_t homeId, uint8_t nodeId, uint8_t **nodeNeighbors)
-// {
-// 	OpenZWave::Manager *man = (OpenZWave::Manager*)m;
-// 	return man->GetNodeNeighbours(homeId, nodeId);
-// }
+void manager_getNodeNeighbors(manager_t m, uint32_t homeId, uint8_t nodeId, zwbytes_t *o_neighbors)
+{
+	OpenZWave::Manager *man = (OpenZWave::Manager*)m;
+	uint8_t *neighbors = NULL;
+	uint32_t count = man->GetNodeNeighbors(homeId, nodeId, &neighbors);
+	zwbytes_reserve(o_neighbors, count);
+	if (count > 0) {
+		memcpy(o_neighbors->data, neighbors, count);
+	}
+	delete[] neighbors;
+}
 
 char* manager_getNodeManufacturerName(manager_t m, uint32_t homeId, uint8_t nodeId)
 {
@@ -652,6 +662,42 @@ bool manager_getValueFloatPrecision(manager_t m, valueid_t valueid, uint8_t *o_v
 	return man->GetValueFloatPrecision(*val, o_value);
 }
 
+// BitSet values were added in OpenZWave 1.6. Build with the openzwave16 tag to
+// use them.
+
+bool manager_getValueAsBitSet(manager_t m, valueid_t valueid, uint8_t pos, bool *o_value)
+{
+#ifdef GZW_OPENZWAVE_1_6
+	OpenZWave::Manager *man = (OpenZWave::Manager*)m;
+	OpenZWave::ValueID *val = (OpenZWave::ValueID*)valueid;
+	return man->GetValueAsBitSet(*val, pos, o_value);
+#else
+	return false;
+#endif
+}
+
+bool manager_getBitMask(manager_t m, valueid_t valueid, int32_t *o_value)
+{
+#ifdef GZW_OPENZWAVE_1_6
+	OpenZWave::Manager *man = (OpenZWave::Manager*)m;
+	OpenZWave::ValueID *val = (OpenZWave::ValueID*)valueid;
+	return man->GetBitMask(*val, o_value);
+#else
+	return false;
+#endif
+}
+
+bool manager_getBitSetSize(manager_t m, valueid_t valueid, uint8_t *o_value)
+{
+#ifdef GZW_OPENZWAVE_1_6
+	OpenZWave::Manager *man = (OpenZWave::Manager*)m;
+	OpenZWave::ValueID *val = (OpenZWave::ValueID*)valueid;
+	return man->GetBitSetSize(*val, o_value);
+#else
+	return false;
+#endif
+}
+
 bool manager_setValueBool(manager_t m, valueid_t valueid, bool value)
 {
 	OpenZWave::Manager *man = (OpenZWave::Manager*)m;
@@ -710,6 +756,28 @@ bool manager_setValueListSelection(manager_t m, valueid_t valueid, const char* s
 	return man->SetValueListSelection(*val, str);
 }
 
+bool manager_setValueBitSet(manager_t m, valueid_t valueid, uint8_t pos, bool value)
+{
+#ifdef GZW_OPENZWAVE_1_6
+	OpenZWave::Manager *man = (OpenZWave::Manager*)m;
+	OpenZWave::ValueID *val = (OpenZWave::ValueID*)valueid;
+	return man->SetValue(*val, pos, value);
+#else
+	return false;
+#endif
+}
+
+bool manager_setBitMask(manager_t m, valueid_t valueid, uint32_t value)
+{
+#ifdef GZW_OPENZWAVE_1_6
+	OpenZWave::Manager *man = (OpenZWave::Manager*)m;
+	OpenZWave::ValueID *val = (OpenZWave::ValueID*)valueid;
+	return man->SetBitMask(*val, value);
+#else
+	return false;
+#endif
+}
+
 bool manager_refreshValue(manager_t m, valueid_t valueid)
 {
 	OpenZWave::Manager *man = (OpenZWave::Manager*)m;
@@ -835,15 +903,38 @@ uint8_t manager_getNumGroups(manager_t m, uint32_t homeId, uint8_t nodeId)
 
 uint32_t manager_getAssociations(manager_t m, uint32_t homeId, uint8_t nodeId, uint8_t groupIdx, uint8_t **o_associations)
 {
+	// OpenZWave allocates the array with new[], copy it so that the caller
+	// can free it with free().
 	OpenZWave::Manager *man = (OpenZWave::Manager*)m;
-	return man->GetAssociations(homeId, nodeId, groupIdx, o_associations);
+	uint8_t *associations = NULL;
+	uint32_t count = man->GetAssociations(homeId, nodeId, groupIdx, &associations);
+	*o_associations = NULL;
+	if (count > 0) {
+		*o_associations = (uint8_t*)malloc(count * sizeof(uint8_t));
+		memcpy(*o_associations, associations, count * sizeof(uint8_t));
+	}
+	delete[] associations;
+	return count;
 }
 
-//TODO uint32_t manager_getAssociations(manager_t m, uint32_t homeId, uint8_t nodeId, uint8_t groupIdx, instanceassociation_t **o_associations)
-// {
-// 	OpenZWave::Manager *man = (OpenZWave::Manager*)m;
-// 	return man->GetAssociations(homeId, nodeId, groupIdx, ...);
-// }
+uint32_t manager_getInstanceAssociations(manager_t m, uint32_t homeId, uint8_t nodeId, uint8_t groupIdx, instanceassociation_t **o_associations)
+{
+	// OpenZWave allocates the array with new[], copy it so that the caller
+	// can free it with free().
+	OpenZWave::Manager *man = (OpenZWave::Manager*)m;
+	OpenZWave::InstanceAssociation *associations = NULL;
+	uint32_t count = man->GetAssociations(homeId, nodeId, groupIdx, &associations);
+	*o_associations = NULL;
+	if (count > 0) {
+		*o_associations = (instanceassociation_t*)malloc(count * sizeof(instanceassociation_t));
+		for (uint32_t i = 0; i < count; i++) {
+			(*o_associations)[i].nodeId = associations[i].m_nodeId;
+			(*o_associations)[i].instance = associations[i].m_instance;
+		}
+	}
+	delete[] associations;
+	return count;
+}
 
 uint8_t manager_getMaxAssociations(manager_t m, uint32_t homeId, uint8_t nodeId, uint8_t groupIdx)
 {
@@ -1051,8 +1142,18 @@ uint8_t manager_getNumScenes(manager_t m)
 
 uint8_t manager_getAllScenes(manager_t m, uint8_t **sceneIds)
 {
+	// OpenZWave allocates the array with new[], copy it so that the caller
+	// can free it with free().
 	OpenZWave::Manager *man = (OpenZWave::Manager*)m;
-	return man->GetAllScenes(sceneIds);
+	uint8_t *ids = NULL;
+	uint8_t count = man->GetAllScenes(&ids);
+	*sceneIds = NULL;
+	if (count > 0) {
+		*sceneIds = (uint8_t*)malloc(count);
+		memcpy(*sceneIds, ids, count);
+	}
+	delete[] ids;
+	return count;
 }
 
 void manager_removeAllScenes(manager_t m, uint32_t homeId)
@@ -1138,15 +1239,20 @@ bool manager_removeSceneValue(manager_t m, uint8_t sceneId, valueid_t valueid)
 	return man->RemoveSceneValue(sceneId, *val);
 }
 
-//TODO int manager_sceneGetValues(manager_t m, uint8_t sceneId, valueidlist_t *o_value)
-// {
-// 	OpenZWave::Manager *man = (OpenZWave::Manager*)m;
-// 	OpenZWave::ValueID *val = (OpenZWave::ValueID*)valueid;
-// 	std::vector<OpenZWave::ValueID> values;
-// 	int result = man->SceneGetValues(sceneId, *val, &values);
-// 	valueid_copyValueIDList(o_value, values);
-// 	return result;
-// }
+int manager_sceneGetValues(manager_t m, uint8_t sceneId, valueid_t **o_values)
+{
+	OpenZWave::Manager *man = (OpenZWave::Manager*)m;
+	std::vector<OpenZWave::ValueID> values;
+	int count = man->SceneGetValues(sceneId, &values);
+	*o_values = NULL;
+	if (count > 0) {
+		*o_values = (valueid_t*)malloc(count * sizeof(valueid_t));
+		for (int i = 0; i < count; i++) {
+			(*o_values)[i] = (valueid_t)new OpenZWave::ValueID(values[i]);
+		}
+	}
+	return count;
+}
 
 bool manager_sceneGetValueAsBool(manager_t m, uint8_t sceneId, valueid_t valueid, bool *o_value)
 {
@@ -1303,16 +1409,72 @@ bool manager_activateScene(manager_t m, uint8_t sceneId)
 // Statistics retreival interface.
 //
 
-//TODO void manager_getDriverStatistics(manager_t m, uint32_t homeId, driver_driverdata_t *data)
-// {
-// 	OpenZWave::Manager *man = (OpenZWave::Manager*)m;
-// 	OpenZWave::Driver::DriverData *driverData = (OpenZWave::Driver::DriverData*)data;
-// 	man->GetDriverStatistics(homeId, driverData);
-// }
+void manager_getDriverStatistics(manager_t m, uint32_t homeId, driver_data_t *data)
+{
+	OpenZWave::Manager *man = (OpenZWave::Manager*)m;
+	OpenZWave::Driver::DriverData driverData;
+	memset(&driverData, 0, sizeof(driverData));
+	man->GetDriverStatistics(homeId, &driverData);
+	data->sofCnt = driverData.m_SOFCnt;
+	data->ackWaiting = driverData.m_ACKWaiting;
+	data->readAborts = driverData.m_readAborts;
+	data->badChecksum = driverData.m_badChecksum;
+	data->readCnt = driverData.m_readCnt;
+	data->writeCnt = driverData.m_writeCnt;
+	data->canCnt = driverData.m_CANCnt;
+	data->nakCnt = driverData.m_NAKCnt;
+	data->ackCnt = driverData.m_ACKCnt;
+	data->oofCnt = driverData.m_OOFCnt;
+	data->dropped = driverData.m_dropped;
+	data->retries = driverData.m_retries;
+	data->callbacks = driverData.m_callbacks;
+	data->badroutes = driverData.m_badroutes;
+	data->noack = driverData.m_noack;
+	data->netbusy = driverData.m_netbusy;
+	data->notidle = driverData.m_notidle;
+	data->nondelivery = driverData.m_nondelivery;
+	data->routedbusy = driverData.m_routedbusy;
+	data->broadcastReadCnt = driverData.m_broadcastReadCnt;
+	data->broadcastWriteCnt = driverData.m_broadcastWriteCnt;
+}
 //
-//TODO void manager_getNodeStatistics(manager_t m, uint32_t homeId, uint8_t nodeId, node_nodedata_t *data)
-// {
-// 	OpenZWave::Manager *man = (OpenZWave::Manager*)m;
-// 	OpenZWave::Node::NodeData *nodeData = (OpenZWave::Node::NodeData*)data;
-// 	man->GetNodeStatistics(homeId, nodeId, nodeData);
-// }
+void manager_getNodeStatistics(manager_t m, uint32_t homeId, uint8_t nodeId, node_data_t *data)
+{
+	OpenZWave::Manager *man = (OpenZWave::Manager*)m;
+	OpenZWave::Node::NodeData nodeData;
+	nodeData.m_sentCnt = 0;
+	nodeData.m_sentFailed = 0;
+	nodeData.m_retries = 0;
+	nodeData.m_receivedCnt = 0;
+	nodeData.m_receivedDups = 0;
+	nodeData.m_receivedUnsolicited = 0;
+	nodeData.m_lastRequestRTT = 0;
+	nodeData.m_averageRequestRTT = 0;
+	nodeData.m_lastResponseRTT = 0;
+	nodeData.m_averageResponseRTT = 0;
+	nodeData.m_quality = 0;
+	memset(nodeData.m_lastReceivedMessage, 0, sizeof(nodeData.m_lastReceivedMessage));
+	man->GetNodeStatistics(homeId, nodeId, &nodeData);
+
+	memset(data, 0, sizeof(node_data_t));
+	data->sentCnt = nodeData.m_sentCnt;
+	data->sentFailed = nodeData.m_sentFailed;
+	data->retries = nodeData.m_retries;
+	data->receivedCnt = nodeData.m_receivedCnt;
+	data->receivedDups = nodeData.m_receivedDups;
+	data->receivedUnsolicited = nodeData.m_receivedUnsolicited;
+	strncpy(data->sentTS, nodeData.m_sentTS.c_str(), sizeof(data->sentTS) - 1);
+	strncpy(data->receivedTS, nodeData.m_receivedTS.c_str(), sizeof(data->receivedTS) - 1);
+	data->lastRequestRTT = nodeData.m_lastRequestRTT;
+	data->averageRequestRTT = nodeData.m_averageRequestRTT;
+	data->lastResponseRTT = nodeData.m_lastResponseRTT;
+	data->averageResponseRTT = nodeData.m_averageResponseRTT;
+	data->quality = nodeData.m_quality;
+	memcpy(data->lastReceivedMessage, nodeData.m_lastReceivedMessage, sizeof(data->lastReceivedMessage));
+	for (std::list<OpenZWave::Node::CommandClassData>::iterator it = nodeData.m_ccData.begin(); it != nodeData.m_ccData.end() && data->ccDataCount < 256; ++it) {
+		data->ccData[data->ccDataCount].commandClassId = it->m_commandClassId;
+		data->ccData[data->ccDataCount].sentCnt = it->m_sentCnt;
+		data->ccData[data->ccDataCount].receivedCnt = it->m_receivedCnt;
+		data->ccDataCount++;
+	}
+}
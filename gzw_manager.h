@@ -16,6 +16,13 @@ extern "C" {
 	// Types.
 	typedef void* manager_t;
 
+	// instanceassociation_t is a member of an association group, see
+	// OpenZWave::InstanceAssociation.
+	typedef struct {
+		uint8_t nodeId;
+		uint8_t instance;
+	} instanceassociation_t;
+
 	//
 	// Construction.
 	//
@@ -84,7 +91,7 @@ extern "C" {
 	uint8_t manager_getNodeGeneric(manager_t m, uint32_t homeId, uint8_t nodeId);
 	uint8_t manager_getNodeSpecific(manager_t m, uint32_t homeId, uint8_t nodeId);
 	char* manager_getNodeType(manager_t m, uint32_t homeId, uint8_t nodeId); /*!< C string must be freed. */
-//TODO uint32_t manager_getNodeNeighbors(manager_t m, uint32_t homeId, uint8_t nodeId, uint8_t **nodeNeighbors);
+	void manager_getNodeNeighbors(manager_t m, uint32_t homeId, uint8_t nodeId, zwbytes_t *o_neighbors);
 	char* manager_getNodeManufacturerName(manager_t m, uint32_t homeId, uint8_t nodeId); /*!< C string must be freed. */
 	char* manager_getNodeProductName(manager_t m, uint32_t homeId, uint8_t nodeId); /*!< C string must be freed. */
 	char* manager_getNodeName(manager_t m, uint32_t homeId, uint8_t nodeId); /*!< C string must be freed. */
@@ -138,6 +145,9 @@ extern "C" {
 	bool manager_getValueListSelectionAsInt32(manager_t m, valueid_t valueid, int32_t *o_value);
 	bool manager_getValueListItems(manager_t m, valueid_t valueid, zwlist_t **o_value);
 	bool manager_getValueFloatPrecision(manager_t m, valueid_t valueid, uint8_t *o_value);
+	bool manager_getValueAsBitSet(manager_t m, valueid_t valueid, uint8_t pos, bool *o_value);
+	bool manager_getBitMask(manager_t m, valueid_t valueid, int32_t *o_value);
+	bool manager_getBitSetSize(manager_t m, valueid_t valueid, uint8_t *o_value);
 	bool manager_setValueBool(manager_t m, valueid_t valueid, bool value);
 	bool manager_setValueUint8(manager_t m, valueid_t valueid, uint8_t value);
 	bool manager_setValueFloat(manager_t m, valueid_t valueid, float value);
@@ -146,6 +156,8 @@ extern "C" {
 	bool manager_setValueBytes(manager_t m, valueid_t valueid, zwbytes_t *value);
 	bool manager_setValueString(manager_t m, valueid_t valueid, const char* value);
 	bool manager_setValueListSelection(manager_t m, valueid_t valueid, const char* selectedItem);
+	bool manager_setValueBitSet(manager_t m, valueid_t valueid, uint8_t pos, bool value);
+	bool manager_setBitMask(manager_t m, valueid_t valueid, uint32_t value);
 	bool manager_refreshValue(manager_t m, valueid_t valueid);
 	void manager_setChangeVerified(manager_t m, valueid_t valueid, bool verify);
 	bool manager_getChangeVerified(manager_t m, valueid_t valueid);
@@ -182,8 +194,8 @@ extern "C" {
 	//
 
 	uint8_t manager_getNumGroups(manager_t m, uint32_t homeId, uint8_t nodeId);
-	uint32_t manager_getAssociations(manager_t m, uint32_t homeId, uint8_t nodeId, uint8_t groupIdx, uint8_t **o_associations);
-//TODO uint32_t manager_getAssociations(manager_t m, uint32_t homeId, uint8_t nodeId, uint8_t groupIdx, instanceassociation_t **o_associations);
+	uint32_t manager_getAssociations(manager_t m, uint32_t homeId, uint8_t nodeId, uint8_t groupIdx, uint8_t **o_associations); /*!< Array must be freed. */
+	uint32_t manager_getInstanceAssociations(manager_t m, uint32_t homeId, uint8_t nodeId, uint8_t groupIdx, instanceassociation_t **o_associations); /*!< Array must be freed. */
 	uint8_t manager_getMaxAssociations(manager_t m, uint32_t homeId, uint8_t nodeId, uint8_t groupIdx);
 	char* manager_getGroupLabel(manager_t m, uint32_t homeId, uint8_t nodeId, uint8_t groupIdx);
 	void manager_addAssociation(manager_t m, uint32_t homeId, uint8_t nodeId, uint8_t groupIdx, uint8_t targetNodeId, uint8_t instance);
@@ -235,7 +247,7 @@ extern "C" {
 	//
 
 	uint8_t manager_getNumScenes(manager_t m);
-	uint8_t manager_getAllScenes(manager_t m, uint8_t **sceneIds);
+	uint8_t manager_getAllScenes(manager_t m, uint8_t **sceneIds); /*!< sceneIds must be freed. */
 	void manager_removeAllScenes(manager_t m, uint32_t homeId);
 	uint8_t manager_createScene(manager_t m);
 	bool manager_removeScene(manager_t m, uint8_t sceneId);
@@ -248,7 +260,7 @@ extern "C" {
 	bool manager_addSceneValueListSelectionString(manager_t m, uint8_t sceneId, valueid_t valueid, const char* value);
 	bool manager_addSceneValueListSelectionInt32(manager_t m, uint8_t sceneId, valueid_t valueid, int32_t value);
 	bool manager_removeSceneValue(manager_t m, uint8_t sceneId, valueid_t valueid);
-//TODO int manager_sceneGetValues(manager_t m, uint8_t sceneId, valueidlist_t *o_value);
+	int manager_sceneGetValues(manager_t m, uint8_t sceneId, valueid_t **o_values); /*!< Each valueid must be freed with valueid_free, then o_values with free. */
 	bool manager_sceneGetValueAsBool(manager_t m, uint8_t sceneId, valueid_t valueid, bool *o_value);
 	bool manager_sceneGetValueAsByte(manager_t m, uint8_t sceneId, valueid_t valueid, uint8_t *o_value);
 	bool manager_sceneGetValueAsFloat(manager_t m, uint8_t sceneId, valueid_t valueid, float *o_value);
@@ -274,8 +286,61 @@ extern "C" {
 	// Statistics retreival interface.
 	//
 
-//TODO void manager_getDriverStatistics(manager_t m, uint32_t homeId, driver_driverdata_t *data);
-//TODO void manager_getNodeStatistics(manager_t m, uint32_t homeId, uint8_t nodeId, node_nodedata_t *data);
+	// driver_data_t holds the statistics of a driver, see OpenZWave::Driver::DriverData.
+	typedef struct {
+		uint32_t sofCnt;
+		uint32_t ackWaiting;
+		uint32_t readAborts;
+		uint32_t badChecksum;
+		uint32_t readCnt;
+		uint32_t writeCnt;
+		uint32_t canCnt;
+		uint32_t nakCnt;
+		uint32_t ackCnt;
+		uint32_t oofCnt;
+		uint32_t dropped;
+		uint32_t retries;
+		uint32_t callbacks;
+		uint32_t badroutes;
+		uint32_t noack;
+		uint32_t netbusy;
+		uint32_t notidle;
+		uint32_t nondelivery;
+		uint32_t routedbusy;
+		uint32_t broadcastReadCnt;
+		uint32_t broadcastWriteCnt;
+	} driver_data_t;
+
+	void manager_getDriverStatistics(manager_t m, uint32_t homeId, driver_data_t *data);
+	// node_ccdata_t holds the message counts of a command class, see
+	// OpenZWave::Node::CommandClassData.
+	typedef struct {
+		uint8_t commandClassId;
+		uint32_t sentCnt;
+		uint32_t receivedCnt;
+	} node_ccdata_t;
+
+	// node_data_t holds the statistics of a node, see OpenZWave::Node::NodeData.
+	typedef struct {
+		uint32_t sentCnt;
+		uint32_t sentFailed;
+		uint32_t retries;
+		uint32_t receivedCnt;
+		uint32_t receivedDups;
+		uint32_t receivedUnsolicited;
+		char sentTS[32];
+		char receivedTS[32];
+		uint32_t lastRequestRTT;
+		uint32_t averageRequestRTT;
+		uint32_t lastResponseRTT;
+		uint32_t averageResponseRTT;
+		uint8_t quality;
+		uint8_t lastReceivedMessage[254];
+		node_ccdata_t ccData[256];
+		size_t ccDataCount;
+	} node_data_t;
+
+	void manager_getNodeStatistics(manager_t m, uint32_t homeId, uint8_t nodeId, node_data_t *data);
 
 #ifdef __cplusplus
 }
@@ -58,6 +58,38 @@ extern "C" {
         notification_code_alive
     } notification_code;
 
+    // enum controller_state, matching OpenZWave::Driver::ControllerState.
+    typedef enum {
+        controller_state_normal = 0,
+        controller_state_starting,
+        controller_state_cancel,
+        controller_state_error,
+        controller_state_waiting,
+        controller_state_sleeping,
+        controller_state_inProgress,
+        controller_state_completed,
+        controller_state_failed,
+        controller_state_nodeOK,
+        controller_state_nodeFailed
+    } controller_state;
+
+    // enum controller_error, matching OpenZWave::Driver::ControllerError.
+    typedef enum {
+        controller_error_none = 0,
+        controller_error_buttonNotFound,
+        controller_error_nodeNotFound,
+        controller_error_notBridge,
+        controller_error_notSUC,
+        controller_error_notSecondary,
+        controller_error_notPrimary,
+        controller_error_isPrimary,
+        controller_error_notFound,
+        controller_error_busy,
+        controller_error_failed,
+        controller_error_disabled,
+        controller_error_overflow
+    } controller_error;
+
     // Public member functions.
     notification_type notification_getType(notification_t n);
     uint32_t notification_getHomeId(notification_t n);
@@ -20,7 +20,7 @@ uint8_t valueid_getNodeId(valueid_t v)
 valueid_genre valueid_getGenre(valueid_t v)
 {
     OpenZWave::ValueID *valid = (OpenZWave::ValueID*)v;
-    valueid_genre val_genre;
+    valueid_genre val_genre = valueid_genre_unknown;
     switch (valid->GetGenre()) {
         case OpenZWave::ValueID::ValueGenre_Basic:
             val_genre = valueid_genre_basic;
@@ -62,7 +62,7 @@ uint8_t valueid_getIndex(valueid_t v)
 valueid_type valueid_getType(valueid_t v)
 {
     OpenZWave::ValueID *valid = (OpenZWave::ValueID*)v;
-    valueid_type val_type;
+    valueid_type val_type = valueid_type_unknown;
     switch (valid->GetType()) {
         case OpenZWave::ValueID::ValueType_Bool:
             val_type = valueid_type_bool;
@@ -94,9 +94,11 @@ valueid_type valueid_getType(valueid_t v)
         case OpenZWave::ValueID::ValueType_Raw:
             val_type = valueid_type_raw;
             break;
-        // case OpenZWave::ValueID::ValueType_Max:
-        //     val_type = valueid_type_max;
-        //     break;
+#ifdef GZW_OPENZWAVE_1_6
+        case OpenZWave::ValueID::ValueType_BitSet:
+            val_type = valueid_type_bitset;
+            break;
+#endif
     }
     return val_type;
 }
@@ -18,7 +18,8 @@ extern "C" {
         valueid_genre_user,
         valueid_genre_config,
         valueid_genre_system,
-        valueid_genre_count
+        valueid_genre_count,
+        valueid_genre_unknown = -1
     } valueid_genre;
 
     // enum valueid_type
@@ -33,7 +34,9 @@ extern "C" {
         valueid_type_string,
         valueid_type_button,
         valueid_type_raw,
-        valueid_type_max = valueid_type_raw
+        valueid_type_bitset,
+        valueid_type_max = valueid_type_bitset,
+        valueid_type_unknown = -1
     } valueid_type;
 
     // Public member functions.
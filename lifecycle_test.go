@@ -0,0 +1,220 @@
+package goopenzwave_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jimjibone/goopenzwave"
+	"github.com/jimjibone/goopenzwave/sim"
+)
+
+// errNotState is used as the wanted error of a lifecycle case which must fail
+// with an error other than a *StateError.
+var errNotState = errors.New("error other than a StateError")
+
+// lifecycle holds the Options and Manager of a lifecycle test case.
+type lifecycle struct {
+	options *goopenzwave.Options
+	manager *goopenzwave.Manager
+}
+
+// lifecycleStep is one operation of a lifecycle test case.
+type lifecycleStep func(l *lifecycle) error
+
+func lockOptions(l *lifecycle) error {
+	return l.options.Lock()
+}
+
+func addOption(l *lifecycle) error {
+	return l.options.AddOptionInt("PollInterval", 500)
+}
+
+func destroyOptions(l *lifecycle) error {
+	return l.options.Destroy()
+}
+
+func newManager(l *lifecycle) error {
+	m, err := goopenzwave.NewManager(l.options)
+	if err == nil {
+		l.manager = m
+	}
+	return err
+}
+
+func newManagerNilOptions(l *lifecycle) error {
+	_, err := goopenzwave.NewManager(nil)
+	return err
+}
+
+func start(l *lifecycle) error {
+	return l.manager.Start(func(notification *goopenzwave.Notification) {})
+}
+
+func stop(l *lifecycle) error {
+	return l.manager.Stop()
+}
+
+func destroy(l *lifecycle) error {
+	return l.manager.Destroy()
+}
+
+func addDriver(l *lifecycle) error {
+	return l.manager.AddDriver(sim.DefaultControllerPath)
+}
+
+func removeDriver(l *lifecycle) error {
+	return l.manager.RemoveDriver(sim.DefaultControllerPath)
+}
+
+// Steps bringing a new Manager to each of its states.
+var (
+	created      = []lifecycleStep{lockOptions, newManager}
+	started      = []lifecycleStep{lockOptions, newManager, start}
+	driversAdded = []lifecycleStep{lockOptions, newManager, start, addDriver}
+	stopped      = []lifecycleStep{lockOptions, newManager, start, stop}
+	destroyed    = []lifecycleStep{lockOptions, newManager, start, stop, destroy}
+)
+
+func TestLifecycle(t *testing.T) {
+	tests := []struct {
+		name   string
+		setup  []lifecycleStep
+		op     lifecycleStep
+		opName string
+		want   error                    // nil for success, or the Err variable of the StateError.
+		state  goopenzwave.ManagerState // Checked if a Manager was created.
+	}{
+		// Options.
+		{"Lock", nil, lockOptions, "Lock", nil, 0},
+		{"Lock twice", []lifecycleStep{lockOptions}, lockOptions, "Lock", goopenzwave.ErrOptionsLocked, 0},
+		{"Lock destroyed", []lifecycleStep{destroyOptions}, lockOptions, "Lock", goopenzwave.ErrOptionsDestroyed, 0},
+		{"AddOption", nil, addOption, "AddOptionInt", nil, 0},
+		{"AddOption locked", []lifecycleStep{lockOptions}, addOption, "AddOptionInt", goopenzwave.ErrOptionsLocked, 0},
+		{"AddOption destroyed", []lifecycleStep{destroyOptions}, addOption, "AddOptionInt", goopenzwave.ErrOptionsDestroyed, 0},
+		{"DestroyOptions", nil, destroyOptions, "DestroyOptions", nil, 0},
+		{"DestroyOptions twice", []lifecycleStep{destroyOptions}, destroyOptions, "DestroyOptions", goopenzwave.ErrOptionsDestroyed, 0},
+		{"DestroyOptions in use", created, destroyOptions, "DestroyOptions", goopenzwave.ErrOptionsInUse, goopenzwave.ManagerStateCreated},
+		{"DestroyOptions started", started, destroyOptions, "DestroyOptions", goopenzwave.ErrOptionsInUse, goopenzwave.ManagerStateStarted},
+		{"DestroyOptions after Destroy", destroyed, destroyOptions, "DestroyOptions", nil, goopenzwave.ManagerStateDestroyed},
+
+		// NewManager.
+		{"NewManager", []lifecycleStep{lockOptions}, newManager, "NewManager", nil, goopenzwave.ManagerStateCreated},
+		{"NewManager nil options", nil, newManagerNilOptions, "NewManager", goopenzwave.ErrOptionsNotCreated, 0},
+		{"NewManager unlocked", nil, newManager, "NewManager", goopenzwave.ErrOptionsNotLocked, 0},
+		{"NewManager options destroyed", []lifecycleStep{destroyOptions}, newManager, "NewManager", goopenzwave.ErrOptionsDestroyed, 0},
+		{"NewManager twice", created, newManager, "NewManager", goopenzwave.ErrOptionsInUse, goopenzwave.ManagerStateCreated},
+
+		// Start.
+		{"Start", created, start, "Start", nil, goopenzwave.ManagerStateStarted},
+		{"Start twice", started, start, "Start", goopenzwave.ErrManagerStarted, goopenzwave.ManagerStateStarted},
+		{"Start with drivers", driversAdded, start, "Start", goopenzwave.ErrManagerStarted, goopenzwave.ManagerStateDriversAdded},
+		{"Start after Stop", stopped, start, "Start", nil, goopenzwave.ManagerStateStarted},
+		{"Start after Destroy", destroyed, start, "Start", goopenzwave.ErrManagerDestroyed, goopenzwave.ManagerStateDestroyed},
+
+		// Stop.
+		{"Stop", started, stop, "Stop", nil, goopenzwave.ManagerStateStopped},
+		{"Stop with drivers", driversAdded, stop, "Stop", nil, goopenzwave.ManagerStateStopped},
+		{"Stop before Start", created, stop, "Stop", goopenzwave.ErrManagerNotStarted, goopenzwave.ManagerStateCreated},
+		{"Stop twice", stopped, stop, "Stop", goopenzwave.ErrManagerNotStarted, goopenzwave.ManagerStateStopped},
+		{"Stop after Destroy", destroyed, stop, "Stop", goopenzwave.ErrManagerDestroyed, goopenzwave.ManagerStateDestroyed},
+
+		// Destroy.
+		{"Destroy created", created, destroy, "Destroy", nil, goopenzwave.ManagerStateDestroyed},
+		{"Destroy started", started, destroy, "Destroy", nil, goopenzwave.ManagerStateDestroyed},
+		{"Destroy with drivers", driversAdded, destroy, "Destroy", nil, goopenzwave.ManagerStateDestroyed},
+		{"Destroy stopped", stopped, destroy, "Destroy", nil, goopenzwave.ManagerStateDestroyed},
+		{"Destroy twice", destroyed, destroy, "Destroy", goopenzwave.ErrManagerDestroyed, goopenzwave.ManagerStateDestroyed},
+
+		// AddDriver.
+		{"AddDriver created", created, addDriver, "AddDriver", goopenzwave.ErrManagerNotStarted, goopenzwave.ManagerStateCreated},
+		{"AddDriver started", started, addDriver, "AddDriver", nil, goopenzwave.ManagerStateDriversAdded},
+		{"AddDriver twice", driversAdded, addDriver, "AddDriver", errNotState, goopenzwave.ManagerStateDriversAdded},
+		{"AddDriver stopped", stopped, addDriver, "AddDriver", goopenzwave.ErrManagerNotStarted, goopenzwave.ManagerStateStopped},
+		{"AddDriver destroyed", destroyed, addDriver, "AddDriver", goopenzwave.ErrManagerDestroyed, goopenzwave.ManagerStateDestroyed},
+
+		// RemoveDriver.
+		{"RemoveDriver created", created, removeDriver, "RemoveDriver", goopenzwave.ErrManagerNoDrivers, goopenzwave.ManagerStateCreated},
+		{"RemoveDriver started", started, removeDriver, "RemoveDriver", goopenzwave.ErrManagerNoDrivers, goopenzwave.ManagerStateStarted},
+		{"RemoveDriver", driversAdded, removeDriver, "RemoveDriver", nil, goopenzwave.ManagerStateStarted},
+		{"RemoveDriver twice", append(driversAdded[:len(driversAdded):len(driversAdded)], removeDriver), removeDriver, "RemoveDriver", goopenzwave.ErrManagerNoDrivers, goopenzwave.ManagerStateStarted},
+		{"RemoveDriver stopped", append(driversAdded[:len(driversAdded):len(driversAdded)], stop), removeDriver, "RemoveDriver", nil, goopenzwave.ManagerStateStopped},
+		{"RemoveDriver stopped without drivers", stopped, removeDriver, "RemoveDriver", goopenzwave.ErrManagerNoDrivers, goopenzwave.ManagerStateStopped},
+		{"RemoveDriver destroyed", destroyed, removeDriver, "RemoveDriver", goopenzwave.ErrManagerDestroyed, goopenzwave.ManagerStateDestroyed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			goopenzwave.SetBackend(sim.New())
+			l := &lifecycle{options: goopenzwave.CreateOptions("", "", "")}
+			t.Cleanup(func() {
+				if l.manager != nil {
+					l.manager.Destroy()
+				}
+				l.options.Destroy()
+			})
+			for i, step := range tt.setup {
+				if err := step(l); err != nil {
+					t.Fatalf("setup step %d: %v", i, err)
+				}
+			}
+
+			err := tt.op(l)
+			checkLifecycleError(t, err, tt.opName, tt.want)
+			if l.manager != nil {
+				if state := l.manager.State(); state != tt.state {
+					t.Errorf("manager state = %s, want %s", state, tt.state)
+				}
+			}
+		})
+	}
+}
+
+func TestLifecycleNoBackend(t *testing.T) {
+	goopenzwave.SetBackend(nil)
+	options := goopenzwave.CreateOptions("", "", "")
+	_, err := goopenzwave.NewManager(options)
+	checkLifecycleError(t, err, "NewManager", goopenzwave.ErrNoBackend)
+}
+
+func TestPackageLifecycle(t *testing.T) {
+	goopenzwave.SetBackend(sim.New())
+	options := goopenzwave.CreateOptions("", "", "")
+	defer options.Destroy()
+	handler := func(notification *goopenzwave.Notification) {}
+
+	checkLifecycleError(t, goopenzwave.Stop(), "Stop", goopenzwave.ErrManagerNotStarted)
+	checkLifecycleError(t, goopenzwave.Start(handler), "NewManager", goopenzwave.ErrOptionsNotLocked)
+	if err := options.Lock(); err != nil {
+		t.Fatal(err)
+	}
+	checkLifecycleError(t, goopenzwave.Start(handler), "", nil)
+	checkLifecycleError(t, goopenzwave.Start(handler), "Start", goopenzwave.ErrManagerStarted)
+	checkLifecycleError(t, goopenzwave.Stop(), "", nil)
+	checkLifecycleError(t, goopenzwave.Stop(), "Stop", goopenzwave.ErrManagerNotStarted)
+}
+
+// checkLifecycleError checks that err is nil if want is nil, a *StateError for
+// op wrapping want otherwise, or any other error if want is errNotState.
+func checkLifecycleError(t *testing.T, err error, op string, want error) {
+	t.Helper()
+	var stateErr *goopenzwave.StateError
+	switch {
+	case want == nil:
+		if err != nil {
+			t.Errorf("error = %v, want nil", err)
+		}
+	case want == errNotState:
+		if err == nil || errors.As(err, &stateErr) {
+			t.Errorf("error = %v, want an error other than a StateError", err)
+		}
+	default:
+		if errors.As(err, &stateErr) == false {
+			t.Fatalf("error = %v (%T), want a *StateError", err, err)
+		}
+		if stateErr.Op != op {
+			t.Errorf("StateError.Op = %q, want %q", stateErr.Op, op)
+		}
+		if errors.Is(err, want) == false {
+			t.Errorf("error = %v, want errors.Is %v", err, want)
+		}
+	}
+}
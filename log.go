@@ -0,0 +1,84 @@
+package goopenzwave
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// LogHandler defines the format for a function that will handle the lines
+// written to the OpenZWave log. nodeID is 0 for lines which are not about a
+// node.
+type LogHandler func(level LogLevel, nodeID uint8, message string)
+
+// SetLogHandler forwards the OpenZWave log to the handler, in place of the log
+// file or console set up by the LogFileName and ConsoleOutput options. Lines
+// more detailed than the SaveLogLevel option are dropped, and nothing is logged
+// if the Logging option is false. The "Node004, " prefix OpenZWave gives lines
+// about a node is removed from the message and passed as the nodeID.
+//
+// The lines OpenZWave logs while the Manager is being created are not
+// forwarded. A nil handler discards the log. The handler is called on the
+// OpenZWave threads, so it should return quickly.
+func (m *Manager) SetLogHandler(handler LogHandler) error {
+	m.lifecycle.Lock()
+	defer m.lifecycle.Unlock()
+	switch m.State() {
+	case ManagerStateNotCreated, ManagerStateDestroyed:
+		return m.stateError("SetLogHandler")
+	}
+	var forward LogHandler
+	if handler != nil {
+		forward = func(level LogLevel, nodeID uint8, message string) {
+			nodeID, message = splitLogNode(nodeID, message)
+			handler(level, nodeID, message)
+		}
+	}
+	if m.backend.SetLogHandler(forward) == false {
+		return fmt.Errorf("failed to set log handler")
+	}
+	return nil
+}
+
+// NewSlogLogHandler returns a LogHandler which writes each line to the logger
+// at the SlogLevel of its LogLevel. The OpenZWave level is added as the
+// "ozw_level" attribute, and the node ID, if there is one, as "node".
+func NewSlogLogHandler(logger *slog.Logger) LogHandler {
+	return func(level LogLevel, nodeID uint8, message string) {
+		ctx := context.Background()
+		slogLevel := level.SlogLevel()
+		if logger.Enabled(ctx, slogLevel) == false {
+			return
+		}
+		attrs := []slog.Attr{slog.String("ozw_level", level.String())}
+		if nodeID != 0 {
+			attrs = append(attrs, slog.Int("node", int(nodeID)))
+		}
+		logger.LogAttrs(ctx, slogLevel, message, attrs...)
+	}
+}
+
+// splitLogNode removes the "Node004, " prefix from an OpenZWave log line,
+// returning the node ID from it if nodeID is 0.
+func splitLogNode(nodeID uint8, message string) (uint8, string) {
+	message = strings.TrimRight(message, "\r\n")
+	rest, ok := strings.CutPrefix(message, "Node")
+	if ok == false || len(rest) < 4 || rest[3] != ',' {
+		return nodeID, message
+	}
+	id := 0
+	for _, c := range rest[:3] {
+		if c < '0' || c > '9' {
+			return nodeID, message
+		}
+		id = id*10 + int(c-'0')
+	}
+	if id > 0xff {
+		return nodeID, message
+	}
+	if nodeID == 0 {
+		nodeID = uint8(id)
+	}
+	return nodeID, strings.TrimLeft(rest[4:], " ")
+}
@@ -1,22 +1,80 @@
 package goopenzwave
 
-// #include "gzw_loglevel.h"
-// #include <stdlib.h>
-import "C"
+import (
+	"log/slog"
+)
 
+// LogLevel defines a type for the OpenZWave log level enum. The values match
+// the loglevel_t enum in gzw_loglevel.h.
 type LogLevel int32
 
 const (
-	LogLevelInvalid      = LogLevel(C.loglevel_invalid)
-	LogLevelNone         = LogLevel(C.loglevel_none)
-	LogLevelAlways       = LogLevel(C.loglevel_always)
-	LogLevelFatal        = LogLevel(C.loglevel_fatal)
-	LogLevelError        = LogLevel(C.loglevel_error)
-	LogLevelWarning      = LogLevel(C.loglevel_warning)
-	LogLevelAlert        = LogLevel(C.loglevel_alert)
-	LogLevelInfo         = LogLevel(C.loglevel_info)
-	LogLevelDetail       = LogLevel(C.loglevel_detail)
-	LogLevelDebug        = LogLevel(C.loglevel_debug)
-	LogLevelStreamdetail = LogLevel(C.loglevel_streamdetail)
-	LogLevelInternal     = LogLevel(C.loglevel_internal)
+	LogLevelInvalid LogLevel = iota
+	LogLevelNone
+	LogLevelAlways
+	LogLevelFatal
+	LogLevelError
+	LogLevelWarning
+	LogLevelAlert
+	LogLevelInfo
+	LogLevelDetail
+	LogLevelDebug
+	LogLevelStreamdetail
+	LogLevelInternal
 )
+
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelInvalid:
+		return "Invalid"
+	case LogLevelNone:
+		return "None"
+	case LogLevelAlways:
+		return "Always"
+	case LogLevelFatal:
+		return "Fatal"
+	case LogLevelError:
+		return "Error"
+	case LogLevelWarning:
+		return "Warning"
+	case LogLevelAlert:
+		return "Alert"
+	case LogLevelInfo:
+		return "Info"
+	case LogLevelDetail:
+		return "Detail"
+	case LogLevelDebug:
+		return "Debug"
+	case LogLevelStreamdetail:
+		return "StreamDetail"
+	case LogLevelInternal:
+		return "Internal"
+	}
+	return "UNKNOWN"
+}
+
+// SlogLevel returns the log/slog level for the OpenZWave log level. Fatal is
+// above slog.LevelError and Alert between slog.LevelWarn and slog.LevelInfo.
+// Detail is slog.LevelDebug, with Debug, StreamDetail and Internal below it.
+// Always, which OpenZWave logs whatever the log level, is slog.LevelInfo.
+func (l LogLevel) SlogLevel() slog.Level {
+	switch l {
+	case LogLevelFatal:
+		return slog.LevelError + 4
+	case LogLevelError:
+		return slog.LevelError
+	case LogLevelWarning:
+		return slog.LevelWarn
+	case LogLevelAlert:
+		return slog.LevelInfo + 2
+	case LogLevelDetail:
+		return slog.LevelDebug
+	case LogLevelDebug:
+		return slog.LevelDebug - 2
+	case LogLevelStreamdetail:
+		return slog.LevelDebug - 4
+	case LogLevelInternal:
+		return slog.LevelDebug - 6
+	}
+	return slog.LevelInfo
+}
@@ -1,56 +1,327 @@
 package goopenzwave
 
-// #include "gzw_manager.h"
-// #include "gzw_notification.h"
-// #include <stdlib.h>
-import "C"
 import (
 	"fmt"
-	"unsafe"
+	"sync"
 )
 
-var (
-	cmanager C.manager_t
+// ManagerState defines a type for the lifecycle state of a Manager.
+type ManagerState int
+
+const (
+	ManagerStateNotCreated ManagerState = iota
+	ManagerStateCreated
+	ManagerStateStarted
+	ManagerStateDriversAdded
+	ManagerStateStopped
+	ManagerStateDestroyed
 )
 
-// createManager creates the Manager singleton object. The Manager provides the
-// public interface to OpenZWave, exposing all the functionality required to add
-// Z-Wave support to an application. There can be only one Manager in an
-// OpenZWave application. An Options object must be created and Locked first,
-// otherwise the call to Manager::Create will fail. Once the Manager has been
-// created, call AddWatcher to install a notification callback handler, and then
-// call the AddDriver method for each attached PC Z-Wave controller in turn.
-func createManager() error {
-	cmanager = C.manager_create()
-	if cmanager == nil {
-		return fmt.Errorf("libopenzwave returned NULL pointer")
+func (s ManagerState) String() string {
+	switch s {
+	case ManagerStateNotCreated:
+		return "NotCreated"
+	case ManagerStateCreated:
+		return "Created"
+	case ManagerStateStarted:
+		return "Started"
+	case ManagerStateDriversAdded:
+		return "DriversAdded"
+	case ManagerStateStopped:
+		return "Stopped"
+	case ManagerStateDestroyed:
+		return "Destroyed"
+	}
+	return "UNKNOWN"
+}
+
+// Manager is a container for the C++ OpenZWave library Manager class. The
+// Manager provides the public interface to OpenZWave, exposing all the
+// functionality required to add Z-Wave support to an application.
+//
+// Create a Manager with NewManager once the Options have been created and
+// locked, then call Start to install a notification handler, and then call
+// AddDriver for each attached PC Z-Wave controller in turn. When finished call
+// Stop and then Destroy. Lifecycle methods called in the wrong state return a
+// *StateError.
+//
+// There can only be one Manager for each Backend; the OpenZWave library only
+// supports a single Manager per application. Every package level function
+// calls the method of the same name on the default Manager, which is created by
+// the package level Start function.
+type Manager struct {
+	backend Backend
+	options *Options
+
+	// lifecycle serialises the lifecycle methods. It is not held by State,
+	// so that a NotificationHandler may call State while the Manager is
+	// being stopped.
+	lifecycle sync.Mutex
+
+	mu      sync.Mutex
+	state   ManagerState
+	drivers map[string]bool
+
+	// queueCapacity and queuePolicy configure the NotificationQueue created
+	// by Start. The queue is not used if queueCapacity is 0.
+	queueCapacity int
+	queuePolicy   OverflowPolicy
+	queue         *NotificationQueue
+
+	// subs is replaced, never modified, when a Subscription is added or
+	// removed. See subscribe.go. subsClosed is set once the Manager has
+	// been destroyed.
+	subsMu     sync.Mutex
+	subs       []*Subscription
+	subsClosed bool
+
+	// handler is only written by Start before the watcher is added, so that
+	// dispatch can read it without holding mu.
+	handler NotificationHandler
+}
+
+// NotificationHandler defines the format for a function that will handle new
+// Notification's as they arrive.
+type NotificationHandler func(notification *Notification)
+
+// NewManager creates a new Manager using the Backend of the Options. The
+// Options must have been created and locked first, and must not be in use by
+// another Manager.
+func NewManager(options *Options) (*Manager, error) {
+	if options == nil {
+		return nil, &StateError{Op: "NewManager", Err: ErrOptionsNotCreated}
+	}
+	if options.backend == nil {
+		return nil, &StateError{Op: "NewManager", Err: ErrNoBackend}
+	}
+	m := &Manager{
+		backend: options.backend,
+		options: options,
+		state:   ManagerStateCreated,
+		drivers: make(map[string]bool),
+	}
+	err := options.attach(m)
+	if err != nil {
+		return nil, err
+	}
+	if m.backend.CreateManager() == false {
+		m.setState(ManagerStateDestroyed)
+		return nil, fmt.Errorf("backend failed to create manager")
+	}
+	return m, nil
+}
+
+// Options returns the Options used to create the Manager.
+func (m *Manager) Options() *Options {
+	return m.options
+}
+
+// State returns the current lifecycle state of the Manager.
+func (m *Manager) State() ManagerState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state
+}
+
+// setState changes the lifecycle state of the Manager.
+func (m *Manager) setState(state ManagerState) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.state = state
+}
+
+// stateError returns a StateError for the operation, describing why it cannot
+// be performed in the current state.
+func (m *Manager) stateError(op string) error {
+	var err error
+	switch m.State() {
+	case ManagerStateNotCreated:
+		err = ErrManagerNotCreated
+	case ManagerStateCreated, ManagerStateStopped:
+		err = ErrManagerNotStarted
+	case ManagerStateStarted, ManagerStateDriversAdded:
+		err = ErrManagerStarted
+	case ManagerStateDestroyed:
+		err = ErrManagerDestroyed
+	}
+	return &StateError{Op: op, Err: err}
+}
+
+// checkStarted returns a StateError for the operation unless the Manager is
+// started.
+func (m *Manager) checkStarted(op string) error {
+	switch m.State() {
+	case ManagerStateStarted, ManagerStateDriversAdded:
+		return nil
+	case ManagerStateCreated, ManagerStateStopped:
+		return &StateError{Op: op, Err: ErrManagerNotStarted}
+	}
+	return m.stateError(op)
+}
+
+// Start starts notifications, passing each new Notification to the handler.
+// The Manager must be newly created or stopped.
+func (m *Manager) Start(handler NotificationHandler) error {
+	m.lifecycle.Lock()
+	defer m.lifecycle.Unlock()
+	state := m.State()
+	if state != ManagerStateCreated && state != ManagerStateStopped {
+		return m.stateError("Start")
 	}
+	m.mu.Lock()
+	if m.queueCapacity > 0 {
+		m.queue = NewNotificationQueue(handler, m.queueCapacity, m.queuePolicy)
+		handler = m.queue.Handle
+	}
+	m.mu.Unlock()
+	m.handler = handler
+	ok := m.backend.AddWatcher(m.dispatch)
+	if ok == false {
+		m.closeQueue()
+		return fmt.Errorf("failed to add watcher")
+	}
+	m.mu.Lock()
+	if len(m.drivers) > 0 {
+		m.state = ManagerStateDriversAdded
+	} else {
+		m.state = ManagerStateStarted
+	}
+	m.mu.Unlock()
+	return nil
+}
+
+// Stop stops notifications. The Manager must be started. Any drivers remain
+// until they are removed or the Manager is destroyed.
+func (m *Manager) Stop() error {
+	m.lifecycle.Lock()
+	defer m.lifecycle.Unlock()
+	if err := m.checkStarted("Stop"); err != nil {
+		return err
+	}
+	ok := m.backend.RemoveWatcher()
+	if ok == false {
+		return fmt.Errorf("failed to remove watcher")
+	}
+	m.closeQueue()
+	m.setState(ManagerStateStopped)
 	return nil
 }
 
-// getManager gets a pointer to the Manager object.
-func getManager() C.manager_t {
-	return cmanager
+// Destroy deletes the Manager and cleans up any associated objects, stopping
+// it first if required. The Manager cannot be used again afterwards. Don't
+// forget to destroy the Options object after calling this.
+func (m *Manager) Destroy() error {
+	m.lifecycle.Lock()
+	defer m.lifecycle.Unlock()
+	switch m.State() {
+	case ManagerStateNotCreated, ManagerStateDestroyed:
+		return m.stateError("Destroy")
+	case ManagerStateStarted, ManagerStateDriversAdded:
+		m.backend.RemoveWatcher()
+		m.closeQueue()
+	}
+	m.backend.DestroyManager()
+	m.unsubscribeAll()
+	m.mu.Lock()
+	m.state = ManagerStateDestroyed
+	m.drivers = make(map[string]bool)
+	m.mu.Unlock()
+	return nil
+}
+
+// SetNotificationQueue configures the Manager to pass notifications to the
+// NotificationHandler on a separate goroutine, through a NotificationQueue
+// holding up to capacity notifications, so that a slow handler does not stall
+// the OpenZWave driver thread. A capacity of 0 passes notifications to the
+// handler directly, which is the default. It must be called before Start.
+func (m *Manager) SetNotificationQueue(capacity int, policy OverflowPolicy) error {
+	m.lifecycle.Lock()
+	defer m.lifecycle.Unlock()
+	state := m.State()
+	if state != ManagerStateCreated && state != ManagerStateStopped {
+		return m.stateError("SetNotificationQueue")
+	}
+	if capacity < 0 {
+		capacity = 0
+	}
+	m.mu.Lock()
+	m.queueCapacity = capacity
+	m.queuePolicy = policy
+	m.mu.Unlock()
+	return nil
 }
 
-// destroyManager deletes the Manager and cleans up any associated objects.
-func destroyManager() {
-	C.manager_destroy()
+// NotificationQueue returns the NotificationQueue in use while the Manager is
+// started, or nil if notifications are passed to the handler directly. Use it
+// to read the queue's depth and drop counters.
+func (m *Manager) NotificationQueue() *NotificationQueue {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.queue
+}
+
+// closeQueue closes the NotificationQueue, if any, once the watcher has been
+// removed, waiting for the queued notifications to be handled.
+func (m *Manager) closeQueue() {
+	m.mu.Lock()
+	queue := m.queue
+	m.queue = nil
+	m.mu.Unlock()
+	if queue != nil {
+		queue.Close()
+	}
+}
+
+// dispatch is installed as the Backend watcher while the Manager is started.
+// It associates the Notification's ValueID with the Manager and passes the
+// Notification on to the handler and any subscriptions.
+func (m *Manager) dispatch(notification *Notification) {
+	if notification.ValueID != nil {
+		notification.ValueID.manager = m
+	}
+	if m.handler != nil {
+		m.handler(notification)
+	}
+	m.publish(notification)
+}
+
+// NewNode will create a new Node object for the node on this Manager.
+func (m *Manager) NewNode(homeID uint32, nodeID uint8) *Node {
+	return &Node{
+		HomeID:  homeID,
+		NodeID:  nodeID,
+		manager: m,
+	}
+}
+
+// NewValueID will create a new ValueID for the value with the fields on this
+// Manager. See NewValueID.
+func (m *Manager) NewValueID(homeID uint32, nodeID uint8, genre ValueIDGenre, commandClassID, instance, index uint8, typ ValueIDType) (*ValueID, error) {
+	v, err := NewValueID(homeID, nodeID, genre, commandClassID, instance, index, typ)
+	if err != nil {
+		return nil, err
+	}
+	v.manager = m
+	return v, nil
+}
+
+// NewScene will create a new Scene object for the scene on this Manager.
+func (m *Manager) NewScene(sceneID uint8) *Scene {
+	return &Scene{
+		SceneID: sceneID,
+		manager: m,
+	}
 }
 
 // GetVersionAsString returns the Version Number of OZW as a string.
-func GetVersionAsString() string {
-	cstr := C.manager_getVersionAsString()
-	defer C.free(unsafe.Pointer(cstr))
-	return C.GoString(cstr)
+func (m *Manager) GetVersionAsString() string {
+	return m.backend.GetVersionAsString()
 }
 
 // GetVersionLongAsString returns the Version Number including Git commit of OZW
 // as a string.
-func GetVersionLongAsString() string {
-	cstr := C.manager_getVersionLongAsString()
-	defer C.free(unsafe.Pointer(cstr))
-	return C.GoString(cstr)
+func (m *Manager) GetVersionLongAsString() string {
+	return m.backend.GetVersionLongAsString()
 }
 
 // Version represents the OpenZWave library version as major and minor integers.
@@ -61,12 +332,10 @@ type Version struct {
 
 // GetVersion returns the Version Number as the Version Struct (Only Major/Minor
 // returned).
-func GetVersion() Version {
-	var cMajor C.uint16_t
-	var cMinor C.uint16_t
-	C.manager_getVersion(&cMajor, &cMinor)
+func (m *Manager) GetVersion() Version {
+	major, minor := m.backend.GetVersion()
 	return Version{
-		Major: int(cMajor),
-		Minor: int(cMinor),
+		Major: int(major),
+		Minor: int(minor),
 	}
 }
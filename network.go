@@ -1,38 +1,34 @@
 package goopenzwave
 
-// #include "gzw_manager.h"
-// #include <stdlib.h>
-import "C"
-
 // TestNetworkNode tests the network node.
 //
 // Sends a series of messages to a network node for testing network reliability.
-func TestNetworkNode(homeID uint32, nodeID uint8, count uint32) {
-	C.manager_testNetworkNode(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID), C.uint32_t(count))
+func (m *Manager) TestNetworkNode(homeID uint32, nodeID uint8, count uint32) {
+	m.backend.TestNetworkNode(homeID, nodeID, count)
 }
 
 // TestNetwork tests the network.
 //
 // Sends a series of messages to every node on the network for testing network
 // reliability.
-func TestNetwork(homeID uint32, count uint32) {
-	C.manager_testNetwork(cmanager, C.uint32_t(homeID), C.uint32_t(count))
+func (m *Manager) TestNetwork(homeID uint32, count uint32) {
+	m.backend.TestNetwork(homeID, count)
 }
 
 // HealNetworkNode heals a network node by requesting that the node rediscovers
 // their neighbors.
 //
 // Sends a ControllerCommand_RequestNodeNeighborUpdate to the node.
-func HealNetworkNode(homeID uint32, nodeID uint8, doRR bool) {
-	C.manager_healNetworkNode(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID), C.bool(doRR))
+func (m *Manager) HealNetworkNode(homeID uint32, nodeID uint8, doRR bool) {
+	m.backend.HealNetworkNode(homeID, nodeID, doRR)
 }
 
 // HealNetwork heals a network by requesting node's rediscover their neighbors.
 //
 // Sends a ControllerCommand_RequestNodeNeighborUpdate to every node. Can take a
 // while on larger networks.
-func HealNetwork(homeID uint32, doRR bool) {
-	C.manager_healNetwork(cmanager, C.uint32_t(homeID), C.bool(doRR))
+func (m *Manager) HealNetwork(homeID uint32, doRR bool) {
+	m.backend.HealNetwork(homeID, doRR)
 }
 
 // AddNode starts the Inclusion Process to add a Node to the Network. It will
@@ -40,8 +36,8 @@ func HealNetwork(homeID uint32, doRR bool) {
 //
 // The Status of the Node Inclusion is communicated via Notifications.
 // Specifically, you should monitor ControllerCommand Notifications.
-func AddNode(homeID uint32, doSecurity bool) bool {
-	return bool(C.manager_addNode(cmanager, C.uint32_t(homeID), C.bool(doSecurity)))
+func (m *Manager) AddNode(homeID uint32, doSecurity bool) bool {
+	return m.backend.AddNode(homeID, doSecurity)
 }
 
 // RemoveNode removes a Device from the Z-Wave Network. It will return true if
@@ -49,8 +45,8 @@ func AddNode(homeID uint32, doSecurity bool) bool {
 //
 // The Status of the Node Removal is communicated via Notifications.
 // Specifically, you should monitor ControllerCommand Notifications.
-func RemoveNode(homeID uint32) bool {
-	return bool(C.manager_removeNode(cmanager, C.uint32_t(homeID)))
+func (m *Manager) RemoveNode(homeID uint32) bool {
+	return m.backend.RemoveNode(homeID)
 }
 
 // RemoveFailedNode removes a Failed Device from the Z-Wave Network. It will
@@ -62,8 +58,8 @@ func RemoveNode(homeID uint32) bool {
 // the Node has Failed. The Status of the Node Removal is communicated via
 // Notifications. Specifically, you should monitor ControllerCommand
 // Notifications.
-func RemoveFailedNode(homeID uint32, nodeID uint8) bool {
-	return bool(C.manager_removeFailedNode(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) RemoveFailedNode(homeID uint32, nodeID uint8) bool {
+	return m.backend.RemoveFailedNode(homeID, nodeID)
 }
 
 // HasNodeFailed checks if the Controller Believes a Node has Failed. The result
@@ -75,23 +71,23 @@ func RemoveFailedNode(homeID uint32, nodeID uint8) bool {
 // Nodes, which might be different. The Results will be communicated via
 // Notifications. Specifically, you should monitor the ControllerCommand
 // notifications.
-func HasNodeFailed(homeID uint32, nodeID uint8) bool {
-	return bool(C.manager_hasNodeFailed(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) HasNodeFailed(homeID uint32, nodeID uint8) bool {
+	return m.backend.HasNodeFailed(homeID, nodeID)
 }
 
 // RequestNodeNeighborUpdate will ask a Node to update its Neighbor Tables. It
 // will return true if the command was sent to the controller successfully.
 //
 // This command will ask a Node to update its Neighbor Tables.
-func RequestNodeNeighborUpdate(homeID uint32, nodeID uint8) bool {
-	return bool(C.manager_requestNodeNeighborUpdate(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) RequestNodeNeighborUpdate(homeID uint32, nodeID uint8) bool {
+	return m.backend.RequestNodeNeighborUpdate(homeID, nodeID)
 }
 
 // AssignReturnRoute will ask a Node to update its update its Return Route to
 // the Controller. It will return true if the command was sent to the controller
 // successfully.
-func AssignReturnRoute(homeID uint32, nodeID uint8) bool {
-	return bool(C.manager_assignReturnRoute(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) AssignReturnRoute(homeID uint32, nodeID uint8) bool {
+	return m.backend.AssignReturnRoute(homeID, nodeID)
 }
 
 // DeleteAllReturnRoutes will ask a Node to delete all Return Routes. It will
@@ -99,16 +95,16 @@ func AssignReturnRoute(homeID uint32, nodeID uint8) bool {
 //
 // This command will ask a Node to delete all its return routes, and will
 // rediscover when needed.
-func DeleteAllReturnRoutes(homeID uint32, nodeID uint8) bool {
-	return bool(C.manager_deleteAllReturnRoutes(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) DeleteAllReturnRoutes(homeID uint32, nodeID uint8) bool {
+	return m.backend.DeleteAllReturnRoutes(homeID, nodeID)
 }
 
 // SendNodeInformation sends a NIF frame from the Controller to a Node. It will
 // return true if the command was sent to the controller successfully.
 //
 // This command send a NIF frame from the Controller to a Node.
-func SendNodeInformation(homeID uint32, nodeID uint8) bool {
-	return bool(C.manager_sendNodeInformation(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) SendNodeInformation(homeID uint32, nodeID uint8) bool {
+	return m.backend.SendNodeInformation(homeID, nodeID)
 }
 
 // CreateNewPrimary will create a new primary controller when old primary fails.
@@ -119,16 +115,16 @@ func SendNodeInformation(homeID uint32, nodeID uint8) bool {
 // Failed.
 //
 // Requires a SUC on the network to function.
-func CreateNewPrimary(homeID uint32) bool {
-	return bool(C.manager_createNewPrimary(cmanager, C.uint32_t(homeID)))
+func (m *Manager) CreateNewPrimary(homeID uint32) bool {
+	return m.backend.CreateNewPrimary(homeID)
 }
 
 // ReceiveConfiguration will receive network configuration information from
 // the primary controller. Requires secondary. This command prepares the
 // controller to recieve Network Configuration from a Secondary Controller. It
 // will return true if the command was sent to the controller successfully.
-func ReceiveConfiguration(homeID uint32) bool {
-	return bool(C.manager_receiveConfiguration(cmanager, C.uint32_t(homeID)))
+func (m *Manager) ReceiveConfiguration(homeID uint32) bool {
+	return m.backend.ReceiveConfiguration(homeID)
 }
 
 // ReplaceFailedNode will replace a failed device with another. If the node is
@@ -136,38 +132,38 @@ func ReceiveConfiguration(homeID uint32) bool {
 // will fail. You can check if a Node is in the Controllers Failed node list by
 // using the HasNodeFailed method. It will return true if the command was sent
 // to the controller successfully.
-func ReplaceFailedNode(homeID uint32, nodeID uint8) bool {
-	return bool(C.manager_replaceFailedNode(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) ReplaceFailedNode(homeID uint32, nodeID uint8) bool {
+	return m.backend.ReplaceFailedNode(homeID, nodeID)
 }
 
 // TransferPrimaryRole adds a new controller to the network and make it the
 // primary. The existing primary will become a secondary controller. It will
 // return true if the command was sent to the controller successfully.
-func TransferPrimaryRole(homeID uint32) bool {
-	return bool(C.manager_transferPrimaryRole(cmanager, C.uint32_t(homeID)))
+func (m *Manager) TransferPrimaryRole(homeID uint32) bool {
+	return m.backend.TransferPrimaryRole(homeID)
 }
 
 // RequestNetworkUpdate updates the controller with network information from the
 // SUC/SIS. It will return true if the command was sent to the controller
 // successfully.
-func RequestNetworkUpdate(homeID uint32, nodeID uint8) bool {
-	return bool(C.manager_requestNetworkUpdate(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) RequestNetworkUpdate(homeID uint32, nodeID uint8) bool {
+	return m.backend.RequestNetworkUpdate(homeID, nodeID)
 }
 
 // ReplicationSend sends information from primary to secondary. It will return
 // true if the command was sent to the controller successfully.
-func ReplicationSend(homeID uint32, nodeID uint8) bool {
-	return bool(C.manager_replicationSend(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) ReplicationSend(homeID uint32, nodeID uint8) bool {
+	return m.backend.ReplicationSend(homeID, nodeID)
 }
 
 // CreateButton create a handheld button id.  It will return true if the command
 // was sent to the controller successfully.
-func CreateButton(homeID uint32, nodeID uint8, buttonID uint8) bool {
-	return bool(C.manager_createButton(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID), C.uint8_t(buttonID)))
+func (m *Manager) CreateButton(homeID uint32, nodeID uint8, buttonID uint8) bool {
+	return m.backend.CreateButton(homeID, nodeID, buttonID)
 }
 
 // DeleteButton deletes a handheld button id. It will return true if the command
 // was sent to the controller successfully.
-func DeleteButton(homeID uint32, nodeID uint8, buttonID uint8) bool {
-	return bool(C.manager_deleteButton(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID), C.uint8_t(buttonID)))
+func (m *Manager) DeleteButton(homeID uint32, nodeID uint8, buttonID uint8) bool {
+	return m.backend.DeleteButton(homeID, nodeID, buttonID)
 }
@@ -1,10 +1,8 @@
 package goopenzwave
 
-// #include "gzw_manager.h"
-// #include <stdlib.h>
-import "C"
 import (
-	"unsafe"
+	"strings"
+	"time"
 )
 
 // RefreshNodeInfo triggers the fetching of fixed data about a node. Returns
@@ -17,8 +15,8 @@ import (
 // library. This can be especially useful for devices that were asleep when the
 // application was first run. This is the same as the query state starting from
 // the beginning.
-func RefreshNodeInfo(homeID uint32, nodeID uint8) bool {
-	return bool(C.manager_refreshNodeInfo(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) RefreshNodeInfo(homeID uint32, nodeID uint8) bool {
+	return m.backend.RefreshNodeInfo(homeID, nodeID)
 }
 
 // RequestNodeState triggers the fetching of dynamic value data for a node.
@@ -26,8 +24,8 @@ func RefreshNodeInfo(homeID uint32, nodeID uint8) bool {
 //
 // Causes the node's values to be requested from the Z-Wave network. This is the
 // same as the query state starting from the associations state.
-func RequestNodeState(homeID uint32, nodeID uint8) bool {
-	return bool(C.manager_requestNodeState(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) RequestNodeState(homeID uint32, nodeID uint8) bool {
+	return m.backend.RequestNodeState(homeID, nodeID)
 }
 
 // RequestNodeDynamic triggers the fetching of just the dynamic value data for a
@@ -35,86 +33,88 @@ func RequestNodeState(homeID uint32, nodeID uint8) bool {
 //
 // Causes the node's values to be requested from the Z-Wave network. This is the
 // same as the query state starting from the dynamic state.
-func RequestNodeDynamic(homeID uint32, nodeID uint8) bool {
-	return bool(C.manager_requestNodeDynamic(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) RequestNodeDynamic(homeID uint32, nodeID uint8) bool {
+	return m.backend.RequestNodeDynamic(homeID, nodeID)
 }
 
 // IsNodeListeningDevice returns true if the node is a listening device that
 // does not go to sleep.
-func IsNodeListeningDevice(homeID uint32, nodeID uint8) bool {
-	return bool(C.manager_isNodeListeningDevice(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) IsNodeListeningDevice(homeID uint32, nodeID uint8) bool {
+	return m.backend.IsNodeListeningDevice(homeID, nodeID)
 }
 
 // IsNodeFrequentListeningDevice returns true if the node is a frequent
 // listening device that goes to sleep but can be woken up by a beam. Useful to
 // determine node and controller consistency.
-func IsNodeFrequentListeningDevice(homeID uint32, nodeID uint8) bool {
-	return bool(C.manager_isNodeFrequentListeningDevice(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) IsNodeFrequentListeningDevice(homeID uint32, nodeID uint8) bool {
+	return m.backend.IsNodeFrequentListeningDevice(homeID, nodeID)
 }
 
 // IsNodeBeamingDevice returns true if the node is a beam capable device.
-func IsNodeBeamingDevice(homeID uint32, nodeID uint8) bool {
-	return bool(C.manager_isNodeBeamingDevice(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) IsNodeBeamingDevice(homeID uint32, nodeID uint8) bool {
+	return m.backend.IsNodeBeamingDevice(homeID, nodeID)
 }
 
 // IsNodeRoutingDevice returns true if the node is a routing device that passes
 // messages to other nodes.
-func IsNodeRoutingDevice(homeID uint32, nodeID uint8) bool {
-	return bool(C.manager_isNodeRoutingDevice(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) IsNodeRoutingDevice(homeID uint32, nodeID uint8) bool {
+	return m.backend.IsNodeRoutingDevice(homeID, nodeID)
 }
 
 // IsNodeSecurityDevice returns true if the node supports security features.
-func IsNodeSecurityDevice(homeID uint32, nodeID uint8) bool {
-	return bool(C.manager_isNodeSecurityDevice(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) IsNodeSecurityDevice(homeID uint32, nodeID uint8) bool {
+	return m.backend.IsNodeSecurityDevice(homeID, nodeID)
 }
 
 // GetNodeMaxBaudRate returns the maximum baud rate of a node's communications.
-func GetNodeMaxBaudRate(homeID uint32, nodeID uint8) uint32 {
-	return uint32(C.manager_getNodeMaxBaudRate(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) GetNodeMaxBaudRate(homeID uint32, nodeID uint8) uint32 {
+	return m.backend.GetNodeMaxBaudRate(homeID, nodeID)
 }
 
 // GetNodeVersion returns the version number of a node.
-func GetNodeVersion(homeID uint32, nodeID uint8) uint8 {
-	return uint8(C.manager_getNodeVersion(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) GetNodeVersion(homeID uint32, nodeID uint8) uint8 {
+	return m.backend.GetNodeVersion(homeID, nodeID)
 }
 
 // GetNodeSecurity returns the security byte of a node.
-func GetNodeSecurity(homeID uint32, nodeID uint8) uint8 {
-	return uint8(C.manager_getNodeSecurity(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) GetNodeSecurity(homeID uint32, nodeID uint8) uint8 {
+	return m.backend.GetNodeSecurity(homeID, nodeID)
 }
 
 // IsNodeZWavePlus returns true if this a ZWave+ Supported Node.
-func IsNodeZWavePlus(homeID uint32, nodeID uint8) bool {
-	return bool(C.manager_isNodeZWavePlus(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) IsNodeZWavePlus(homeID uint32, nodeID uint8) bool {
+	return m.backend.IsNodeZWavePlus(homeID, nodeID)
 }
 
 // GetNodeBasicType returns the basic type of a node.
-func GetNodeBasicType(homeID uint32, nodeID uint8) uint8 {
-	return uint8(C.manager_getNodeBasic(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) GetNodeBasicType(homeID uint32, nodeID uint8) uint8 {
+	return m.backend.GetNodeBasicType(homeID, nodeID)
 }
 
 // GetNodeGenericType returns the generic type of a node.
-func GetNodeGenericType(homeID uint32, nodeID uint8) uint8 {
-	return uint8(C.manager_getNodeGeneric(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) GetNodeGenericType(homeID uint32, nodeID uint8) uint8 {
+	return m.backend.GetNodeGenericType(homeID, nodeID)
 }
 
 // GetNodeSpecificType returns the specific type of a node.
-func GetNodeSpecificType(homeID uint32, nodeID uint8) uint8 {
-	return uint8(C.manager_getNodeSpecific(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) GetNodeSpecificType(homeID uint32, nodeID uint8) uint8 {
+	return m.backend.GetNodeSpecificType(homeID, nodeID)
 }
 
 // GetNodeType returns a human-readable label describing the node.
 //
 // The label is taken from the Z-Wave specific, generic or basic type, depending
 // on which of those values are specified by the node.
-func GetNodeType(homeID uint32, nodeID uint8) string {
-	cstr := C.manager_getNodeType(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID))
-	defer C.free(unsafe.Pointer(cstr))
-	return C.GoString(cstr)
+func (m *Manager) GetNodeType(homeID uint32, nodeID uint8) string {
+	return m.backend.GetNodeType(homeID, nodeID)
 }
 
-// GetNodeNeighbours returns the bitmap of this node's neighbors.
-//TODO func GetNodeNeighbours(homeID uint32, nodeID uint8) (uint32, uint8 nodeNeighbors)
+// GetNodeNeighbors returns the IDs of the nodes which the node can reach
+// directly, taken from the node's neighbor bitmap. It returns nil if the node
+// has no neighbors or the neighbors are not yet known.
+func (m *Manager) GetNodeNeighbors(homeID uint32, nodeID uint8) []uint8 {
+	return m.backend.GetNodeNeighbors(homeID, nodeID)
+}
 
 // GetNodeManufacturerName returns the manufacturer name of a device.
 //
@@ -125,10 +125,8 @@ func GetNodeType(homeID uint32, nodeID uint8) string {
 // the command class, so to enable the user to manually set the name, it is
 // stored with the node data and accessed via this method rather than being
 // reported via a command class Value object.
-func GetNodeManufacturerName(homeID uint32, nodeID uint8) string {
-	cstr := C.manager_getNodeManufacturerName(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID))
-	defer C.free(unsafe.Pointer(cstr))
-	return C.GoString(cstr)
+func (m *Manager) GetNodeManufacturerName(homeID uint32, nodeID uint8) string {
+	return m.backend.GetNodeManufacturerName(homeID, nodeID)
 }
 
 // GetNodeProductName returns the product name of a device.
@@ -140,10 +138,8 @@ func GetNodeManufacturerName(homeID uint32, nodeID uint8) string {
 // the command class, so to enable the user to manually set the name, it is
 // stored with the node data and accessed via this method rather than being
 // reported via a command class Value object.
-func GetNodeProductName(homeID uint32, nodeID uint8) string {
-	cstr := C.manager_getNodeProductName(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID))
-	defer C.free(unsafe.Pointer(cstr))
-	return C.GoString(cstr)
+func (m *Manager) GetNodeProductName(homeID uint32, nodeID uint8) string {
+	return m.backend.GetNodeProductName(homeID, nodeID)
 }
 
 // GetNodeName returns the name of a node.
@@ -154,10 +150,8 @@ func GetNodeProductName(homeID uint32, nodeID uint8) string {
 // data, and provides access through this method and SetNodeName, rather than
 // reporting it via a command class Value object. The maximum length of a node
 // name is 16 characters.
-func GetNodeName(homeID uint32, nodeID uint8) string {
-	cstr := C.manager_getNodeName(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID))
-	defer C.free(unsafe.Pointer(cstr))
-	return C.GoString(cstr)
+func (m *Manager) GetNodeName(homeID uint32, nodeID uint8) string {
+	return m.backend.GetNodeName(homeID, nodeID)
 }
 
 // GetNodeLocation returns the location of a node.
@@ -167,10 +161,8 @@ func GetNodeName(homeID uint32, nodeID uint8) string {
 // node can always report its location, OpenZWave stores it with the node data,
 // and provides access through this method and SetNodeLocation, rather than
 // reporting it via a command class Value object.
-func GetNodeLocation(homeID uint32, nodeID uint8) string {
-	cstr := C.manager_getNodeLocation(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID))
-	defer C.free(unsafe.Pointer(cstr))
-	return C.GoString(cstr)
+func (m *Manager) GetNodeLocation(homeID uint32, nodeID uint8) string {
+	return m.backend.GetNodeLocation(homeID, nodeID)
 }
 
 // GetNodeManufacturerID returns the manufacturer ID of a device.
@@ -182,10 +174,8 @@ func GetNodeLocation(homeID uint32, nodeID uint8) string {
 // manufacturer ID is still stored with the node data (rather than being
 // reported via a command class Value object) to retain a consistent approach
 // with the other manufacturer specific data.
-func GetNodeManufacturerID(homeID uint32, nodeID uint8) string {
-	cstr := C.manager_getNodeManufacturerId(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID))
-	defer C.free(unsafe.Pointer(cstr))
-	return C.GoString(cstr)
+func (m *Manager) GetNodeManufacturerID(homeID uint32, nodeID uint8) string {
+	return m.backend.GetNodeManufacturerID(homeID, nodeID)
 }
 
 // GetNodeProductType returns the product type of a device.
@@ -197,10 +187,8 @@ func GetNodeManufacturerID(homeID uint32, nodeID uint8) string {
 // type is still stored with the node data (rather than being reported via a
 // command class Value object) to retain a consistent approach with the other
 // manufacturer specific data.
-func GetNodeProductType(homeID uint32, nodeID uint8) string {
-	cstr := C.manager_getNodeProductType(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID))
-	defer C.free(unsafe.Pointer(cstr))
-	return C.GoString(cstr)
+func (m *Manager) GetNodeProductType(homeID uint32, nodeID uint8) string {
+	return m.backend.GetNodeProductType(homeID, nodeID)
 }
 
 // GetNodeProductID returns the product ID of a device.
@@ -212,10 +200,8 @@ func GetNodeProductType(homeID uint32, nodeID uint8) string {
 // is still stored with the node data (rather than being reported via a command
 // class Value object) to retain a consistent approach with the other
 // manufacturer specific data.
-func GetNodeProductID(homeID uint32, nodeID uint8) string {
-	cstr := C.manager_getNodeProductId(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID))
-	defer C.free(unsafe.Pointer(cstr))
-	return C.GoString(cstr)
+func (m *Manager) GetNodeProductID(homeID uint32, nodeID uint8) string {
+	return m.backend.GetNodeProductID(homeID, nodeID)
 }
 
 // SetNodeManufacturerName sets the manufacturer name of a device.
@@ -227,10 +213,8 @@ func GetNodeProductID(homeID uint32, nodeID uint8) string {
 // the command class, so to enable the user to manually set the name, it is
 // stored with the node data and accessed via this method rather than being
 // reported via a command class Value object.
-func SetNodeManufacturerName(homeID uint32, nodeID uint8, manufacturerName string) {
-	cstr := C.CString(manufacturerName)
-	C.manager_setNodeManufacturerName(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID), cstr)
-	defer C.free(unsafe.Pointer(cstr))
+func (m *Manager) SetNodeManufacturerName(homeID uint32, nodeID uint8, manufacturerName string) {
+	m.backend.SetNodeManufacturerName(homeID, nodeID, manufacturerName)
 }
 
 // SetNodeProductName sets the product name of a device.
@@ -242,10 +226,8 @@ func SetNodeManufacturerName(homeID uint32, nodeID uint8, manufacturerName strin
 // the command class, so to enable the user to manually set the name, it is
 // stored with the node data and accessed via this method rather than being
 // reported via a command class Value object.
-func SetNodeProductName(homeID uint32, nodeID uint8, productName string) {
-	cstr := C.CString(productName)
-	C.manager_setNodeProductName(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID), cstr)
-	defer C.free(unsafe.Pointer(cstr))
+func (m *Manager) SetNodeProductName(homeID uint32, nodeID uint8, productName string) {
+	m.backend.SetNodeProductName(homeID, nodeID, productName)
 }
 
 // SetNodeName sets the name of a node.
@@ -257,10 +239,8 @@ func SetNodeProductName(homeID uint32, nodeID uint8, productName string) {
 // reporting it via a command class Value object. If the device does support the
 // Node Naming command class, the new name will be sent to the node. The maximum
 // length of a node name is 16 characters.
-func SetNodeName(homeID uint32, nodeID uint8, nodeName string) {
-	cstr := C.CString(nodeName)
-	C.manager_setNodeName(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID), cstr)
-	defer C.free(unsafe.Pointer(cstr))
+func (m *Manager) SetNodeName(homeID uint32, nodeID uint8, nodeName string) {
+	m.backend.SetNodeName(homeID, nodeID, nodeName)
 }
 
 // SetNodeLocation sets the location of a node.
@@ -271,10 +251,8 @@ func SetNodeName(homeID uint32, nodeID uint8, nodeName string) {
 // and provides access through this method and GetNodeLocation, rather than
 // reporting it via a command class Value object. If the device does support the
 // Node Naming command class, the new location will be sent to the node.
-func SetNodeLocation(homeID uint32, nodeID uint8, location string) {
-	cstr := C.CString(location)
-	C.manager_setNodeLocation(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID), cstr)
-	defer C.free(unsafe.Pointer(cstr))
+func (m *Manager) SetNodeLocation(homeID uint32, nodeID uint8, location string) {
+	m.backend.SetNodeLocation(homeID, nodeID, location)
 }
 
 // SetNodeOn turns a node on.
@@ -284,8 +262,8 @@ func SetNodeLocation(homeID uint32, nodeID uint8, location string) {
 // to 255, and will generate a ValueChanged notification from that class. This
 // command will turn on the device at its last known level, if supported by the
 // device, otherwise it will turn it on at 100%.
-func SetNodeOn(homeID uint32, nodeID uint8) {
-	C.manager_setNodeOn(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID))
+func (m *Manager) SetNodeOn(homeID uint32, nodeID uint8) {
+	m.backend.SetNodeOn(homeID, nodeID)
 }
 
 // SetNodeOff turns a node off.
@@ -293,8 +271,8 @@ func SetNodeOn(homeID uint32, nodeID uint8) {
 // This is a helper method to simplify basic control of a node. It is the
 // equivalent of changing the level reported by the node's Basic command class
 // to zero, and will generate a ValueChanged notification from that class.
-func SetNodeOff(homeID uint32, nodeID uint8) {
-	C.manager_setNodeOff(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID))
+func (m *Manager) SetNodeOff(homeID uint32, nodeID uint8) {
+	m.backend.SetNodeOff(homeID, nodeID)
 }
 
 // SetNodeLevel sets the basic level of a node.
@@ -302,85 +280,71 @@ func SetNodeOff(homeID uint32, nodeID uint8) {
 // This is a helper method to simplify basic control of a node. It is the
 // equivalent of changing the value reported by the node's Basic command class
 // and will generate a ValueChanged notification from that class.
-func SetNodeLevel(homeID uint32, nodeID uint8, level uint8) {
-	C.manager_setNodeLevel(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID), C.uint8_t(level))
+func (m *Manager) SetNodeLevel(homeID uint32, nodeID uint8, level uint8) {
+	m.backend.SetNodeLevel(homeID, nodeID, level)
 }
 
 // IsNodeInfoReceived returns whether the node information has been received.
-func IsNodeInfoReceived(homeID uint32, nodeID uint8) bool {
-	return bool(C.manager_isNodeInfoReceived(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) IsNodeInfoReceived(homeID uint32, nodeID uint8) bool {
+	return m.backend.IsNodeInfoReceived(homeID, nodeID)
 }
 
 // GetNodeClassInformation returns true if the node has the defined class
 // available or not, and the class name and version if available.
-func GetNodeClassInformation(homeID uint32, nodeID uint8, commandClassID uint8) (bool, string, uint8) {
-	var cClassName *C.char
-	var cClassVersion C.uint8_t
-	result := bool(C.manager_getNodeClassInformation(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID), C.uint8_t(commandClassID), &cClassName, &cClassVersion))
-	goClassName := C.GoString(cClassName)
-	goClassVersion := uint8(cClassVersion)
-	C.free(unsafe.Pointer(cClassName))
-	return result, goClassName, goClassVersion
+func (m *Manager) GetNodeClassInformation(homeID uint32, nodeID uint8, commandClassID uint8) (bool, string, uint8) {
+	return m.backend.GetNodeClassInformation(homeID, nodeID, commandClassID)
 }
 
 // IsNodeAwake returns true if the node is awake, otherwise false if it is
 // asleep.
-func IsNodeAwake(homeID uint32, nodeID uint8) bool {
-	return bool(C.manager_isNodeAwake(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) IsNodeAwake(homeID uint32, nodeID uint8) bool {
+	return m.backend.IsNodeAwake(homeID, nodeID)
 }
 
 // IsNodeFailed returns true if the node is working, otherwise false if it has
 // failed.
-func IsNodeFailed(homeID uint32, nodeID uint8) bool {
-	return bool(C.manager_isNodeFailed(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) IsNodeFailed(homeID uint32, nodeID uint8) bool {
+	return m.backend.IsNodeFailed(homeID, nodeID)
 }
 
 // GetNodeQueryStage returns the node's query stage as a string.
-func GetNodeQueryStage(homeID uint32, nodeID uint8) string {
-	cstr := C.manager_getNodeQueryStage(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID))
-	defer C.free(unsafe.Pointer(cstr))
-	return C.GoString(cstr)
+func (m *Manager) GetNodeQueryStage(homeID uint32, nodeID uint8) string {
+	return m.backend.GetNodeQueryStage(homeID, nodeID)
 }
 
 // GetNodeDeviceType returns the node device type as reported in the Z-Wave+
 // Info report.
-func GetNodeDeviceType(homeID uint32, nodeID uint8) uint16 {
-	return uint16(C.manager_getNodeDeviceType(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) GetNodeDeviceType(homeID uint32, nodeID uint8) uint16 {
+	return m.backend.GetNodeDeviceType(homeID, nodeID)
 }
 
 // GetNodeDeviceTypeString returns a string of the node device type as reported
 // in the Z-Wave+ Info report.
-func GetNodeDeviceTypeString(homeID uint32, nodeID uint8) string {
-	cstr := C.manager_getNodeDeviceTypeString(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID))
-	defer C.free(unsafe.Pointer(cstr))
-	return C.GoString(cstr)
+func (m *Manager) GetNodeDeviceTypeString(homeID uint32, nodeID uint8) string {
+	return m.backend.GetNodeDeviceTypeString(homeID, nodeID)
 }
 
 // GetNodeRole returns the node role as reported in the Z-Wave+ Info report.
-func GetNodeRole(homeID uint32, nodeID uint8) uint8 {
-	return uint8(C.manager_getNodeRole(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) GetNodeRole(homeID uint32, nodeID uint8) uint8 {
+	return m.backend.GetNodeRole(homeID, nodeID)
 }
 
 // GetNodeRoleString returns a string of the node role as reported in the
 // Z-Wave+ Info report.
-func GetNodeRoleString(homeID uint32, nodeID uint8) string {
-	cstr := C.manager_getNodeRoleString(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID))
-	defer C.free(unsafe.Pointer(cstr))
-	return C.GoString(cstr)
+func (m *Manager) GetNodeRoleString(homeID uint32, nodeID uint8) string {
+	return m.backend.GetNodeRoleString(homeID, nodeID)
 }
 
 // GetNodePlusType returns the node PlusType as reported in the Z-Wave+ Info
 // report.
-func GetNodePlusType(homeID uint32, nodeID uint8) uint8 {
-	return uint8(C.manager_getNodePlusType(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID)))
+func (m *Manager) GetNodePlusType(homeID uint32, nodeID uint8) uint8 {
+	return m.backend.GetNodePlusType(homeID, nodeID)
 }
 
 // GetNodePlusTypeString returns a string of the node PlusType as reported in
 // the Z-Wave+ Info report.
-func GetNodePlusTypeString(homeID uint32, nodeID uint8) string {
-	cstr := C.manager_getNodePlusTypeString(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID))
-	defer C.free(unsafe.Pointer(cstr))
-	return C.GoString(cstr)
+func (m *Manager) GetNodePlusTypeString(homeID uint32, nodeID uint8) string {
+	return m.backend.GetNodePlusTypeString(homeID, nodeID)
 }
 
 // SetNodeConfigParam sets the value of a configurable parameter in a device.
@@ -391,18 +355,18 @@ func GetNodePlusTypeString(homeID uint32, nodeID uint8) string {
 // network, but can usually be found in the device's user manual. This method
 // returns immediately, without waiting for confirmation from the device that
 // the change has been made.
-func SetNodeConfigParam(homeID uint32, nodeID uint8, param uint8, value int32, size uint8) bool {
-	return bool(C.manager_setConfigParam(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID), C.uint8_t(param), C.int32_t(value), C.uint8_t(size)))
+func (m *Manager) SetNodeConfigParam(homeID uint32, nodeID uint8, param uint8, value int32, size uint8) bool {
+	return m.backend.SetNodeConfigParam(homeID, nodeID, param, value, size)
 }
 
 // SwitchAllOn Switch all devices on. All devices that support the SwitchAll command class will be turned on.
-func SwitchAllOn(homeID uint32) {
-	C.manager_switchAllOn(cmanager, C.uint32_t(homeID))
+func (m *Manager) SwitchAllOn(homeID uint32) {
+	m.backend.SwitchAllOn(homeID)
 }
 
 // SwitchAllOff Switch all devices off. All devices that support the SwitchAll command class will be turned off.
-func SwitchAllOff(homeID uint32) {
-	C.manager_switchAllOff(cmanager, C.uint32_t(homeID))
+func (m *Manager) SwitchAllOff(homeID uint32) {
+	m.backend.SwitchAllOff(homeID)
 }
 
 // RequestNodeConfigParam requests the value of a configurable parameter from a
@@ -418,15 +382,62 @@ func SwitchAllOff(homeID uint32) {
 // the callback will have an index set the same as _param and a command class
 // set to the same value as returned by a call to
 // Configuration::StaticGetCommandClassId.
-func RequestNodeConfigParam(homeID uint32, nodeID uint8, param uint8) {
-	C.manager_requestConfigParam(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID), C.uint8_t(param))
+func (m *Manager) RequestNodeConfigParam(homeID uint32, nodeID uint8, param uint8) {
+	m.backend.RequestNodeConfigParam(homeID, nodeID, param)
 }
 
 // RequestNodeAllConfigParam requests the values of all known configurable
 // parameters from a device.
-func RequestNodeAllConfigParam(homeID uint32, nodeID uint8) {
-	C.manager_requestAllConfigParams(cmanager, C.uint32_t(homeID), C.uint8_t(nodeID))
+func (m *Manager) RequestNodeAllConfigParam(homeID uint32, nodeID uint8) {
+	m.backend.RequestNodeAllConfigParam(homeID, nodeID)
+}
+
+// NodeStatistics contains the statistics of the messages exchanged with a node,
+// as kept by OpenZWave.
+type NodeStatistics struct {
+	SentCount           uint32        // Number of messages sent to the node.
+	SentFailed          uint32        // Number of messages which failed to be sent.
+	Retries             uint32        // Number of message retries.
+	ReceivedCount       uint32        // Number of messages received from the node.
+	ReceivedDuplicates  uint32        // Number of duplicated messages received.
+	ReceivedUnsolicited uint32        // Number of messages received unsolicited.
+	LastSent            time.Time     // When the last message was sent, or zero.
+	LastReceived        time.Time     // When the last message was received, or zero.
+	LastRequestRTT      time.Duration // Last message request round trip time.
+	AverageRequestRTT   time.Duration // Average message request round trip time.
+	LastResponseRTT     time.Duration // Last message response round trip time.
+	AverageResponseRTT  time.Duration // Average message response round trip time.
+	Quality             uint8         // Node quality measure.
+	LastReceivedMessage []byte        // The last message received from the node, from its type byte to its checksum.
+
+	// CommandClasses holds the message counts for each command class.
+	CommandClasses []CommandClassStatistics
+}
+
+// CommandClassStatistics contains the number of messages exchanged with a node
+// for one of its command classes.
+type CommandClassStatistics struct {
+	CommandClassID uint8
+	SentCount      uint32
+	ReceivedCount  uint32
+}
+
+// statisticsTimeLayout is the layout of the timestamps in the OpenZWave node
+// statistics.
+const statisticsTimeLayout = "2006-01-02 15:04:05:000"
+
+// parseStatisticsTime parses an OpenZWave node statistics timestamp, which is
+// in local time. It returns the zero time if the timestamp is empty or invalid.
+func parseStatisticsTime(s string) time.Time {
+	t, err := time.ParseInLocation(statisticsTimeLayout, strings.TrimSpace(s), time.Local)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// GetNodeStatistics returns the current statistics of the messages exchanged
+// with the node. The statistics are all zero if there is no such node.
+func (m *Manager) GetNodeStatistics(homeID uint32, nodeID uint8) NodeStatistics {
+	return m.backend.GetNodeStatistics(homeID, nodeID)
 }
-
-// GetNodeStatistics Retrieve statistics per node.
-//TODO func GetNodeStatistics(...) ...
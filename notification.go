@@ -1,8 +1,5 @@
 package goopenzwave
 
-// #include "gzw_notification.h"
-// #include <stdlib.h>
-import "C"
 import (
 	"fmt"
 )
@@ -41,6 +38,10 @@ const (
 	NotificationTypeDriverRemoved
 	NotificationTypeControllerCommand
 	NotificationTypeNodeReset
+
+	// NotificationTypeUnknown is the type of a Notification whose type is
+	// not known to this package, e.g. one added by a newer OpenZWave.
+	NotificationTypeUnknown NotificationType = -1
 )
 
 func (nt NotificationType) String() string {
@@ -120,6 +121,10 @@ const (
 	NotificationCodeSleep                               // C.notification_code_sleep
 	NotificationCodeDead                                // C.notification_code_dead
 	NotificationCodeAlive                               // C.notification_code_alive
+
+	// NotificationCodeUnknown is a notification code not known to this
+	// package.
+	NotificationCodeUnknown NotificationCode = -1
 )
 
 func (nc NotificationCode) String() string {
@@ -142,170 +147,139 @@ func (nc NotificationCode) String() string {
 	return "UNKNOWN"
 }
 
-// Notification is a container for the C++ OpenZWave library Notification class.
-type Notification struct {
-	Type         NotificationType
-	HomeID       uint32
-	NodeID       uint8
-	ValueID      *ValueID
-	GroupIDX     *uint8
-	Event        *uint8
-	ButtonID     *uint8
-	SceneID      *uint8
-	Notification *NotificationCode
-}
-
-// buildNotification builds a new Notification filled with the relevant
-// information from the OpenZWave::Notification as received from the OpenZWave
-// library.
-func buildNotification(n C.notification_t) *Notification {
-	notification := &Notification{
-		HomeID: uint32(C.notification_getHomeId(n)),
-		NodeID: uint8(C.notification_getNodeId(n)),
-	}
-
-	switch C.notification_getType(n) {
-	case C.notification_type_valueAdded:
-		notification.Type = NotificationTypeValueAdded
-	case C.notification_type_valueRemoved:
-		notification.Type = NotificationTypeValueRemoved
-	case C.notification_type_valueChanged:
-		notification.Type = NotificationTypeValueChanged
-	case C.notification_type_valueRefreshed:
-		notification.Type = NotificationTypeValueRefreshed
-	case C.notification_type_group:
-		notification.Type = NotificationTypeGroup
-	case C.notification_type_nodeNew:
-		notification.Type = NotificationTypeNodeNew
-	case C.notification_type_nodeAdded:
-		notification.Type = NotificationTypeNodeAdded
-	case C.notification_type_nodeRemoved:
-		notification.Type = NotificationTypeNodeRemoved
-	case C.notification_type_nodeProtocolInfo:
-		notification.Type = NotificationTypeNodeProtocolInfo
-	case C.notification_type_nodeNaming:
-		notification.Type = NotificationTypeNodeNaming
-	case C.notification_type_nodeEvent:
-		notification.Type = NotificationTypeNodeEvent
-	case C.notification_type_pollingDisabled:
-		notification.Type = NotificationTypePollingDisabled
-	case C.notification_type_pollingEnabled:
-		notification.Type = NotificationTypePollingEnabled
-	case C.notification_type_sceneEvent:
-		notification.Type = NotificationTypeSceneEvent
-	case C.notification_type_createButton:
-		notification.Type = NotificationTypeCreateButton
-	case C.notification_type_deleteButton:
-		notification.Type = NotificationTypeDeleteButton
-	case C.notification_type_buttonOn:
-		notification.Type = NotificationTypeButtonOn
-	case C.notification_type_buttonOff:
-		notification.Type = NotificationTypeButtonOff
-	case C.notification_type_driverReady:
-		notification.Type = NotificationTypeDriverReady
-	case C.notification_type_driverFailed:
-		notification.Type = NotificationTypeDriverFailed
-	case C.notification_type_driverReset:
-		notification.Type = NotificationTypeDriverReset
-	case C.notification_type_essentialNodeQueriesComplete:
-		notification.Type = NotificationTypeEssentialNodeQueriesComplete
-	case C.notification_type_nodeQueriesComplete:
-		notification.Type = NotificationTypeNodeQueriesComplete
-	case C.notification_type_awakeNodesQueried:
-		notification.Type = NotificationTypeAwakeNodesQueried
-	case C.notification_type_allNodesQueriedSomeDead:
-		notification.Type = NotificationTypeAllNodesQueriedSomeDead
-	case C.notification_type_allNodesQueried:
-		notification.Type = NotificationTypeAllNodesQueried
-	case C.notification_type_notification:
-		notification.Type = NotificationTypeNotification
-	case C.notification_type_driverRemoved:
-		notification.Type = NotificationTypeDriverRemoved
-	case C.notification_type_controllerCommand:
-		notification.Type = NotificationTypeControllerCommand
-	case C.notification_type_nodeReset:
-		notification.Type = NotificationTypeNodeReset
-	}
-
-	switch notification.Type {
-	case NotificationTypeValueAdded, NotificationTypeValueRemoved, NotificationTypeValueChanged, NotificationTypeValueRefreshed:
-		notification.ValueID = buildValueID(C.notification_getValueId(n))
-
-	case NotificationTypeGroup:
-		if notification.GroupIDX == nil {
-			notification.GroupIDX = new(uint8)
-		}
-		*(notification.GroupIDX) = uint8(C.notification_getGroupIdx(n))
+// ControllerState defines a type for the state of a controller command, as
+// reported by ControllerCommand notifications.
+type ControllerState int
 
-	case NotificationTypeNodeNew, NotificationTypeNodeAdded, NotificationTypeNodeRemoved, NotificationTypeNodeProtocolInfo, NotificationTypeNodeNaming:
-		// No notification info.
-
-	case NotificationTypeNodeEvent:
-		if notification.Event == nil {
-			notification.Event = new(uint8)
-		}
-		*(notification.Event) = uint8(C.notification_getEvent(n))
-
-	case NotificationTypePollingDisabled, NotificationTypePollingEnabled:
-		// No notification info.
-
-	case NotificationTypeSceneEvent:
-		if notification.SceneID == nil {
-			notification.SceneID = new(uint8)
-		}
-		*(notification.SceneID) = uint8(C.notification_getSceneId(n))
+const (
+	ControllerStateNormal     ControllerState = iota // No command in progress.
+	ControllerStateStarting                          // The command is starting.
+	ControllerStateCancel                            // The command was cancelled.
+	ControllerStateError                             // Command invocation had error(s) and was aborted.
+	ControllerStateWaiting                           // Controller is waiting for a user action.
+	ControllerStateSleeping                          // Controller command is on a sleep queue wait for device.
+	ControllerStateInProgress                        // The controller is communicating with the other device to carry out the command.
+	ControllerStateCompleted                         // The command has completed successfully.
+	ControllerStateFailed                            // The command has failed.
+	ControllerStateNodeOK                            // Used only with HasNodeFailed to indicate that the controller thinks the node is OK.
+	ControllerStateNodeFailed                        // Used only with HasNodeFailed to indicate that the controller thinks the node has failed.
 
-	case NotificationTypeCreateButton, NotificationTypeDeleteButton, NotificationTypeButtonOn, NotificationTypeButtonOff:
-		if notification.ButtonID == nil {
-			notification.ButtonID = new(uint8)
-		}
-		*(notification.ButtonID) = uint8(C.notification_getButtonId(n))
+	// ControllerStateUnknown is a controller state not known to this
+	// package. The raw state is still available from Notification.Event.
+	ControllerStateUnknown ControllerState = -1
+)
 
-	case NotificationTypeDriverReady, NotificationTypeDriverFailed, NotificationTypeDriverReset:
-		// No notification info.
+func (cs ControllerState) String() string {
+	switch cs {
+	case ControllerStateNormal:
+		return "Normal"
+	case ControllerStateStarting:
+		return "Starting"
+	case ControllerStateCancel:
+		return "Cancel"
+	case ControllerStateError:
+		return "Error"
+	case ControllerStateWaiting:
+		return "Waiting"
+	case ControllerStateSleeping:
+		return "Sleeping"
+	case ControllerStateInProgress:
+		return "InProgress"
+	case ControllerStateCompleted:
+		return "Completed"
+	case ControllerStateFailed:
+		return "Failed"
+	case ControllerStateNodeOK:
+		return "NodeOK"
+	case ControllerStateNodeFailed:
+		return "NodeFailed"
+	}
+	return "UNKNOWN"
+}
 
-	case NotificationTypeEssentialNodeQueriesComplete, NotificationTypeNodeQueriesComplete, NotificationTypeAwakeNodesQueried, NotificationTypeAllNodesQueriedSomeDead, NotificationTypeAllNodesQueried:
-		// No notification info.
+// Done returns true if the state is the final state of a controller command.
+func (cs ControllerState) Done() bool {
+	switch cs {
+	case ControllerStateCancel, ControllerStateError, ControllerStateCompleted, ControllerStateFailed, ControllerStateNodeOK, ControllerStateNodeFailed:
+		return true
+	}
+	return false
+}
 
-	case NotificationTypeNotification:
-		if notification.Notification == nil {
-			notification.Notification = new(NotificationCode)
-		}
-		switch C.notification_getNotification(n) {
-		case C.notification_code_msgComplete:
-			*notification.Notification = NotificationCodeMsgComplete
-		case C.notification_code_timeout:
-			*notification.Notification = NotificationCodeTimeout
-		case C.notification_code_noOperation:
-			*notification.Notification = NotificationCodeNoOperation
-		case C.notification_code_awake:
-			*notification.Notification = NotificationCodeAwake
-		case C.notification_code_sleep:
-			*notification.Notification = NotificationCodeSleep
-		case C.notification_code_dead:
-			*notification.Notification = NotificationCodeDead
-		case C.notification_code_alive:
-			*notification.Notification = NotificationCodeAlive
-		}
+// ControllerError defines a type for the reason a controller command failed,
+// as reported by ControllerCommand notifications.
+type ControllerError int
 
-	case NotificationTypeDriverRemoved:
-		// No notification info.
+const (
+	ControllerErrorNone           ControllerError = iota
+	ControllerErrorButtonNotFound                 // Button.
+	ControllerErrorNodeNotFound                   // Button.
+	ControllerErrorNotBridge                      // Button.
+	ControllerErrorNotSUC                         // CreateNewPrimary.
+	ControllerErrorNotSecondary                   // CreateNewPrimary.
+	ControllerErrorNotPrimary                     // RemoveFailedNode, AddNodeToNetwork.
+	ControllerErrorIsPrimary                      // ReceiveConfiguration.
+	ControllerErrorNotFound                       // RemoveFailedNode.
+	ControllerErrorBusy                           // RemoveFailedNode, RequestNetworkUpdate.
+	ControllerErrorFailed                         // RemoveFailedNode, RequestNetworkUpdate.
+	ControllerErrorDisabled                       // RequestNetworkUpdate error.
+	ControllerErrorOverflow                       // RequestNetworkUpdate error.
 
-	case NotificationTypeControllerCommand:
-		if notification.Event == nil {
-			notification.Event = new(uint8)
-		}
-		*(notification.Event) = uint8(C.notification_getEvent(n))
-		if notification.Notification == nil {
-			notification.Notification = new(NotificationCode)
-		}
-		*(notification.Notification) = NotificationCode(C.notification_getNotification(n))
+	// ControllerErrorUnknown is a controller error not known to this
+	// package.
+	ControllerErrorUnknown ControllerError = -1
+)
 
-	case NotificationTypeNodeReset:
-		// No notification info.
+func (ce ControllerError) String() string {
+	switch ce {
+	case ControllerErrorNone:
+		return "None"
+	case ControllerErrorButtonNotFound:
+		return "ButtonNotFound"
+	case ControllerErrorNodeNotFound:
+		return "NodeNotFound"
+	case ControllerErrorNotBridge:
+		return "NotBridge"
+	case ControllerErrorNotSUC:
+		return "NotSUC"
+	case ControllerErrorNotSecondary:
+		return "NotSecondary"
+	case ControllerErrorNotPrimary:
+		return "NotPrimary"
+	case ControllerErrorIsPrimary:
+		return "IsPrimary"
+	case ControllerErrorNotFound:
+		return "NotFound"
+	case ControllerErrorBusy:
+		return "Busy"
+	case ControllerErrorFailed:
+		return "Failed"
+	case ControllerErrorDisabled:
+		return "Disabled"
+	case ControllerErrorOverflow:
+		return "Overflow"
 	}
+	return "UNKNOWN"
+}
 
-	return notification
+// Notification is a container for the C++ OpenZWave library Notification class.
+//
+// For ControllerCommand notifications the ControllerState and ControllerError
+// fields are set. Event is also set to the raw controller state for
+// compatibility, but Notification is not, as the controller error is not a
+// NotificationCode.
+type Notification struct {
+	Type            NotificationType
+	HomeID          uint32
+	NodeID          uint8
+	ValueID         *ValueID
+	GroupIDX        *uint8
+	Event           *uint8
+	ButtonID        *uint8
+	SceneID         *uint8
+	Notification    *NotificationCode
+	ControllerState *ControllerState
+	ControllerError *ControllerError
 }
 
 func (n *Notification) String() string {
@@ -316,7 +290,7 @@ func (n *Notification) String() string {
 	if n.GroupIDX != nil {
 		pointed = append(pointed, fmt.Sprintf("GroupIDX: %d", *(n.GroupIDX)))
 	}
-	if n.Event != nil {
+	if n.Event != nil && n.ControllerState == nil {
 		pointed = append(pointed, fmt.Sprintf("Event: %d", *(n.Event)))
 	}
 	if n.ButtonID != nil {
@@ -328,6 +302,12 @@ func (n *Notification) String() string {
 	if n.Notification != nil {
 		pointed = append(pointed, fmt.Sprintf("Notification: %s", *(n.Notification)))
 	}
+	if n.ControllerState != nil {
+		pointed = append(pointed, fmt.Sprintf("ControllerState: %s", *(n.ControllerState)))
+	}
+	if n.ControllerError != nil {
+		pointed = append(pointed, fmt.Sprintf("ControllerError: %s", *(n.ControllerError)))
+	}
 	output := fmt.Sprintf("<%s, HomeID: 0x%x, NodeID: %d", n.Type, n.HomeID, n.NodeID)
 	for i := range pointed {
 		output = fmt.Sprintf("%s, %s", output, pointed[i])
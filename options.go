@@ -1,121 +1,213 @@
 package goopenzwave
 
-// #include "gzw_options.h"
-// #include <stdlib.h>
-import "C"
-import "unsafe"
+import (
+	"fmt"
+	"sync"
+)
 
-// Options is a container for the C++ OpenZWave library Options class.
+// OptionsState defines a type for the lifecycle state of the Options.
+type OptionsState int
+
+const (
+	OptionsStateCreated OptionsState = iota
+	OptionsStateLocked
+	OptionsStateDestroyed
+)
+
+func (s OptionsState) String() string {
+	switch s {
+	case OptionsStateCreated:
+		return "Created"
+	case OptionsStateLocked:
+		return "Locked"
+	case OptionsStateDestroyed:
+		return "Destroyed"
+	}
+	return "UNKNOWN"
+}
+
+// Options is a container for the C++ OpenZWave library Options class. The
+// options themselves are held by the installed Backend.
+//
+// The Options must be created and locked before a Manager can be created from
+// them, and must not be destroyed until that Manager has been destroyed.
 type Options struct {
-	options C.options_t
+	backend Backend
+
+	mu      sync.Mutex
+	state   OptionsState
+	manager *Manager
 }
 
-// CreateOptions creates an object to manage the program options.
+var (
+	currentOptionsMu sync.Mutex
+	currentOptions   *Options
+)
+
+// CreateOptions creates an object to manage the program options. As in
+// OpenZWave there is only one Options object; if it has already been created
+// for the installed Backend, and not destroyed, the existing Options is
+// returned.
 func CreateOptions(configPath, userPath, commandLine string) *Options {
-	o := &Options{}
-	cConfigPath := C.CString(configPath)
-	cUserPath := C.CString(userPath)
-	cCommandLine := C.CString(commandLine)
-	o.options = C.options_create(cConfigPath, cUserPath, cCommandLine)
-	C.free(unsafe.Pointer(cConfigPath))
-	C.free(unsafe.Pointer(cUserPath))
-	C.free(unsafe.Pointer(cCommandLine))
+	currentOptionsMu.Lock()
+	defer currentOptionsMu.Unlock()
+	if o := currentOptions; o != nil && o.backend == backend && o.State() != OptionsStateDestroyed {
+		return o
+	}
+	o := &Options{backend: backend, state: OptionsStateCreated}
+	if backend == nil || backend.CreateOptions(configPath, userPath, commandLine) == false {
+		o.state = OptionsStateDestroyed
+	}
+	currentOptions = o
 	return o
 }
 
 // DestroyOptions deletes the Options and cleans up any associated objects. The
 // application is responsible for destroying the Options object, but this must
 // not be done until after the Manager object has been destroyed.
-func DestroyOptions() bool {
-	if C.bool(C.options_destroy()) {
-		return true
+func DestroyOptions() error {
+	o := GetOptions()
+	if o == nil {
+		return &StateError{Op: "DestroyOptions", Err: ErrOptionsNotCreated}
 	}
-	return false
+	return o.Destroy()
 }
 
-// GetOptions gets a pointer to the Options singleton object.
+// GetOptions gets a pointer to the Options singleton object, or nil if it has
+// not been created.
 func GetOptions() *Options {
-	o := &Options{}
-	o.options = C.options_get()
-	return o
+	currentOptionsMu.Lock()
+	defer currentOptionsMu.Unlock()
+	return currentOptions
+}
+
+// State returns the current lifecycle state of the Options.
+func (o *Options) State() OptionsState {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.state
+}
+
+// Destroy deletes the Options and cleans up any associated objects, in the
+// same way as DestroyOptions. Any Manager created from the Options must be
+// destroyed first.
+func (o *Options) Destroy() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.state == OptionsStateDestroyed {
+		return &StateError{Op: "DestroyOptions", Err: ErrOptionsDestroyed}
+	}
+	if o.manager != nil && o.manager.State() != ManagerStateDestroyed {
+		return &StateError{Op: "DestroyOptions", Err: ErrOptionsInUse}
+	}
+	o.backend.DestroyOptions()
+	o.state = OptionsStateDestroyed
+	o.manager = nil
+	return nil
 }
 
 // Lock locks the options. Reads in option values from the XML options file and
 // command line string and marks the options as locked. Once locked, no more
 // calls to AddOption can be made. The options must be locked before the
-// Manager::Create method is called.
-func (o *Options) Lock() bool {
-	return bool(C.options_lock(o.options))
+// Manager::Create method is called. An error is returned, and the options are
+// left unlocked, if OpenZWave fails to lock them, for example if the command
+// line string cannot be parsed.
+func (o *Options) Lock() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if err := o.checkUnlocked("Lock"); err != nil {
+		return err
+	}
+	if o.backend.LockOptions() == false {
+		return fmt.Errorf("failed to lock options")
+	}
+	o.state = OptionsStateLocked
+	return nil
+}
+
+// checkUnlocked returns a StateError if the options can no longer be changed.
+// o.mu must be held.
+func (o *Options) checkUnlocked(op string) error {
+	switch o.state {
+	case OptionsStateLocked:
+		return &StateError{Op: op, Err: ErrOptionsLocked}
+	case OptionsStateDestroyed:
+		return &StateError{Op: op, Err: ErrOptionsDestroyed}
+	}
+	return nil
+}
+
+// addOption calls add if the options can still be changed.
+func (o *Options) addOption(op string, add func() bool) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if err := o.checkUnlocked(op); err != nil {
+		return err
+	}
+	if add() == false {
+		return fmt.Errorf("failed to add option")
+	}
+	return nil
 }
 
 // AddOptionBool add a boolean option to the program. Adds an option to the
 // program whose value can then be read from a file or command line. All calls
 // to AddOptionInt must be made before Lock.
-func (o *Options) AddOptionBool(name string, value bool) bool {
-	cName := C.CString(name)
-	result := bool(C.options_addOptionBool(o.options, cName, C.bool(value)))
-	C.free(unsafe.Pointer(cName))
-	return result
+func (o *Options) AddOptionBool(name string, value bool) error {
+	return o.addOption("AddOptionBool", func() bool {
+		return o.backend.AddOptionBool(name, value)
+	})
 }
 
 // AddOptionInt add an integer option to the program. Adds an option to the
 // program whose value can then be read from a file or command line. All calls
 // to AddOptionInt must be made before Lock.
-func (o *Options) AddOptionInt(name string, value int32) bool {
-	cName := C.CString(name)
-	result := bool(C.options_addOptionInt(o.options, cName, C.int32_t(value)))
-	C.free(unsafe.Pointer(cName))
-	return result
+func (o *Options) AddOptionInt(name string, value int32) error {
+	return o.addOption("AddOptionInt", func() bool {
+		return o.backend.AddOptionInt(name, value)
+	})
 }
 
 // AddOptionLogLevel add a log level option to the program. Adds an option to
 // the program whose value can then be read from a file or command line. All
 // calls to AddOptionLogLevel must be made before Lock.
-func (o *Options) AddOptionLogLevel(name string, value LogLevel) bool {
-	cName := C.CString(name)
-	result := bool(C.options_addOptionLogLevel(o.options, cName, C.loglevel_t(value)))
-	C.free(unsafe.Pointer(cName))
-	return result
+func (o *Options) AddOptionLogLevel(name string, value LogLevel) error {
+	return o.addOption("AddOptionLogLevel", func() bool {
+		return o.backend.AddOptionLogLevel(name, value)
+	})
 }
 
 // AddOptionString add a string option to the program. Adds an option to the
 // program whose value can then be read from a file or command line. All calls
 // to AddOptionString must be made before Lock.
-func (o *Options) AddOptionString(name string, value string, append bool) bool {
-	cName := C.CString(name)
-	cValue := C.CString(value)
-	result := bool(C.options_addOptionString(o.options, cName, cValue, C.bool(append)))
-	C.free(unsafe.Pointer(cName))
-	C.free(unsafe.Pointer(cValue))
-	return result
+func (o *Options) AddOptionString(name string, value string, append bool) error {
+	return o.addOption("AddOptionString", func() bool {
+		return o.backend.AddOptionString(name, value, append)
+	})
 }
 
 // GetOptionAsBool get the value of a boolean option.
 func (o *Options) GetOptionAsBool(name string) (bool, bool) {
-	cName := C.CString(name)
-	var cValue C.bool
-	result := bool(C.options_getOptionAsBool(o.options, cName, &cValue))
-	C.free(unsafe.Pointer(cName))
-	return result, bool(cValue)
+	if o.State() == OptionsStateDestroyed {
+		return false, false
+	}
+	return o.backend.GetOptionAsBool(name)
 }
 
 // GetOptionAsInt get the value of an integer option.
 func (o *Options) GetOptionAsInt(name string) (bool, int32) {
-	cName := C.CString(name)
-	var cValue C.int32_t
-	result := bool(C.options_getOptionAsInt(o.options, cName, &cValue))
-	C.free(unsafe.Pointer(cName))
-	return result, int32(cValue)
+	if o.State() == OptionsStateDestroyed {
+		return false, 0
+	}
+	return o.backend.GetOptionAsInt(name)
 }
 
 // GetOptionAsString get the value of a string option.
 func (o *Options) GetOptionAsString(name string) (bool, string) {
-	cName := C.CString(name)
-	var cstr *C.char
-	result := bool(C.options_getOptionAsString(o.options, cName, &cstr))
-	gostr := C.GoString(cstr)
-	C.free(unsafe.Pointer(cstr))
-	return result, gostr
+	if o.State() == OptionsStateDestroyed {
+		return false, ""
+	}
+	return o.backend.GetOptionAsString(name)
 }
 
 // GetOptionType get the type of value stored in an option.
@@ -123,5 +215,23 @@ func (o *Options) GetOptionAsString(name string) (bool, string) {
 
 // AreLocked test whether the options have been locked.
 func (o *Options) AreLocked() bool {
-	return bool(C.options_areLocked(o.options))
+	return o.State() == OptionsStateLocked
+}
+
+// attach records that the Manager has been created from the Options, which
+// must be locked and not already in use.
+func (o *Options) attach(m *Manager) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	switch o.state {
+	case OptionsStateCreated:
+		return &StateError{Op: "NewManager", Err: ErrOptionsNotLocked}
+	case OptionsStateDestroyed:
+		return &StateError{Op: "NewManager", Err: ErrOptionsDestroyed}
+	}
+	if o.manager != nil && o.manager.State() != ManagerStateDestroyed {
+		return &StateError{Op: "NewManager", Err: ErrOptionsInUse}
+	}
+	o.manager = m
+	return nil
 }
@@ -1,12 +1,8 @@
 package goopenzwave
 
-// #include "gzw_manager.h"
-// #include <stdlib.h>
-import "C"
-
 // GetPollInterval returns the time period between polls of a node's state.
-func GetPollInterval() int32 {
-	return int32(C.manager_getPollInterval(cmanager))
+func (m *Manager) GetPollInterval() int32 {
+	return m.backend.GetPollInterval()
 }
 
 // SetPollInterval will set the time period between polls of a node's state.
@@ -20,31 +16,25 @@ func GetPollInterval() int32 {
 // the interval should not be set shorter than the number of polled devices in
 // seconds (so that the network does not have to cope with more than one poll
 // per second).
-func SetPollInterval(milliseconds int32, intervalBetweenPolls bool) {
-	C.manager_setPollInterval(cmanager, C.int32_t(milliseconds), C.bool(intervalBetweenPolls))
+func (m *Manager) SetPollInterval(milliseconds int32, intervalBetweenPolls bool) {
+	m.backend.SetPollInterval(milliseconds, intervalBetweenPolls)
 }
 
 // EnablePoll enables the polling of a device's state. Returns true if polling
 // was enabled.
-func EnablePoll(homeID uint32, valueID uint64, intensity uint8) bool {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	return bool(C.manager_enablePoll(cmanager, cvalueid, C.uint8_t(intensity)))
+func (m *Manager) EnablePoll(homeID uint32, valueID uint64, intensity uint8) bool {
+	return m.backend.EnablePoll(homeID, valueID, intensity)
 }
 
 // DisablePoll disables the polling of a device's state. Returns true if polling
 // was disabled.
-func DisablePoll(homeID uint32, valueID uint64) bool {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	return bool(C.manager_disablePoll(cmanager, cvalueid))
+func (m *Manager) DisablePoll(homeID uint32, valueID uint64) bool {
+	return m.backend.DisablePoll(homeID, valueID)
 }
 
 // IsPolled returns true if the device's state is being polled.
-func IsPolled(homeID uint32, valueID uint64) bool {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	return bool(C.manager_isPolled(cmanager, cvalueid))
+func (m *Manager) IsPolled(homeID uint32, valueID uint64) bool {
+	return m.backend.IsPolled(homeID, valueID)
 }
 
 // SetPollIntensity sets the frequency of polling.
@@ -53,15 +43,11 @@ func IsPolled(homeID uint32, valueID uint64) bool {
 //  - 1 = every time through the list
 //  - 2 = every other time
 //  - etc.
-func SetPollIntensity(homeID uint32, valueID uint64, intensity uint8) {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	C.manager_setPollIntensity(cmanager, cvalueid, C.uint8_t(intensity))
+func (m *Manager) SetPollIntensity(homeID uint32, valueID uint64, intensity uint8) {
+	m.backend.SetPollIntensity(homeID, valueID, intensity)
 }
 
 // GetPollIntensity returns the polling intensity of a device's state.
-func GetPollIntensity(homeID uint32, valueID uint64) uint8 {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	return uint8(C.manager_getPollIntensity(cmanager, cvalueid))
+func (m *Manager) GetPollIntensity(homeID uint32, valueID uint64) uint8 {
+	return m.backend.GetPollIntensity(homeID, valueID)
 }
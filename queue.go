@@ -0,0 +1,196 @@
+package goopenzwave
+
+import (
+	"sync"
+)
+
+// OverflowPolicy defines what a NotificationQueue does with a new Notification
+// when the queue is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits for the handler to make room in the queue. This
+	// blocks the thread delivering the notification, which for the OpenZWave
+	// library is its driver thread.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest discards the oldest queued Notification to make room
+	// for the new one.
+	OverflowDropOldest
+	// OverflowDropNewest discards the new Notification.
+	OverflowDropNewest
+	// OverflowGrow grows the queue beyond its capacity to hold the new
+	// Notification, so that none are dropped and the delivering thread is
+	// never blocked, at the cost of unbounded memory use.
+	OverflowGrow
+)
+
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowBlock:
+		return "Block"
+	case OverflowDropOldest:
+		return "DropOldest"
+	case OverflowDropNewest:
+		return "DropNewest"
+	case OverflowGrow:
+		return "Grow"
+	}
+	return "UNKNOWN"
+}
+
+// QueueStats contains the counters of a NotificationQueue.
+type QueueStats struct {
+	Depth     int    // Number of notifications waiting to be handled.
+	Capacity  int    // Maximum number of notifications that can wait.
+	Handled   uint64 // Number of notifications passed to the handler.
+	Dropped   uint64 // Number of notifications discarded by the overflow policy or after Close.
+	MaxDepth  int    // Largest Depth seen.
+	Overflows uint64 // Number of times a notification arrived while the queue was full.
+}
+
+// NotificationQueue passes notifications to a NotificationHandler on its own
+// goroutine, so that a slow handler does not stall the thread delivering the
+// notifications. Notifications are queued in a bounded queue and handled one
+// at a time in the order they arrived, so the order of the notifications for
+// each node is preserved. What happens when the queue is full is decided by the
+// OverflowPolicy.
+//
+// Pass the Handle method to Start, or use Manager.SetNotificationQueue to have
+// the Manager create and close the queue itself.
+type NotificationQueue struct {
+	handler  NotificationHandler
+	capacity int
+	policy   OverflowPolicy
+
+	mu       sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	items    []*Notification
+	closed   bool
+	stats    QueueStats
+	done     chan struct{}
+}
+
+// NewNotificationQueue creates a NotificationQueue holding up to capacity
+// notifications for the handler, and starts the goroutine that handles them.
+// A capacity of less than 1 is treated as 1.
+func NewNotificationQueue(handler NotificationHandler, capacity int, policy OverflowPolicy) *NotificationQueue {
+	if capacity < 1 {
+		capacity = 1
+	}
+	q := &NotificationQueue{
+		handler:  handler,
+		capacity: capacity,
+		policy:   policy,
+		items:    make([]*Notification, 0, capacity),
+		done:     make(chan struct{}),
+	}
+	q.notEmpty = sync.NewCond(&q.mu)
+	q.notFull = sync.NewCond(&q.mu)
+	q.stats.Capacity = capacity
+	go q.run()
+	return q
+}
+
+// Handle adds the notification to the queue, applying the OverflowPolicy if
+// the queue is full. Notifications passed to Handle after Close are dropped.
+func (q *NotificationQueue) Handle(notification *Notification) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		q.stats.Dropped++
+		return
+	}
+	if len(q.items) >= q.capacity {
+		q.stats.Overflows++
+		switch q.policy {
+		case OverflowDropOldest:
+			q.items[0] = nil
+			q.items = q.items[1:]
+			q.stats.Dropped++
+		case OverflowDropNewest:
+			q.stats.Dropped++
+			return
+		case OverflowGrow:
+			// append makes the room.
+		default:
+			for len(q.items) >= q.capacity && !q.closed {
+				q.notFull.Wait()
+			}
+			if q.closed {
+				q.stats.Dropped++
+				return
+			}
+		}
+	}
+	q.items = append(q.items, notification)
+	if len(q.items) > q.stats.MaxDepth {
+		q.stats.MaxDepth = len(q.items)
+	}
+	q.notEmpty.Signal()
+}
+
+// Depth returns the number of notifications waiting to be handled.
+func (q *NotificationQueue) Depth() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.items)
+}
+
+// Dropped returns the number of notifications that have been discarded.
+func (q *NotificationQueue) Dropped() uint64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.stats.Dropped
+}
+
+// Stats returns a copy of the queue's counters.
+func (q *NotificationQueue) Stats() QueueStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	stats := q.stats
+	stats.Depth = len(q.items)
+	return stats
+}
+
+// Close stops the queue accepting notifications and waits for the ones
+// already queued to be handled. Any Handle call blocked by OverflowBlock
+// returns, dropping its notification. Close must not be called from the
+// handler.
+func (q *NotificationQueue) Close() {
+	q.mu.Lock()
+	q.closed = true
+	q.notEmpty.Broadcast()
+	q.notFull.Broadcast()
+	q.mu.Unlock()
+	<-q.done
+}
+
+// run passes the queued notifications to the handler until the queue is closed
+// and empty.
+func (q *NotificationQueue) run() {
+	defer close(q.done)
+	for {
+		q.mu.Lock()
+		for len(q.items) == 0 && !q.closed {
+			q.notEmpty.Wait()
+		}
+		if len(q.items) == 0 {
+			q.mu.Unlock()
+			return
+		}
+		notification := q.items[0]
+		q.items[0] = nil
+		q.items = q.items[1:]
+		q.notFull.Signal()
+		q.mu.Unlock()
+
+		if q.handler != nil {
+			q.handler(notification)
+		}
+
+		q.mu.Lock()
+		q.stats.Handled++
+		q.mu.Unlock()
+	}
+}
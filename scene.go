@@ -0,0 +1,149 @@
+package goopenzwave
+
+import (
+	"fmt"
+)
+
+// Scene is an OpenZWave scene: a set of values which are all set when the
+// scene is activated. Create a new scene with CreateScene, then use `NewScene`
+// with the returned Scene ID.
+type Scene struct {
+	SceneID uint8
+
+	manager *Manager
+}
+
+// NewScene will create a new Scene object for the scene on the default
+// Manager. Use Manager.NewScene for other Managers.
+func NewScene(sceneID uint8) *Scene {
+	return &Scene{
+		SceneID: sceneID,
+	}
+}
+
+// mgr returns the Manager that the Scene was created for, or the default
+// Manager.
+func (s *Scene) mgr() *Manager {
+	if s.manager != nil {
+		return s.manager
+	}
+	return defaultManager()
+}
+
+// SceneValue is a value in a scene and the data it is set to when the scene is
+// activated. Value holds a bool, uint8, float32, int32, int16 or string
+// according to the type of the ValueID. List values hold the label of the
+// selected item as a string.
+type SceneValue struct {
+	ValueID *ValueID
+	Value   interface{}
+}
+
+// String will return a string containing the ID and label of the scene, and
+// the number of values in it.
+func (s *Scene) String() string {
+	return fmt.Sprintf("Scene{SceneID: %d, Label: %q, Values: %d}", s.SceneID, s.Label(), len(s.mgr().SceneGetValues(s.SceneID)))
+}
+
+// Label returns the label of the scene.
+func (s *Scene) Label() string {
+	return s.mgr().GetSceneLabel(s.SceneID)
+}
+
+// SetLabel sets the label of the scene.
+func (s *Scene) SetLabel(label string) {
+	s.mgr().SetSceneLabel(s.SceneID, label)
+}
+
+// Exists returns true if the scene is defined.
+func (s *Scene) Exists() bool {
+	return s.mgr().SceneExists(s.SceneID)
+}
+
+// Values returns the values in the scene along with the data each is set to.
+// Values whose data could not be read are returned with a nil Value.
+func (s *Scene) Values() []SceneValue {
+	var values []SceneValue
+	for _, valueID := range s.mgr().SceneGetValues(s.SceneID) {
+		value, err := s.get(valueID)
+		if err != nil {
+			value = nil
+		}
+		values = append(values, SceneValue{ValueID: valueID, Value: value})
+	}
+	return values
+}
+
+// get returns the data for the value in the scene, read according to the type
+// of the ValueID.
+func (s *Scene) get(v *ValueID) (interface{}, error) {
+	m := s.mgr()
+	switch v.Type {
+	case ValueIDTypeBool:
+		return m.GetSceneValueAsBool(s.SceneID, v.HomeID, v.ID)
+	case ValueIDTypeByte:
+		return m.GetSceneValueAsByte(s.SceneID, v.HomeID, v.ID)
+	case ValueIDTypeDecimal:
+		return m.GetSceneValueAsFloat(s.SceneID, v.HomeID, v.ID)
+	case ValueIDTypeInt:
+		return m.GetSceneValueAsInt(s.SceneID, v.HomeID, v.ID)
+	case ValueIDTypeShort:
+		return m.GetSceneValueAsShort(s.SceneID, v.HomeID, v.ID)
+	case ValueIDTypeList:
+		return m.GetSceneValueListSelectionString(s.SceneID, v.HomeID, v.ID)
+	}
+	return m.GetSceneValueAsString(s.SceneID, v.HomeID, v.ID)
+}
+
+// Add adds the value to the scene, to be set to value when the scene is
+// activated. The type of value must match the type of the ValueID, as
+// described by SceneValue; a list value may also be given the index of the
+// selected item as an int32. A string is parsed for any type of ValueID.
+// Returns an error if the value was not added.
+func (s *Scene) Add(v *ValueID, value interface{}) error {
+	m := s.mgr()
+	var ok bool
+	switch value := value.(type) {
+	case bool:
+		ok = m.AddSceneValueBool(s.SceneID, v.HomeID, v.ID, value)
+	case uint8:
+		ok = m.AddSceneValueUint8(s.SceneID, v.HomeID, v.ID, value)
+	case float32:
+		ok = m.AddSceneValueFloat(s.SceneID, v.HomeID, v.ID, value)
+	case int32:
+		if v.Type == ValueIDTypeList {
+			ok = m.AddSceneValueListSelectionInt32(s.SceneID, v.HomeID, v.ID, value)
+		} else {
+			ok = m.AddSceneValueInt32(s.SceneID, v.HomeID, v.ID, value)
+		}
+	case int16:
+		ok = m.AddSceneValueInt16(s.SceneID, v.HomeID, v.ID, value)
+	case string:
+		if v.Type == ValueIDTypeList {
+			ok = m.AddSceneValueListSelectionString(s.SceneID, v.HomeID, v.ID, value)
+		} else {
+			ok = m.AddSceneValueString(s.SceneID, v.HomeID, v.ID, value)
+		}
+	default:
+		return fmt.Errorf("unsupported scene value type %T", value)
+	}
+	if ok == false {
+		return fmt.Errorf("value was not added to scene")
+	}
+	return nil
+}
+
+// Remove removes the value from the scene. Returns an error if the value was
+// not removed.
+func (s *Scene) Remove(v *ValueID) error {
+	if s.mgr().RemoveSceneValue(s.SceneID, v.HomeID, v.ID) == false {
+		return fmt.Errorf("value was not removed from scene")
+	}
+	return nil
+}
+
+// Activate activates the scene, setting all of its values. Returns an error if
+// the scene was not activated.
+func (s *Scene) Activate() error {
+	return s.mgr().ActivateScene(s.SceneID)
+}
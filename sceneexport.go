@@ -0,0 +1,219 @@
+package goopenzwave
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Errors describing why an exported scene value could not be imported. They
+// are returned in a SceneValueError.
+var (
+	ErrSceneNodeMissing     = errors.New("node not found")
+	ErrSceneProductMismatch = errors.New("node is a different product")
+	ErrSceneValueMissing    = errors.New("value not found")
+	ErrSceneValueRejected   = errors.New("value not added to scene")
+)
+
+// SceneExport is a portable copy of the scenes of a network. Values are
+// referenced by node ID and command class, instance and index rather than by
+// Scene ID and ValueID, so that the scenes can be imported into another
+// network or after the controller has been replaced. It can be encoded as JSON
+// or YAML.
+type SceneExport struct {
+	Scenes []ExportedScene `json:"scenes" yaml:"scenes"`
+}
+
+// ExportedScene is a scene in a SceneExport.
+type ExportedScene struct {
+	Label  string               `json:"label" yaml:"label"`
+	Values []ExportedSceneValue `json:"values" yaml:"values"`
+}
+
+// ExportedSceneValue is a value in an ExportedScene. The value is held as a
+// string, as read by GetSceneValueAsString, with list values holding the label
+// of the selected item. The genre and type are held by name, e.g. "User" and
+// "Byte".
+//
+// The manufacturer and product IDs of the node are recorded when the scene is
+// exported. When they are set, the value is only imported if the node is the
+// same product.
+type ExportedSceneValue struct {
+	NodeID         uint8        `json:"nodeId" yaml:"nodeId"`
+	Genre          ValueIDGenre `json:"genre" yaml:"genre"`
+	CommandClassID uint8        `json:"commandClassId" yaml:"commandClassId"`
+	Instance       uint8        `json:"instance" yaml:"instance"`
+	Index          uint8        `json:"index" yaml:"index"`
+	Type           ValueIDType  `json:"type" yaml:"type"`
+	Label          string       `json:"label,omitempty" yaml:"label,omitempty"` // For reference only.
+	Value          string       `json:"value" yaml:"value"`
+	ManufacturerID string       `json:"manufacturerId,omitempty" yaml:"manufacturerId,omitempty"`
+	ProductType    string       `json:"productType,omitempty" yaml:"productType,omitempty"`
+	ProductID      string       `json:"productId,omitempty" yaml:"productId,omitempty"`
+}
+
+func (v ExportedSceneValue) String() string {
+	return fmt.Sprintf("<NodeID: %d, CommandClassID: %d, Instance: %d, Index: %d, Label: %q, Value: %q>", v.NodeID, v.CommandClassID, v.Instance, v.Index, v.Label, v.Value)
+}
+
+// SceneValueError describes an exported value which could not be imported.
+type SceneValueError struct {
+	Scene string // The label of the scene.
+	Value ExportedSceneValue
+	Err   error // One of the ErrScene variables.
+}
+
+func (e *SceneValueError) Error() string {
+	return fmt.Sprintf("scene %q: value %s: %s", e.Scene, e.Value, e.Err)
+}
+
+// Unwrap returns the reason for the error.
+func (e *SceneValueError) Unwrap() error {
+	return e.Err
+}
+
+// SceneImportOptions controls how ImportScenes maps a SceneExport onto the
+// network.
+type SceneImportOptions struct {
+	// NodeIDs maps the node IDs in the export to the node IDs in the network,
+	// for nodes which have been re-included. Unmapped nodes keep their ID.
+	NodeIDs map[uint8]uint8
+
+	// IgnoreProduct imports values even if the node is a different product to
+	// the one the scene was exported from.
+	IgnoreProduct bool
+
+	// Replace removes any existing scenes with the same label as an imported
+	// scene, rather than adding another scene.
+	Replace bool
+}
+
+// SceneImportReport is the outcome of ImportScenes.
+type SceneImportReport struct {
+	SceneIDs []uint8            // The IDs of the scenes created, in the order of the export.
+	Missing  []*SceneValueError // The values which were not imported.
+}
+
+// ExportScenes returns a SceneExport of all of the scenes.
+func (m *Manager) ExportScenes() *SceneExport {
+	export := &SceneExport{Scenes: []ExportedScene{}}
+	for _, scene := range m.GetScenes() {
+		exported := ExportedScene{
+			Label:  scene.Label(),
+			Values: []ExportedSceneValue{},
+		}
+		for _, v := range m.SceneGetValues(scene.SceneID) {
+			value, err := m.GetSceneValueAsString(scene.SceneID, v.HomeID, v.ID)
+			if err != nil {
+				continue
+			}
+			exported.Values = append(exported.Values, ExportedSceneValue{
+				NodeID:         v.NodeID,
+				Genre:          v.Genre,
+				CommandClassID: v.CommandClassID,
+				Instance:       v.Instance,
+				Index:          v.Index,
+				Type:           v.Type,
+				Label:          m.GetValueLabel(v.HomeID, v.ID),
+				Value:          value,
+				ManufacturerID: m.GetNodeManufacturerID(v.HomeID, v.NodeID),
+				ProductType:    m.GetNodeProductType(v.HomeID, v.NodeID),
+				ProductID:      m.GetNodeProductID(v.HomeID, v.NodeID),
+			})
+		}
+		export.Scenes = append(export.Scenes, exported)
+	}
+	return export
+}
+
+// ImportScenes creates a scene for each scene in the export, with the values
+// remapped onto the network with the Home ID. Values which no longer exist, or
+// whose node is now a different product, are left out of the scene and listed
+// in the report. A scene is still created if none of its values could be
+// imported. An error is returned if a scene could not be created.
+func (m *Manager) ImportScenes(homeID uint32, export *SceneExport, options SceneImportOptions) (*SceneImportReport, error) {
+	report := &SceneImportReport{}
+	for _, exported := range export.Scenes {
+		if options.Replace {
+			for _, scene := range m.GetScenes() {
+				if scene.Label() == exported.Label {
+					m.RemoveScene(scene.SceneID)
+				}
+			}
+		}
+
+		sceneID := m.CreateScene()
+		if sceneID == 0 {
+			return report, fmt.Errorf("failed to create scene %q", exported.Label)
+		}
+		m.SetSceneLabel(sceneID, exported.Label)
+		report.SceneIDs = append(report.SceneIDs, sceneID)
+
+		for _, value := range exported.Values {
+			if err := m.importSceneValue(homeID, sceneID, value, options); err != nil {
+				report.Missing = append(report.Missing, &SceneValueError{
+					Scene: exported.Label,
+					Value: value,
+					Err:   err,
+				})
+			}
+		}
+	}
+	return report, nil
+}
+
+// importSceneValue adds the exported value to the scene, returning one of the
+// ErrScene variables if it could not be added.
+func (m *Manager) importSceneValue(homeID uint32, sceneID uint8, value ExportedSceneValue, options SceneImportOptions) error {
+	nodeID := value.NodeID
+	if mapped, ok := options.NodeIDs[nodeID]; ok {
+		nodeID = mapped
+	}
+	if m.GetNodeBasicType(homeID, nodeID) == 0 {
+		return ErrSceneNodeMissing
+	}
+	if options.IgnoreProduct == false && value.ManufacturerID != "" {
+		if m.GetNodeManufacturerID(homeID, nodeID) != value.ManufacturerID ||
+			m.GetNodeProductType(homeID, nodeID) != value.ProductType ||
+			m.GetNodeProductID(homeID, nodeID) != value.ProductID {
+			return ErrSceneProductMismatch
+		}
+	}
+
+	v, err := NewValueID(homeID, nodeID, value.Genre, value.CommandClassID, value.Instance, value.Index, value.Type)
+	if err != nil {
+		// No value on the network can have an unknown genre or type.
+		return ErrSceneValueMissing
+	}
+	if _, ok := m.backend.GetValueAsString(homeID, v.ID); ok == false {
+		return ErrSceneValueMissing
+	}
+	var ok bool
+	if v.Type == ValueIDTypeList {
+		ok = m.AddSceneValueListSelectionString(sceneID, homeID, v.ID, value.Value)
+	} else {
+		ok = m.AddSceneValueString(sceneID, homeID, v.ID, value.Value)
+	}
+	if ok == false {
+		return ErrSceneValueRejected
+	}
+	return nil
+}
+
+// WriteJSON writes the SceneExport to w as indented JSON.
+func (e *SceneExport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(e)
+}
+
+// ReadSceneExport reads a SceneExport written by SceneExport.WriteJSON.
+func ReadSceneExport(r io.Reader) (*SceneExport, error) {
+	export := &SceneExport{}
+	err := json.NewDecoder(r).Decode(export)
+	if err != nil {
+		return nil, err
+	}
+	return export, nil
+}
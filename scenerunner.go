@@ -0,0 +1,395 @@
+package goopenzwave
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Command class and index of the Dimming Duration value of a Switch
+// Multilevel (version 2 and later) value, used for fades.
+const (
+	switchMultilevelCommandClassID uint8 = uint8(CommandClassSwitchMultilevel)
+	switchMultilevelIndexDuration  uint8 = 5
+)
+
+// SceneProgram is a scene run by Manager.RunScene rather than by OpenZWave.
+// Its groups are run in order, each group starting once every step of the
+// previous group has finished.
+type SceneProgram struct {
+	Label  string
+	Groups []SceneGroup
+
+	// Rollback restores the values set by the program to the values they had
+	// before it was run, in reverse order, if a step fails.
+	Rollback bool
+}
+
+// SceneGroup is a group of steps in a SceneProgram. The steps of a group are
+// run at the same time, each after its own Delay.
+type SceneGroup struct {
+	Label string
+	Steps []SceneStep
+}
+
+// SceneStep sets a value as part of a SceneProgram.
+type SceneStep struct {
+	ValueID *ValueID
+
+	// Value is the value to set, which must be of the type of the ValueID as
+	// described by SceneValue. A string may be given for any ValueID.
+	Value interface{}
+
+	// Delay is the time to wait after the group starts before setting the
+	// value.
+	Delay time.Duration
+
+	// Fade is the time a dimmer should take to reach the new level. It is
+	// applied through the Dimming Duration value of the Switch Multilevel
+	// command class, which is restored once the level has been set, and
+	// ignored for nodes which do not support it. Z-Wave durations are limited
+	// to 127 minutes, with a resolution of one second up to 127 seconds and
+	// one minute above that.
+	Fade time.Duration
+
+	// OnlyIfOff skips the step unless the value is currently off: false for a
+	// bool, or zero for a number.
+	OnlyIfOff bool
+}
+
+// SceneEventType defines a type for the events reported by RunScene.
+type SceneEventType int
+
+const (
+	SceneEventGroupStarted SceneEventType = iota
+	SceneEventStepApplied
+	SceneEventStepSkipped
+	SceneEventStepFailed
+	SceneEventStepRolledBack
+	SceneEventGroupFinished
+)
+
+func (t SceneEventType) String() string {
+	switch t {
+	case SceneEventGroupStarted:
+		return "GroupStarted"
+	case SceneEventStepApplied:
+		return "StepApplied"
+	case SceneEventStepSkipped:
+		return "StepSkipped"
+	case SceneEventStepFailed:
+		return "StepFailed"
+	case SceneEventStepRolledBack:
+		return "StepRolledBack"
+	case SceneEventGroupFinished:
+		return "GroupFinished"
+	}
+	return "UNKNOWN"
+}
+
+// SceneEvent reports the progress of RunScene. Group and Step are the indexes
+// of the group and step in the SceneProgram; Step is -1 for group events.
+type SceneEvent struct {
+	Type  SceneEventType
+	Group int
+	Step  int
+	Err   error // The reason a step failed or could not be rolled back.
+}
+
+func (e SceneEvent) String() string {
+	if e.Err != nil {
+		return fmt.Sprintf("<Type: %s, Group: %d, Step: %d, Err: %s>", e.Type, e.Group, e.Step, e.Err)
+	}
+	return fmt.Sprintf("<Type: %s, Group: %d, Step: %d>", e.Type, e.Group, e.Step)
+}
+
+// SceneStepError is returned by RunScene when a step fails.
+type SceneStepError struct {
+	Group int
+	Step  int
+	Err   error
+}
+
+func (e *SceneStepError) Error() string {
+	return fmt.Sprintf("scene group %d step %d: %s", e.Group, e.Step, e.Err)
+}
+
+// Unwrap returns the reason the step failed.
+func (e *SceneStepError) Unwrap() error {
+	return e.Err
+}
+
+// RunScene runs the program, calling progress (if not nil) as each group and
+// step is run. progress is called from one goroutine at a time.
+//
+// If a step fails, the rest of its group is abandoned, the values already set
+// are rolled back if program.Rollback is set, and a SceneStepError is
+// returned. If ctx is done first, RunScene stops and returns ctx.Err() without
+// rolling back.
+func (m *Manager) RunScene(ctx context.Context, program *SceneProgram, progress func(SceneEvent)) error {
+	r := &sceneRun{manager: m, progress: progress}
+	for g, group := range program.Groups {
+		r.emit(SceneEvent{Type: SceneEventGroupStarted, Group: g, Step: -1})
+		err := r.runGroup(ctx, g, group)
+		if err == nil {
+			r.emit(SceneEvent{Type: SceneEventGroupFinished, Group: g, Step: -1})
+			continue
+		}
+		if _, ok := err.(*SceneStepError); ok && program.Rollback {
+			r.rollback()
+		}
+		return err
+	}
+	return nil
+}
+
+// sceneRun is the state of a single RunScene.
+type sceneRun struct {
+	manager  *Manager
+	progress func(SceneEvent)
+
+	mu      sync.Mutex
+	applied []appliedStep // In the order they were set.
+
+	// fadeMu is held while a step changes a Dimming Duration, so that steps
+	// fading the same dimmer do not restore each other's duration.
+	fadeMu sync.Mutex
+}
+
+// appliedStep is a step which has been set, with the value it replaced.
+type appliedStep struct {
+	group, step int
+	valueID     *ValueID
+	previous    interface{}
+	duration    *dimmingDuration // Nil if the step did not fade.
+}
+
+// dimmingDuration is a Dimming Duration value changed for a fade, with the
+// value it replaced.
+type dimmingDuration struct {
+	valueID  *ValueID
+	previous uint8
+}
+
+// emit passes the event to the progress function.
+func (r *sceneRun) emit(event SceneEvent) {
+	if r.progress == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.progress(event)
+}
+
+// runGroup runs the steps of the group concurrently and waits for them. The
+// first step to fail cancels the others.
+func (r *sceneRun) runGroup(ctx context.Context, g int, group SceneGroup) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	for s, step := range group.Steps {
+		wg.Add(1)
+		go func(s int, step SceneStep) {
+			defer wg.Done()
+			if err := r.runStep(ctx, g, s, step); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(s, step)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+	// A step may have been cancelled before it ran.
+	return ctx.Err()
+}
+
+// runStep waits for the step's delay, then checks its condition and sets the
+// value.
+func (r *sceneRun) runStep(ctx context.Context, g, s int, step SceneStep) error {
+	if step.Delay > 0 {
+		timer := time.NewTimer(step.Delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	} else if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	m := r.manager
+	v := step.ValueID
+	previous, err := m.getValue(v)
+	if err != nil {
+		return r.fail(g, s, err)
+	}
+	if step.OnlyIfOff && isOff(previous) == false {
+		r.emit(SceneEvent{Type: SceneEventStepSkipped, Group: g, Step: s})
+		return nil
+	}
+	var duration *dimmingDuration
+	if step.Fade > 0 && v.CommandClassID == switchMultilevelCommandClassID {
+		r.fadeMu.Lock()
+		defer r.fadeMu.Unlock()
+		duration, err = r.setFade(v, step.Fade)
+		if err != nil {
+			return r.fail(g, s, err)
+		}
+	}
+	err = m.setValue(v, step.Value)
+	if err == nil {
+		r.mu.Lock()
+		r.applied = append(r.applied, appliedStep{group: g, step: s, valueID: v, previous: previous, duration: duration})
+		r.mu.Unlock()
+	}
+	if restoreErr := r.restoreFade(duration); err == nil {
+		err = restoreErr
+	}
+	if err != nil {
+		return r.fail(g, s, err)
+	}
+	r.emit(SceneEvent{Type: SceneEventStepApplied, Group: g, Step: s})
+	return nil
+}
+
+// setFade sets the Dimming Duration of the dimmer value to the fade. It
+// returns nil if the node has no Dimming Duration value, as in version 1 of
+// the command class.
+func (r *sceneRun) setFade(v *ValueID, fade time.Duration) (*dimmingDuration, error) {
+	m := r.manager
+	duration, err := NewValueID(v.HomeID, v.NodeID, ValueIDGenreSystem, switchMultilevelCommandClassID, v.Instance, switchMultilevelIndexDuration, ValueIDTypeByte)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := m.GetValueAsByte(duration.HomeID, duration.ID)
+	if err != nil {
+		return nil, nil
+	}
+	err = m.SetValueUint8(duration.HomeID, duration.ID, encodeDuration(fade))
+	if err != nil {
+		return nil, fmt.Errorf("failed to set dimming duration: %s", err)
+	}
+	return &dimmingDuration{valueID: duration, previous: previous}, nil
+}
+
+// restoreFade sets the Dimming Duration back to the value it had before
+// setFade. It does nothing if duration is nil.
+func (r *sceneRun) restoreFade(duration *dimmingDuration) error {
+	if duration == nil {
+		return nil
+	}
+	err := r.manager.SetValueUint8(duration.valueID.HomeID, duration.valueID.ID, duration.previous)
+	if err != nil {
+		return fmt.Errorf("failed to restore dimming duration: %s", err)
+	}
+	return nil
+}
+
+// fail reports the failed step and returns a SceneStepError for it.
+func (r *sceneRun) fail(g, s int, err error) error {
+	r.emit(SceneEvent{Type: SceneEventStepFailed, Group: g, Step: s, Err: err})
+	return &SceneStepError{Group: g, Step: s, Err: err}
+}
+
+// rollback restores the values which have been set, latest first.
+func (r *sceneRun) rollback() {
+	r.mu.Lock()
+	applied := r.applied
+	r.applied = nil
+	r.mu.Unlock()
+	for i := len(applied) - 1; i >= 0; i-- {
+		a := applied[i]
+		err := r.manager.setValue(a.valueID, a.previous)
+		if restoreErr := r.restoreFade(a.duration); err == nil {
+			err = restoreErr
+		}
+		r.emit(SceneEvent{Type: SceneEventStepRolledBack, Group: a.group, Step: a.step, Err: err})
+	}
+}
+
+// getValue returns the current value of the ValueID, read according to its
+// type as described by SceneValue.
+func (m *Manager) getValue(v *ValueID) (interface{}, error) {
+	switch v.Type {
+	case ValueIDTypeBool:
+		return m.GetValueAsBool(v.HomeID, v.ID)
+	case ValueIDTypeByte:
+		return m.GetValueAsByte(v.HomeID, v.ID)
+	case ValueIDTypeDecimal:
+		return m.GetValueAsFloat(v.HomeID, v.ID)
+	case ValueIDTypeInt:
+		return m.GetValueAsInt(v.HomeID, v.ID)
+	case ValueIDTypeShort:
+		return m.GetValueAsShort(v.HomeID, v.ID)
+	case ValueIDTypeList:
+		return m.GetValueListSelectionAsString(v.HomeID, v.ID)
+	}
+	value, ok := m.backend.GetValueAsString(v.HomeID, v.ID)
+	if ok == false {
+		return value, fmt.Errorf("string value was not obtained")
+	}
+	return value, nil
+}
+
+// setValue sets the ValueID to the value, which must be of a type described
+// by SceneValue.
+func (m *Manager) setValue(v *ValueID, value interface{}) error {
+	switch value := value.(type) {
+	case bool:
+		return m.SetValueBool(v.HomeID, v.ID, value)
+	case uint8:
+		return m.SetValueUint8(v.HomeID, v.ID, value)
+	case float32:
+		return m.SetValueFloat(v.HomeID, v.ID, value)
+	case int32:
+		return m.SetValueInt32(v.HomeID, v.ID, value)
+	case int16:
+		return m.SetValueInt16(v.HomeID, v.ID, value)
+	case string:
+		if v.Type == ValueIDTypeList {
+			return m.SetValueListSelection(v.HomeID, v.ID, value)
+		}
+		return m.SetValueString(v.HomeID, v.ID, value)
+	}
+	return fmt.Errorf("unsupported value type %T", value)
+}
+
+// isOff returns true if the value is false or zero.
+func isOff(value interface{}) bool {
+	switch value := value.(type) {
+	case bool:
+		return value == false
+	case uint8:
+		return value == 0
+	case float32:
+		return value == 0
+	case int32:
+		return value == 0
+	case int16:
+		return value == 0
+	}
+	return false
+}
+
+// encodeDuration encodes the duration as a Z-Wave duration byte: 1 to 127
+// seconds, or 1 to 127 minutes as 128 to 254.
+func encodeDuration(d time.Duration) uint8 {
+	seconds := (d + time.Second/2) / time.Second
+	if seconds <= 127 {
+		return uint8(seconds)
+	}
+	minutes := (d + time.Minute/2) / time.Minute
+	if minutes > 127 {
+		minutes = 127
+	}
+	return uint8(127 + minutes)
+}
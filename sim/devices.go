@@ -0,0 +1,198 @@
+package sim
+
+import (
+	"github.com/jimjibone/goopenzwave"
+)
+
+// Command class IDs used by the simulated devices.
+const (
+	ccNoOperation            uint8 = 0x00
+	ccBasic                  uint8 = 0x20
+	ccSwitchBinary           uint8 = 0x25
+	ccSwitchMultilevel       uint8 = 0x26
+	ccSwitchAll              uint8 = 0x27
+	ccSensorBinary           uint8 = 0x30
+	ccSensorMultilevel       uint8 = 0x31
+	ccMeter                  uint8 = 0x32
+	ccThermostatMode         uint8 = 0x40
+	ccThermostatSetpoint     uint8 = 0x43
+	ccClimateControlSchedule uint8 = 0x46
+	ccZWavePlusInfo          uint8 = 0x5e
+	ccDoorLock               uint8 = 0x62
+	ccUserCode               uint8 = 0x63
+	ccConfiguration          uint8 = 0x70
+	ccManufacturerSpecific   uint8 = 0x72
+	ccAssociation            uint8 = 0x85
+	ccVersion                uint8 = 0x86
+	ccBattery                uint8 = 0x80
+	ccWakeUp                 uint8 = 0x84
+	ccSecurity               uint8 = 0x98
+)
+
+// Z-Wave basic device classes.
+const (
+	basicTypeStaticController uint8 = 0x02
+	basicTypeSlave            uint8 = 0x03
+	basicTypeRoutingSlave     uint8 = 0x04
+)
+
+// newNode returns a node with the fields common to all simulated devices.
+func newNode(nodeID uint8, name string) *Node {
+	return &Node{
+		ID:               nodeID,
+		BasicType:        basicTypeRoutingSlave,
+		ManufacturerName: "goopenzwave",
+		ManufacturerID:   "0x0000",
+		Name:             name,
+		Listening:        true,
+		Beaming:          true,
+		Routing:          true,
+		MaxBaudRate:      40000,
+		Version:          4,
+		ZWavePlus:        true,
+		Role:             5,
+		PlusType:         0,
+		CommandClasses: map[uint8]uint8{
+			ccZWavePlusInfo:        2,
+			ccManufacturerSpecific: 2,
+			ccVersion:              2,
+			ccAssociation:          2,
+		},
+		Groups: []*Group{
+			{Index: 1, Label: "Lifeline", MaxAssociations: 5, Members: []Association{{NodeID: 1}}},
+		},
+	}
+}
+
+// NewController returns the simulated controller node.
+func NewController(nodeID uint8) *Node {
+	return &Node{
+		ID:               nodeID,
+		BasicType:        basicTypeStaticController,
+		GenericType:      0x02,
+		SpecificType:     0x01,
+		Type:             "Static PC Controller",
+		ManufacturerName: "goopenzwave",
+		ManufacturerID:   "0x0000",
+		ProductName:      "Simulated Controller",
+		ProductType:      "0x0001",
+		ProductID:        "0x0001",
+		Listening:        true,
+		Beaming:          true,
+		Routing:          false,
+		MaxBaudRate:      40000,
+		Version:          4,
+	}
+}
+
+// NewSwitch returns a mains powered binary switch with a power and energy
+// meter.
+func NewSwitch(nodeID uint8, name string) *Node {
+	node := newNode(nodeID, name)
+	node.GenericType = 0x10
+	node.SpecificType = 0x01
+	node.Type = "Binary Power Switch"
+	node.ProductName = "Simulated Switch"
+	node.ProductType = "0x0002"
+	node.ProductID = "0x0001"
+	node.DeviceType = 0x0700
+	node.Values = []*Value{
+		{Genre: goopenzwave.ValueIDGenreUser, CommandClassID: ccSwitchBinary, Instance: 1, Index: 0, Type: goopenzwave.ValueIDTypeBool, Label: "Switch", Data: false},
+		{Genre: goopenzwave.ValueIDGenreUser, CommandClassID: ccMeter, Instance: 1, Index: 0, Type: goopenzwave.ValueIDTypeDecimal, Label: "Energy", Units: "kWh", ReadOnly: true, Precision: 3, Data: goopenzwave.Decimal{Precision: 3}},
+		{Genre: goopenzwave.ValueIDGenreUser, CommandClassID: ccMeter, Instance: 1, Index: 2, Type: goopenzwave.ValueIDTypeDecimal, Label: "Power", Units: "W", ReadOnly: true, Precision: 1, Data: goopenzwave.Decimal{Precision: 1}},
+		{Genre: goopenzwave.ValueIDGenreSystem, CommandClassID: ccSwitchAll, Instance: 1, Index: 0, Type: goopenzwave.ValueIDTypeList, Label: "Switch All", Items: []string{"Disabled", "Off Enabled", "On Enabled", "On and Off Enabled"}, Data: int32(3)},
+	}
+	return node
+}
+
+// NewDimmer returns a mains powered multilevel (dimmer) switch.
+func NewDimmer(nodeID uint8, name string) *Node {
+	node := newNode(nodeID, name)
+	node.GenericType = 0x11
+	node.SpecificType = 0x01
+	node.Type = "Multilevel Power Switch"
+	node.ProductName = "Simulated Dimmer"
+	node.ProductType = "0x0003"
+	node.ProductID = "0x0001"
+	node.DeviceType = 0x0600
+	node.CommandClasses[ccSwitchMultilevel] = 2
+	node.Values = []*Value{
+		{Genre: goopenzwave.ValueIDGenreUser, CommandClassID: ccSwitchMultilevel, Instance: 1, Index: 0, Type: goopenzwave.ValueIDTypeByte, Label: "Level", Min: 0, Max: 255, Data: byte(0)},
+		{Genre: goopenzwave.ValueIDGenreSystem, CommandClassID: ccSwitchMultilevel, Instance: 1, Index: 5, Type: goopenzwave.ValueIDTypeByte, Label: "Dimming Duration", Min: 0, Max: 255, Data: byte(0xff)},
+		{Genre: goopenzwave.ValueIDGenreConfig, CommandClassID: ccConfiguration, Instance: 1, Index: 1, Type: goopenzwave.ValueIDTypeByte, Label: "Minimum Level", Min: 1, Max: 99, Data: byte(1)},
+		{Genre: goopenzwave.ValueIDGenreConfig, CommandClassID: ccConfiguration, Instance: 1, Index: 2, Type: goopenzwave.ValueIDTypeBitSet, Label: "Indicators", Help: "Bit 1: power, bit 2: level, bit 3: night light", BitMask: 0x07, Size: 1, Data: uint32(0x01)},
+		{Genre: goopenzwave.ValueIDGenreSystem, CommandClassID: ccSwitchAll, Instance: 1, Index: 0, Type: goopenzwave.ValueIDTypeList, Label: "Switch All", Items: []string{"Disabled", "Off Enabled", "On Enabled", "On and Off Enabled"}, Data: int32(3)},
+	}
+	return node
+}
+
+// NewSensor returns a battery powered multisensor with motion, temperature and
+// battery level values. It is asleep until woken with Network.SetAwake.
+func NewSensor(nodeID uint8, name string) *Node {
+	node := newNode(nodeID, name)
+	node.GenericType = 0x21
+	node.SpecificType = 0x01
+	node.Type = "Routing Multilevel Sensor"
+	node.ProductName = "Simulated Multisensor"
+	node.ProductType = "0x0004"
+	node.ProductID = "0x0001"
+	node.DeviceType = 0x0d00
+	node.Role = 6
+	node.Listening = false
+	node.Asleep = true
+	node.Groups = append(node.Groups, &Group{Index: 2, Label: "Motion", MaxAssociations: 5})
+	node.Values = []*Value{
+		{Genre: goopenzwave.ValueIDGenreUser, CommandClassID: ccSensorBinary, Instance: 1, Index: 0, Type: goopenzwave.ValueIDTypeBool, Label: "Sensor", ReadOnly: true, Data: false},
+		{Genre: goopenzwave.ValueIDGenreUser, CommandClassID: ccSensorMultilevel, Instance: 1, Index: 1, Type: goopenzwave.ValueIDTypeDecimal, Label: "Temperature", Units: "C", ReadOnly: true, Precision: 1, Data: goopenzwave.Decimal{Mantissa: 205, Precision: 1}},
+		{Genre: goopenzwave.ValueIDGenreUser, CommandClassID: ccBattery, Instance: 1, Index: 0, Type: goopenzwave.ValueIDTypeByte, Label: "Battery Level", Units: "%", ReadOnly: true, Max: 100, Data: byte(100)},
+		{Genre: goopenzwave.ValueIDGenreSystem, CommandClassID: ccWakeUp, Instance: 1, Index: 0, Type: goopenzwave.ValueIDTypeInt, Label: "Wake-up Interval", Units: "Seconds", Min: 240, Max: 86400, Data: int32(3600)},
+		{Genre: goopenzwave.ValueIDGenreConfig, CommandClassID: ccConfiguration, Instance: 1, Index: 3, Type: goopenzwave.ValueIDTypeShort, Label: "Motion Timeout", Units: "Seconds", Min: 10, Max: 3600, Data: int16(240)},
+	}
+	return node
+}
+
+// NewThermostat returns a mains powered thermostat with a mode, heating
+// setpoint, temperature sensor and weekly schedule.
+func NewThermostat(nodeID uint8, name string) *Node {
+	node := newNode(nodeID, name)
+	node.GenericType = 0x08
+	node.SpecificType = 0x06
+	node.Type = "General Thermostat V2"
+	node.ProductName = "Simulated Thermostat"
+	node.ProductType = "0x0005"
+	node.ProductID = "0x0001"
+	node.DeviceType = 0x1200
+	node.CommandClasses[ccThermostatSetpoint] = 2
+	node.Values = []*Value{
+		{Genre: goopenzwave.ValueIDGenreUser, CommandClassID: ccThermostatMode, Instance: 1, Index: 0, Type: goopenzwave.ValueIDTypeList, Label: "Mode", Items: []string{"Off", "Heat", "Cool", "Auto"}, Data: int32(1)},
+		{Genre: goopenzwave.ValueIDGenreUser, CommandClassID: ccThermostatSetpoint, Instance: 1, Index: 1, Type: goopenzwave.ValueIDTypeDecimal, Label: "Heating 1", Units: "C", Precision: 1, Min: 5, Max: 30, Data: goopenzwave.Decimal{Mantissa: 210, Precision: 1}},
+		{Genre: goopenzwave.ValueIDGenreUser, CommandClassID: ccSensorMultilevel, Instance: 1, Index: 1, Type: goopenzwave.ValueIDTypeDecimal, Label: "Temperature", Units: "C", ReadOnly: true, Precision: 1, Data: goopenzwave.Decimal{Mantissa: 195, Precision: 1}},
+		{Genre: goopenzwave.ValueIDGenreUser, CommandClassID: ccClimateControlSchedule, Instance: 1, Index: 1, Type: goopenzwave.ValueIDTypeSchedule, Label: "Monday", Data: []SwitchPoint{{Hours: 7, Setback: 0}, {Hours: 22, Setback: -20}}},
+	}
+	return node
+}
+
+// NewLock returns a battery powered, frequently listening, secure door lock
+// with a single user code slot.
+func NewLock(nodeID uint8, name string) *Node {
+	node := newNode(nodeID, name)
+	node.GenericType = 0x40
+	node.SpecificType = 0x03
+	node.Type = "Secure Keypad Door Lock"
+	node.ProductName = "Simulated Lock"
+	node.ProductType = "0x0006"
+	node.ProductID = "0x0001"
+	node.DeviceType = 0x0300
+	node.Role = 7
+	node.Listening = false
+	node.FrequentListening = true
+	node.Security = true
+	node.CommandClasses[ccSecurity] = 1
+	node.CommandClasses[ccDoorLock] = 2
+	node.Values = []*Value{
+		{Genre: goopenzwave.ValueIDGenreUser, CommandClassID: ccDoorLock, Instance: 1, Index: 0, Type: goopenzwave.ValueIDTypeBool, Label: "Locked", Data: true},
+		{Genre: goopenzwave.ValueIDGenreUser, CommandClassID: ccUserCode, Instance: 1, Index: 1, Type: goopenzwave.ValueIDTypeRaw, Label: "Code 1:", Data: []byte{}},
+		{Genre: goopenzwave.ValueIDGenreUser, CommandClassID: ccBattery, Instance: 1, Index: 0, Type: goopenzwave.ValueIDTypeByte, Label: "Battery Level", Units: "%", ReadOnly: true, Max: 100, Data: byte(100)},
+	}
+	return node
+}
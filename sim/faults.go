@@ -0,0 +1,211 @@
+package sim
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jimjibone/goopenzwave"
+)
+
+// SetDead marks the node as dead or alive, sending a Dead or Alive
+// notification. Messages sent to a dead node time out. It returns false if
+// there is no such node.
+func (n *Network) SetDead(nodeID uint8, dead bool) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	node := n.nodes[nodeID]
+	if node == nil {
+		return false
+	}
+	if node.Dead == dead {
+		return true
+	}
+	node.Dead = dead
+	if dead {
+		n.notifyCodeLocked(node.ID, goopenzwave.NotificationCodeDead)
+		n.checkQueriedLocked()
+	} else {
+		n.notifyCodeLocked(node.ID, goopenzwave.NotificationCodeAlive)
+		if n.driverAdded {
+			n.queryNodeLocked(node)
+		}
+	}
+	return true
+}
+
+// SetAwake wakes the node up or sends it to sleep, sending an Awake or Sleep
+// notification. When the node wakes up any messages sent to it while it was
+// asleep are delivered and its queries are completed. It returns false if
+// there is no such node.
+func (n *Network) SetAwake(nodeID uint8, awake bool) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	node := n.nodes[nodeID]
+	if node == nil {
+		return false
+	}
+	if node.Asleep != awake {
+		return true
+	}
+	node.Asleep = !awake
+	if !awake {
+		n.notifyCodeLocked(node.ID, goopenzwave.NotificationCodeSleep)
+		return true
+	}
+	n.notifyCodeLocked(node.ID, goopenzwave.NotificationCodeAwake)
+	pending := node.pending
+	node.pending = nil
+	for _, msg := range pending {
+		n.transmitLocked(node, msg)
+	}
+	if n.driverAdded {
+		n.queryNodeLocked(node)
+	}
+	return true
+}
+
+// Log writes a line to the OpenZWave log, passing it to the LogHandler if
+// there is one. Like OpenZWave, lines about a node are prefixed with
+// "Node004, " and lines more detailed than the SaveLogLevel option (Detail by
+// default) are dropped.
+func (n *Network) Log(level goopenzwave.LogLevel, nodeID uint8, message string) {
+	n.mu.Lock()
+	handler := n.logHandler
+	saveLevel, ok := n.options["SaveLogLevel"].(int32)
+	n.mu.Unlock()
+	if !ok {
+		saveLevel = int32(goopenzwave.LogLevelDetail)
+	}
+	if handler == nil || (int32(level) > saveLevel && level != goopenzwave.LogLevelAlways) {
+		return
+	}
+	if nodeID != 0 {
+		message = fmt.Sprintf("Node%03d, %s", nodeID, message)
+	}
+	handler(level, nodeID, message)
+}
+
+// InjectTimeouts causes the next count messages sent to the node to time out,
+// sending a Timeout notification in place of their effects. It returns false
+// if there is no such node.
+func (n *Network) InjectTimeouts(nodeID uint8, count int) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	node := n.nodes[nodeID]
+	if node == nil {
+		return false
+	}
+	node.timeouts = count
+	return true
+}
+
+// UpdateValue simulates the node reporting new data for one of its values, as
+// if it had been changed locally on the device. A ValueChanged notification is
+// sent, or ValueRefreshed if the data is unchanged. It returns false if there
+// is no such value or the data is of the wrong Go type for the value.
+func (n *Network) UpdateValue(nodeID uint8, commandClassID, instance, index uint8, data interface{}) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	node := n.nodes[nodeID]
+	if node == nil {
+		return false
+	}
+	value := node.findValue(commandClassID, instance, index)
+	if value == nil || !sameType(value.Data, data) {
+		return false
+	}
+	node.stats.ReceivedUnsolicited++
+	node.receivedLocked(commandClassID, time.Now())
+	n.updateLocked(value, data)
+	return true
+}
+
+// Include simulates the user pressing the inclusion button on a new device
+// while an AddNode or ReplaceFailedNode controller command is waiting. The
+// node is added to the network and announced. It returns false if no such
+// command is in progress, or if the node cannot be added.
+func (n *Network) Include(node *Node) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.command == nil {
+		return false
+	}
+	switch n.command.typ {
+	case controllerCommandAddDevice:
+		if _, found := n.nodes[node.ID]; found {
+			return false
+		}
+	case controllerCommandReplaceFailedNode:
+		old := n.nodes[n.command.nodeID]
+		if node.ID != n.command.nodeID || old == nil {
+			return false
+		}
+		n.removeNodeLocked(old)
+	default:
+		return false
+	}
+	n.command = nil
+	n.notifyControllerLocked(goopenzwave.ControllerStateInProgress, goopenzwave.ControllerErrorNone)
+	n.addNodeLocked(node)
+	n.notifyNodeLocked(goopenzwave.NotificationTypeNodeNew, node.ID)
+	n.announceNodeLocked(node)
+	n.notifyControllerLocked(goopenzwave.ControllerStateCompleted, goopenzwave.ControllerErrorNone)
+	n.queryNodeLocked(node)
+	return true
+}
+
+// Exclude simulates the user pressing the exclusion button on a device while a
+// RemoveNode controller command is waiting. The node is removed from the
+// network. It returns false if no such command is in progress or there is no
+// such node.
+func (n *Network) Exclude(nodeID uint8) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.command == nil || n.command.typ != controllerCommandRemoveDevice {
+		return false
+	}
+	node := n.nodes[nodeID]
+	if node == nil || node.ID == n.controllerID {
+		return false
+	}
+	n.command = nil
+	n.notifyControllerLocked(goopenzwave.ControllerStateInProgress, goopenzwave.ControllerErrorNone)
+	n.removeNodeLocked(node)
+	n.notifyNodeLocked(goopenzwave.NotificationTypeNodeRemoved, nodeID)
+	n.notifyControllerLocked(goopenzwave.ControllerStateCompleted, goopenzwave.ControllerErrorNone)
+	return true
+}
+
+// sameType returns true if a and b hold the same Go type.
+func sameType(a, b interface{}) bool {
+	switch a.(type) {
+	case bool:
+		_, ok := b.(bool)
+		return ok
+	case byte:
+		_, ok := b.(byte)
+		return ok
+	case goopenzwave.Decimal:
+		_, ok := b.(goopenzwave.Decimal)
+		return ok
+	case int32:
+		_, ok := b.(int32)
+		return ok
+	case int16:
+		_, ok := b.(int16)
+		return ok
+	case string:
+		_, ok := b.(string)
+		return ok
+	case []byte:
+		_, ok := b.([]byte)
+		return ok
+	case []SwitchPoint:
+		_, ok := b.([]SwitchPoint)
+		return ok
+	case uint32:
+		_, ok := b.(uint32)
+		return ok
+	}
+	return false
+}
@@ -0,0 +1,456 @@
+package sim
+
+import (
+	"sort"
+
+	"github.com/jimjibone/goopenzwave"
+)
+
+// Version of OpenZWave reported by the simulated network.
+const (
+	versionMajor uint16 = 1
+	versionMinor uint16 = 4
+)
+
+//
+// Construction.
+//
+
+func (n *Network) CreateManager() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.managerCreated {
+		return false
+	}
+	n.managerCreated = true
+	return true
+}
+
+func (n *Network) DestroyManager() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.removeDriverLocked()
+	n.removeWatcherLocked()
+	n.logHandler = nil
+	n.managerCreated = false
+}
+
+func (n *Network) GetVersionAsString() string {
+	return "1.4.0"
+}
+
+func (n *Network) GetVersionLongAsString() string {
+	return "1.4.0 (simulated)"
+}
+
+func (n *Network) GetVersion() (major uint16, minor uint16) {
+	return versionMajor, versionMinor
+}
+
+//
+// Options.
+//
+
+func (n *Network) CreateOptions(configPath, userPath, commandLine string) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.optionsCreated {
+		return false
+	}
+	n.optionsCreated = true
+	n.optionsLocked = false
+	n.options = map[string]interface{}{
+		"ConfigPath":  configPath,
+		"UserPath":    userPath,
+		"CommandLine": commandLine,
+	}
+	return true
+}
+
+func (n *Network) DestroyOptions() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if !n.optionsCreated {
+		return false
+	}
+	n.optionsCreated = false
+	n.optionsLocked = false
+	n.options = make(map[string]interface{})
+	return true
+}
+
+func (n *Network) LockOptions() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if !n.optionsCreated {
+		return false
+	}
+	n.optionsLocked = true
+	return true
+}
+
+func (n *Network) AreOptionsLocked() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.optionsLocked
+}
+
+// addOption stores the option if the options may still be modified.
+func (n *Network) addOption(name string, value interface{}) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if !n.optionsCreated || n.optionsLocked {
+		return false
+	}
+	n.options[name] = value
+	return true
+}
+
+func (n *Network) AddOptionBool(name string, value bool) bool {
+	return n.addOption(name, value)
+}
+
+func (n *Network) AddOptionInt(name string, value int32) bool {
+	return n.addOption(name, value)
+}
+
+func (n *Network) AddOptionLogLevel(name string, value goopenzwave.LogLevel) bool {
+	return n.addOption(name, int32(value))
+}
+
+func (n *Network) AddOptionString(name string, value string, append bool) bool {
+	if append {
+		n.mu.Lock()
+		if existing, ok := n.options[name].(string); ok && existing != "" {
+			value = existing + "," + value
+		}
+		n.mu.Unlock()
+	}
+	return n.addOption(name, value)
+}
+
+func (n *Network) GetOptionAsBool(name string) (bool, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	value, ok := n.options[name].(bool)
+	return ok, value
+}
+
+func (n *Network) GetOptionAsInt(name string) (bool, int32) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	value, ok := n.options[name].(int32)
+	return ok, value
+}
+
+func (n *Network) GetOptionAsString(name string) (bool, string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	value, ok := n.options[name].(string)
+	return ok, value
+}
+
+//
+// Configuration.
+//
+
+func (n *Network) WriteConfig(homeID uint32) {
+}
+
+//
+// Drivers.
+//
+
+func (n *Network) AddDriver(controllerPath string) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if !n.managerCreated || n.driverAdded || controllerPath != n.controllerPath {
+		return false
+	}
+	n.driverAdded = true
+	n.allQueried = false
+	n.awakeQueried = false
+
+	n.notifyNodeLocked(goopenzwave.NotificationTypeDriverReady, n.controllerID)
+	nodes := n.sortedNodesLocked()
+	for _, node := range nodes {
+		n.announceNodeLocked(node)
+	}
+	for _, node := range nodes {
+		n.queryNodeLocked(node)
+	}
+	return true
+}
+
+func (n *Network) RemoveDriver(controllerPath string) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if !n.driverAdded || controllerPath != n.controllerPath {
+		return false
+	}
+	n.removeDriverLocked()
+	return true
+}
+
+func (n *Network) GetControllerNodeID(homeID uint32) uint8 {
+	return n.controllerID
+}
+
+func (n *Network) GetSUCNodeID(homeID uint32) uint8 {
+	return n.controllerID
+}
+
+func (n *Network) IsPrimaryController(homeID uint32) bool {
+	return true
+}
+
+func (n *Network) IsStaticUpdateController(homeID uint32) bool {
+	return true
+}
+
+func (n *Network) IsBridgeController(homeID uint32) bool {
+	return false
+}
+
+func (n *Network) GetLibraryVersion(homeID uint32) string {
+	return "Z-Wave 4.05"
+}
+
+func (n *Network) GetLibraryTypeName(homeID uint32) string {
+	return "Static Controller"
+}
+
+func (n *Network) GetSendQueueCount(homeID uint32) int32 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var count int32
+	for _, node := range n.nodes {
+		count += int32(len(node.pending))
+	}
+	return count
+}
+
+func (n *Network) LogDriverStatistics(homeID uint32) {
+}
+
+func (n *Network) GetDriverStatistics(homeID uint32) goopenzwave.DriverStatistics {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if homeID != n.homeID || !n.driverAdded {
+		return goopenzwave.DriverStatistics{}
+	}
+	return n.stats
+}
+
+func (n *Network) GetControllerPath(homeID uint32) string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if homeID != n.homeID || !n.driverAdded {
+		return ""
+	}
+	return n.controllerPath
+}
+
+// sortedNodesLocked returns the nodes of the network in node ID order.
+func (n *Network) sortedNodesLocked() []*Node {
+	nodes := make([]*Node, 0, len(n.nodes))
+	for _, node := range n.nodes {
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID < nodes[j].ID
+	})
+	return nodes
+}
+
+// announceNodeLocked sends the notifications OpenZWave sends when it first
+// loads a node: NodeAdded, NodeProtocolInfo, NodeNaming, a ValueAdded for each
+// value and finally EssentialNodeQueriesComplete.
+func (n *Network) announceNodeLocked(node *Node) {
+	n.notifyNodeLocked(goopenzwave.NotificationTypeNodeAdded, node.ID)
+	n.notifyNodeLocked(goopenzwave.NotificationTypeNodeProtocolInfo, node.ID)
+	n.notifyNodeLocked(goopenzwave.NotificationTypeNodeNaming, node.ID)
+	for _, value := range node.Values {
+		n.notifyValueLocked(goopenzwave.NotificationTypeValueAdded, value)
+	}
+	n.notifyNodeLocked(goopenzwave.NotificationTypeEssentialNodeQueriesComplete, node.ID)
+}
+
+// queryNodeLocked completes the queries of the node if it is awake and alive,
+// then checks whether the whole network has been queried.
+func (n *Network) queryNodeLocked(node *Node) {
+	switch {
+	case node.queried:
+	case node.Dead:
+		n.notifyCodeLocked(node.ID, goopenzwave.NotificationCodeDead)
+	case node.Asleep:
+		n.notifyCodeLocked(node.ID, goopenzwave.NotificationCodeSleep)
+	default:
+		node.queried = true
+		n.notifyNodeLocked(goopenzwave.NotificationTypeNodeQueriesComplete, node.ID)
+	}
+	n.checkQueriedLocked()
+}
+
+// checkQueriedLocked sends AwakeNodesQueried once all of the awake nodes have
+// been queried, and AllNodesQueried or AllNodesQueriedSomeDead once every node
+// has been queried or found dead.
+func (n *Network) checkQueriedLocked() {
+	if !n.driverAdded || n.allQueried {
+		return
+	}
+	var sleeping, dead int
+	for _, node := range n.nodes {
+		switch {
+		case node.queried:
+		case node.Dead:
+			dead++
+		case node.Asleep:
+			sleeping++
+		default:
+			return
+		}
+	}
+	switch {
+	case sleeping > 0:
+		if !n.awakeQueried {
+			n.awakeQueried = true
+			n.notifyNodeLocked(goopenzwave.NotificationTypeAwakeNodesQueried, n.controllerID)
+		}
+	case dead > 0:
+		n.allQueried = true
+		n.notifyNodeLocked(goopenzwave.NotificationTypeAllNodesQueriedSomeDead, n.controllerID)
+	default:
+		n.allQueried = true
+		n.notifyNodeLocked(goopenzwave.NotificationTypeAllNodesQueried, n.controllerID)
+	}
+}
+
+// removeDriverLocked resets the network as if the controller was removed.
+func (n *Network) removeDriverLocked() {
+	if !n.driverAdded {
+		return
+	}
+	n.driverAdded = false
+	n.command = nil
+	for _, node := range n.nodes {
+		node.queried = false
+		node.pending = nil
+	}
+	n.notifyNodeLocked(goopenzwave.NotificationTypeDriverRemoved, n.controllerID)
+}
+
+//
+// Polling Z-Wave devices.
+//
+
+func (n *Network) GetPollInterval() int32 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.pollInterval
+}
+
+func (n *Network) SetPollInterval(milliseconds int32, intervalBetweenPolls bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.pollInterval = milliseconds
+	n.intervalBetweenPolls = intervalBetweenPolls
+}
+
+func (n *Network) EnablePoll(homeID uint32, valueID uint64, intensity uint8) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	value := n.valueLocked(homeID, valueID)
+	if value == nil {
+		return false
+	}
+	if intensity == 0 {
+		intensity = 1
+	}
+	value.intensity = intensity
+	n.notifyValueLocked(goopenzwave.NotificationTypePollingEnabled, value)
+	return true
+}
+
+func (n *Network) DisablePoll(homeID uint32, valueID uint64) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	value := n.valueLocked(homeID, valueID)
+	if value == nil || value.intensity == 0 {
+		return false
+	}
+	value.intensity = 0
+	n.notifyValueLocked(goopenzwave.NotificationTypePollingDisabled, value)
+	return true
+}
+
+func (n *Network) IsPolled(homeID uint32, valueID uint64) bool {
+	return n.GetPollIntensity(homeID, valueID) > 0
+}
+
+func (n *Network) SetPollIntensity(homeID uint32, valueID uint64, intensity uint8) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if value := n.valueLocked(homeID, valueID); value != nil {
+		value.intensity = intensity
+	}
+}
+
+func (n *Network) GetPollIntensity(homeID uint32, valueID uint64) uint8 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if value := n.valueLocked(homeID, valueID); value != nil {
+		return value.intensity
+	}
+	return 0
+}
+
+//
+// Notifications.
+//
+
+func (n *Network) AddWatcher(watcher goopenzwave.NotificationHandler) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.watcher != nil || watcher == nil {
+		return false
+	}
+	n.watcher = watcher
+	n.stop = make(chan struct{})
+	go n.deliver(watcher, n.stop)
+	return true
+}
+
+func (n *Network) RemoveWatcher() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.removeWatcherLocked()
+}
+
+// removeWatcherLocked stops the delivery of notifications and discards any
+// which are still queued.
+func (n *Network) removeWatcherLocked() bool {
+	if n.watcher == nil {
+		return false
+	}
+	close(n.stop)
+	n.watcher = nil
+	n.stop = nil
+	n.queue = nil
+	n.cond.Broadcast()
+	return true
+}
+
+//
+// Logging.
+//
+
+func (n *Network) SetLogHandler(handler goopenzwave.LogHandler) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if !n.managerCreated {
+		return false
+	}
+	n.logHandler = handler
+	return true
+}
@@ -0,0 +1,275 @@
+package sim
+
+import (
+	"github.com/jimjibone/goopenzwave"
+)
+
+// controllerCommandType identifies a controller command which waits for the
+// user to operate a device.
+type controllerCommandType int
+
+const (
+	controllerCommandAddDevice controllerCommandType = iota
+	controllerCommandRemoveDevice
+	controllerCommandReplaceFailedNode
+)
+
+// controllerCommand is the controller command currently in progress.
+type controllerCommand struct {
+	typ    controllerCommandType
+	nodeID uint8
+}
+
+// notifyControllerLocked queues a ControllerCommand notification. As with
+// OpenZWave 1.4, the notification does not carry the node ID of the command.
+func (n *Network) notifyControllerLocked(state goopenzwave.ControllerState, err goopenzwave.ControllerError) {
+	event := uint8(state)
+	n.notifyLocked(&goopenzwave.Notification{
+		Type:            goopenzwave.NotificationTypeControllerCommand,
+		HomeID:          n.homeID,
+		Event:           &event,
+		ControllerState: &state,
+		ControllerError: &err,
+	})
+}
+
+// beginCommandLocked starts a controller command which completes when the
+// user operates a device, see Include and Exclude. It returns false if
+// another command is already in progress.
+func (n *Network) beginCommandLocked(homeID uint32, typ controllerCommandType, nodeID uint8) bool {
+	if homeID != n.homeID || !n.driverAdded || n.command != nil {
+		return false
+	}
+	n.command = &controllerCommand{typ: typ, nodeID: nodeID}
+	n.notifyControllerLocked(goopenzwave.ControllerStateStarting, goopenzwave.ControllerErrorNone)
+	n.notifyControllerLocked(goopenzwave.ControllerStateWaiting, goopenzwave.ControllerErrorNone)
+	return true
+}
+
+// runCommandLocked runs a controller command against a node which completes
+// immediately, failing if the node is dead.
+func (n *Network) runCommandLocked(homeID uint32, nodeID uint8) bool {
+	if homeID != n.homeID || !n.driverAdded || n.command != nil {
+		return false
+	}
+	node := n.nodes[nodeID]
+	if node == nil {
+		n.notifyControllerLocked(goopenzwave.ControllerStateFailed, goopenzwave.ControllerErrorNodeNotFound)
+		return true
+	}
+	n.notifyControllerLocked(goopenzwave.ControllerStateStarting, goopenzwave.ControllerErrorNone)
+	n.notifyControllerLocked(goopenzwave.ControllerStateInProgress, goopenzwave.ControllerErrorNone)
+	if node.Dead {
+		n.notifyControllerLocked(goopenzwave.ControllerStateFailed, goopenzwave.ControllerErrorFailed)
+		return true
+	}
+	n.notifyControllerLocked(goopenzwave.ControllerStateCompleted, goopenzwave.ControllerErrorNone)
+	return true
+}
+
+// failCommand reports a controller command which the simulated controller
+// does not support.
+func (n *Network) failCommand(homeID uint32) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if homeID != n.homeID || !n.driverAdded || n.command != nil {
+		return false
+	}
+	n.notifyControllerLocked(goopenzwave.ControllerStateFailed, goopenzwave.ControllerErrorFailed)
+	return true
+}
+
+//
+// Controller commands.
+//
+
+func (n *Network) ResetController(homeID uint32) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if homeID != n.homeID {
+		return
+	}
+	for _, node := range n.sortedNodesLocked() {
+		if node.ID != n.controllerID {
+			n.removeNodeLocked(node)
+		}
+	}
+	n.command = nil
+	n.notifyNodeLocked(goopenzwave.NotificationTypeDriverReset, n.controllerID)
+}
+
+func (n *Network) SoftReset(homeID uint32) {
+}
+
+func (n *Network) CancelControllerCommand(homeID uint32) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if homeID != n.homeID || n.command == nil {
+		return false
+	}
+	n.command = nil
+	n.notifyControllerLocked(goopenzwave.ControllerStateCancel, goopenzwave.ControllerErrorNone)
+	return true
+}
+
+//
+// Network commands.
+//
+
+func (n *Network) TestNetworkNode(homeID uint32, nodeID uint8, count uint32) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		n.sendLocked(node, ccNoOperation, func() {
+			n.notifyCodeLocked(node.ID, goopenzwave.NotificationCodeNoOperation)
+		})
+	})
+}
+
+func (n *Network) TestNetwork(homeID uint32, count uint32) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if homeID != n.homeID {
+		return
+	}
+	for _, node := range n.sortedNodesLocked() {
+		if node.ID == n.controllerID {
+			continue
+		}
+		n.sendLocked(node, ccNoOperation, func() {
+			n.notifyCodeLocked(node.ID, goopenzwave.NotificationCodeNoOperation)
+		})
+	}
+}
+
+func (n *Network) HealNetworkNode(homeID uint32, nodeID uint8, doRR bool) {
+}
+
+func (n *Network) HealNetwork(homeID uint32, doRR bool) {
+}
+
+func (n *Network) AddNode(homeID uint32, doSecurity bool) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.beginCommandLocked(homeID, controllerCommandAddDevice, 0)
+}
+
+func (n *Network) RemoveNode(homeID uint32) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.beginCommandLocked(homeID, controllerCommandRemoveDevice, 0)
+}
+
+func (n *Network) RemoveFailedNode(homeID uint32, nodeID uint8) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if homeID != n.homeID || !n.driverAdded || n.command != nil {
+		return false
+	}
+	node := n.nodes[nodeID]
+	switch {
+	case node == nil:
+		n.notifyControllerLocked(goopenzwave.ControllerStateFailed, goopenzwave.ControllerErrorNotFound)
+	case !node.Dead:
+		n.notifyControllerLocked(goopenzwave.ControllerStateStarting, goopenzwave.ControllerErrorNone)
+		n.notifyControllerLocked(goopenzwave.ControllerStateNodeOK, goopenzwave.ControllerErrorNone)
+	default:
+		n.notifyControllerLocked(goopenzwave.ControllerStateStarting, goopenzwave.ControllerErrorNone)
+		n.notifyControllerLocked(goopenzwave.ControllerStateInProgress, goopenzwave.ControllerErrorNone)
+		n.removeNodeLocked(node)
+		n.notifyNodeLocked(goopenzwave.NotificationTypeNodeRemoved, nodeID)
+		n.notifyControllerLocked(goopenzwave.ControllerStateCompleted, goopenzwave.ControllerErrorNone)
+	}
+	return true
+}
+
+func (n *Network) HasNodeFailed(homeID uint32, nodeID uint8) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if homeID != n.homeID || !n.driverAdded || n.command != nil {
+		return false
+	}
+	node := n.nodes[nodeID]
+	switch {
+	case node == nil:
+		n.notifyControllerLocked(goopenzwave.ControllerStateFailed, goopenzwave.ControllerErrorNotFound)
+	case node.Dead:
+		n.notifyControllerLocked(goopenzwave.ControllerStateStarting, goopenzwave.ControllerErrorNone)
+		n.notifyControllerLocked(goopenzwave.ControllerStateNodeFailed, goopenzwave.ControllerErrorNone)
+	default:
+		n.notifyControllerLocked(goopenzwave.ControllerStateStarting, goopenzwave.ControllerErrorNone)
+		n.notifyControllerLocked(goopenzwave.ControllerStateNodeOK, goopenzwave.ControllerErrorNone)
+	}
+	return true
+}
+
+func (n *Network) RequestNodeNeighborUpdate(homeID uint32, nodeID uint8) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.runCommandLocked(homeID, nodeID)
+}
+
+func (n *Network) AssignReturnRoute(homeID uint32, nodeID uint8) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.runCommandLocked(homeID, nodeID)
+}
+
+func (n *Network) DeleteAllReturnRoutes(homeID uint32, nodeID uint8) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.runCommandLocked(homeID, nodeID)
+}
+
+func (n *Network) SendNodeInformation(homeID uint32, nodeID uint8) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.runCommandLocked(homeID, nodeID)
+}
+
+func (n *Network) CreateNewPrimary(homeID uint32) bool {
+	return n.failCommand(homeID)
+}
+
+func (n *Network) ReceiveConfiguration(homeID uint32) bool {
+	return n.failCommand(homeID)
+}
+
+func (n *Network) ReplaceFailedNode(homeID uint32, nodeID uint8) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if homeID != n.homeID || !n.driverAdded || n.command != nil {
+		return false
+	}
+	node := n.nodes[nodeID]
+	switch {
+	case node == nil:
+		n.notifyControllerLocked(goopenzwave.ControllerStateFailed, goopenzwave.ControllerErrorNotFound)
+		return true
+	case !node.Dead:
+		n.notifyControllerLocked(goopenzwave.ControllerStateStarting, goopenzwave.ControllerErrorNone)
+		n.notifyControllerLocked(goopenzwave.ControllerStateNodeOK, goopenzwave.ControllerErrorNone)
+		return true
+	}
+	return n.beginCommandLocked(homeID, controllerCommandReplaceFailedNode, nodeID)
+}
+
+func (n *Network) TransferPrimaryRole(homeID uint32) bool {
+	return n.failCommand(homeID)
+}
+
+func (n *Network) RequestNetworkUpdate(homeID uint32, nodeID uint8) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.runCommandLocked(homeID, nodeID)
+}
+
+func (n *Network) ReplicationSend(homeID uint32, nodeID uint8) bool {
+	return n.failCommand(homeID)
+}
+
+func (n *Network) CreateButton(homeID uint32, nodeID uint8, buttonID uint8) bool {
+	return n.failCommand(homeID)
+}
+
+func (n *Network) DeleteButton(homeID uint32, nodeID uint8, buttonID uint8) bool {
+	return n.failCommand(homeID)
+}
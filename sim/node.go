@@ -0,0 +1,259 @@
+package sim
+
+import (
+	"sort"
+	"time"
+
+	"github.com/jimjibone/goopenzwave"
+)
+
+// Node is a simulated Z-Wave device. The exported fields describe the device
+// and may be set freely before the node is added to a Network. Use the
+// constructors in devices.go for common device types.
+type Node struct {
+	ID uint8
+
+	BasicType    uint8
+	GenericType  uint8
+	SpecificType uint8
+	Type         string
+
+	ManufacturerName string
+	ManufacturerID   string
+	ProductName      string
+	ProductType      string
+	ProductID        string
+	Name             string
+	Location         string
+
+	Listening         bool
+	FrequentListening bool
+	Beaming           bool
+	Routing           bool
+	Security          bool
+	MaxBaudRate       uint32
+	Version           uint8
+
+	ZWavePlus  bool
+	DeviceType uint16
+	Role       uint8
+	PlusType   uint8
+
+	// CommandClasses maps the IDs of the command classes supported by the
+	// node to their version. Command classes used by Values are supported
+	// automatically with version 1.
+	CommandClasses map[uint8]uint8
+
+	Values []*Value
+	Groups []*Group
+
+	// Neighbors holds the IDs of the nodes in direct range of the node. If it
+	// is nil the node is in range of every node whose own Neighbors does not
+	// exclude it.
+	Neighbors []uint8
+
+	// Asleep and Dead set the initial state of the node. Change them once
+	// the node is added to a Network with Network.SetAwake and
+	// Network.SetDead.
+	Asleep bool
+	Dead   bool
+
+	homeID   uint32
+	queried  bool
+	timeouts int
+	pending  []*message
+	stats    goopenzwave.NodeStatistics
+	ccStats  map[uint8]*goopenzwave.CommandClassStatistics
+}
+
+// message is a message sent to a node. Messages to a sleeping node are held
+// until it wakes up.
+type message struct {
+	commandClassID uint8
+	queued         time.Time
+	fn             func()
+}
+
+// Value is a value held by a simulated Node. The Data field holds the current
+// value and must be of the Go type that matches Type:
+//
+//	ValueIDTypeBool, ValueIDTypeButton: bool
+//	ValueIDTypeByte:                    byte
+//	ValueIDTypeDecimal:                 goopenzwave.Decimal
+//	ValueIDTypeInt:                     int32
+//	ValueIDTypeList:                    int32 (the index of the selected Item)
+//	ValueIDTypeSchedule:                []SwitchPoint
+//	ValueIDTypeShort:                   int16
+//	ValueIDTypeString:                  string
+//	ValueIDTypeRaw:                     []byte
+//	ValueIDTypeBitSet:                  uint32
+type Value struct {
+	Genre          goopenzwave.ValueIDGenre
+	CommandClassID uint8
+	Instance       uint8
+	Index          uint8
+	Type           goopenzwave.ValueIDType
+
+	Label     string
+	Units     string
+	Help      string
+	Min       int32
+	Max       int32
+	ReadOnly  bool
+	WriteOnly bool
+	Precision uint8
+	Items     []string
+	BitMask   uint32 // The valid bits of a BitSet value.
+	Size      uint8  // The size of a BitSet value in bytes.
+	Data      interface{}
+
+	node      *Node
+	set       bool
+	verified  bool
+	intensity uint8
+}
+
+// Group is an association group of a simulated Node.
+type Group struct {
+	Index           uint8
+	Label           string
+	MaxAssociations uint8
+	Members         []Association
+}
+
+// Association is a member of an association Group.
+type Association = goopenzwave.Association
+
+// SwitchPoint is a single entry in a climate control schedule value.
+type SwitchPoint struct {
+	Hours   uint8
+	Minutes uint8
+	Setback int8
+}
+
+// ID returns the OpenZWave 64-bit identifier of the value, or 0 if its Genre
+// or Type cannot be encoded.
+func (v *Value) ID() uint64 {
+	var nodeID uint8
+	if v.node != nil {
+		nodeID = v.node.ID
+	}
+	valueID, err := goopenzwave.NewValueID(0, nodeID, v.Genre, v.CommandClassID, v.Instance, v.Index, v.Type)
+	if err != nil {
+		return 0
+	}
+	return valueID.ID
+}
+
+// valueID returns the goopenzwave ValueID describing the value.
+func (v *Value) valueID() *goopenzwave.ValueID {
+	return &goopenzwave.ValueID{
+		HomeID:         v.node.homeID,
+		NodeID:         v.node.ID,
+		Genre:          v.Genre,
+		CommandClassID: v.CommandClassID,
+		Instance:       v.Instance,
+		Index:          v.Index,
+		Type:           v.Type,
+		ID:             v.ID(),
+	}
+}
+
+// group returns the association group with the index, or nil.
+func (n *Node) group(groupIDx uint8) *Group {
+	for _, group := range n.Groups {
+		if group.Index == groupIDx {
+			return group
+		}
+	}
+	return nil
+}
+
+// findValue returns the first value with the command class and index, or nil.
+func (n *Node) findValue(commandClassID, instance, index uint8) *Value {
+	for _, value := range n.Values {
+		if value.CommandClassID == commandClassID && value.Instance == instance && value.Index == index {
+			return value
+		}
+	}
+	return nil
+}
+
+// levelValue returns the value used by SetNodeOn, SetNodeOff and SetNodeLevel.
+func (n *Node) levelValue() *Value {
+	for _, commandClassID := range []uint8{ccSwitchMultilevel, ccSwitchBinary, ccBasic} {
+		if value := n.findValue(commandClassID, 1, 0); value != nil {
+			return value
+		}
+	}
+	return nil
+}
+
+// commandClassVersion returns the version of the command class supported by
+// the node, or zero if it is not supported.
+func (n *Node) commandClassVersion(commandClassID uint8) uint8 {
+	if version, found := n.CommandClasses[commandClassID]; found {
+		return version
+	}
+	for _, value := range n.Values {
+		if value.CommandClassID == commandClassID {
+			return 1
+		}
+	}
+	return 0
+}
+
+// queryStage returns the OpenZWave query stage name for the node.
+func (n *Node) queryStage() string {
+	switch {
+	case n.queried:
+		return "Complete"
+	case n.Dead:
+		return "Probe"
+	case n.Asleep:
+		return "WakeUp"
+	}
+	return "CacheLoad"
+}
+
+// countLocked counts a message sent to, or received from, the node for the
+// command class.
+func (n *Node) countLocked(commandClassID uint8, sent bool) {
+	if commandClassID == 0 {
+		return
+	}
+	if n.ccStats == nil {
+		n.ccStats = make(map[uint8]*goopenzwave.CommandClassStatistics)
+	}
+	cc := n.ccStats[commandClassID]
+	if cc == nil {
+		cc = &goopenzwave.CommandClassStatistics{CommandClassID: commandClassID}
+		n.ccStats[commandClassID] = cc
+	}
+	if sent {
+		cc.SentCount++
+	} else {
+		cc.ReceivedCount++
+	}
+}
+
+// receivedLocked records a message received from the node for the command
+// class.
+func (n *Node) receivedLocked(commandClassID uint8, now time.Time) {
+	n.stats.ReceivedCount++
+	n.stats.LastReceived = now
+	n.countLocked(commandClassID, false)
+}
+
+// statisticsLocked returns a copy of the statistics of the node.
+func (n *Node) statisticsLocked() goopenzwave.NodeStatistics {
+	stats := n.stats
+	stats.CommandClasses = nil
+	for _, cc := range n.ccStats {
+		stats.CommandClasses = append(stats.CommandClasses, *cc)
+	}
+	sort.Slice(stats.CommandClasses, func(i, j int) bool {
+		return stats.CommandClasses[i].CommandClassID < stats.CommandClasses[j].CommandClassID
+	})
+	return stats
+}
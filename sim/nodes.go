@@ -0,0 +1,560 @@
+package sim
+
+import (
+	"sort"
+
+	"github.com/jimjibone/goopenzwave"
+)
+
+// nodeLocked returns the node with the ID on the network with the Home ID, or
+// nil if there is no such node.
+func (n *Network) nodeLocked(homeID uint32, nodeID uint8) *Node {
+	if homeID != n.homeID {
+		return nil
+	}
+	return n.nodes[nodeID]
+}
+
+// withNode calls fn with the node while holding the network lock. It returns
+// false without calling fn if the node does not exist.
+func (n *Network) withNode(homeID uint32, nodeID uint8, fn func(node *Node)) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	node := n.nodeLocked(homeID, nodeID)
+	if node == nil {
+		return false
+	}
+	fn(node)
+	return true
+}
+
+// neighborsLocked returns the IDs of the neighbors of the node, sorted. Nodes
+// with nil Neighbors are in range of every node which does not exclude them.
+func (n *Network) neighborsLocked(node *Node) []uint8 {
+	if node.Neighbors != nil {
+		neighbors := append([]uint8(nil), node.Neighbors...)
+		sort.Slice(neighbors, func(i, j int) bool { return neighbors[i] < neighbors[j] })
+		return neighbors
+	}
+	var neighbors []uint8
+	for _, other := range n.sortedNodesLocked() {
+		if other == node {
+			continue
+		}
+		if other.Neighbors == nil || containsNodeID(other.Neighbors, node.ID) {
+			neighbors = append(neighbors, other.ID)
+		}
+	}
+	return neighbors
+}
+
+// containsNodeID returns true if the node ID is in the slice.
+func containsNodeID(nodeIDs []uint8, nodeID uint8) bool {
+	for _, id := range nodeIDs {
+		if id == nodeID {
+			return true
+		}
+	}
+	return false
+}
+
+// refreshNodeLocked simulates a request for the current state of the node,
+// sending ValueRefreshed for each of its readable values.
+func (n *Network) refreshNodeLocked(node *Node) {
+	n.sendLocked(node, 0, func() {
+		for _, value := range node.Values {
+			if !value.WriteOnly {
+				value.set = true
+				n.notifyValueLocked(goopenzwave.NotificationTypeValueRefreshed, value)
+			}
+		}
+	})
+}
+
+func (n *Network) RefreshNodeInfo(homeID uint32, nodeID uint8) bool {
+	return n.withNode(homeID, nodeID, func(node *Node) {
+		n.refreshNodeLocked(node)
+	})
+}
+
+func (n *Network) RequestNodeState(homeID uint32, nodeID uint8) bool {
+	return n.RefreshNodeInfo(homeID, nodeID)
+}
+
+func (n *Network) RequestNodeDynamic(homeID uint32, nodeID uint8) bool {
+	return n.RefreshNodeInfo(homeID, nodeID)
+}
+
+func (n *Network) IsNodeListeningDevice(homeID uint32, nodeID uint8) (result bool) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = node.Listening
+	})
+	return
+}
+
+func (n *Network) IsNodeFrequentListeningDevice(homeID uint32, nodeID uint8) (result bool) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = node.FrequentListening
+	})
+	return
+}
+
+func (n *Network) IsNodeBeamingDevice(homeID uint32, nodeID uint8) (result bool) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = node.Beaming
+	})
+	return
+}
+
+func (n *Network) IsNodeRoutingDevice(homeID uint32, nodeID uint8) (result bool) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = node.Routing
+	})
+	return
+}
+
+func (n *Network) IsNodeSecurityDevice(homeID uint32, nodeID uint8) (result bool) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = node.Security
+	})
+	return
+}
+
+func (n *Network) GetNodeMaxBaudRate(homeID uint32, nodeID uint8) (result uint32) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = node.MaxBaudRate
+	})
+	return
+}
+
+func (n *Network) GetNodeVersion(homeID uint32, nodeID uint8) (result uint8) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = node.Version
+	})
+	return
+}
+
+func (n *Network) GetNodeSecurity(homeID uint32, nodeID uint8) (result uint8) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		if node.Security {
+			result = 1
+		}
+	})
+	return
+}
+
+func (n *Network) IsNodeZWavePlus(homeID uint32, nodeID uint8) (result bool) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = node.ZWavePlus
+	})
+	return
+}
+
+func (n *Network) GetNodeBasicType(homeID uint32, nodeID uint8) (result uint8) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = node.BasicType
+	})
+	return
+}
+
+func (n *Network) GetNodeGenericType(homeID uint32, nodeID uint8) (result uint8) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = node.GenericType
+	})
+	return
+}
+
+func (n *Network) GetNodeSpecificType(homeID uint32, nodeID uint8) (result uint8) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = node.SpecificType
+	})
+	return
+}
+
+func (n *Network) GetNodeType(homeID uint32, nodeID uint8) (result string) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = node.Type
+	})
+	return
+}
+
+func (n *Network) GetNodeNeighbors(homeID uint32, nodeID uint8) (result []uint8) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = n.neighborsLocked(node)
+	})
+	return
+}
+
+func (n *Network) GetNodeManufacturerName(homeID uint32, nodeID uint8) (result string) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = node.ManufacturerName
+	})
+	return
+}
+
+func (n *Network) GetNodeProductName(homeID uint32, nodeID uint8) (result string) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = node.ProductName
+	})
+	return
+}
+
+func (n *Network) GetNodeName(homeID uint32, nodeID uint8) (result string) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = node.Name
+	})
+	return
+}
+
+func (n *Network) GetNodeLocation(homeID uint32, nodeID uint8) (result string) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = node.Location
+	})
+	return
+}
+
+func (n *Network) GetNodeManufacturerID(homeID uint32, nodeID uint8) (result string) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = node.ManufacturerID
+	})
+	return
+}
+
+func (n *Network) GetNodeProductType(homeID uint32, nodeID uint8) (result string) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = node.ProductType
+	})
+	return
+}
+
+func (n *Network) GetNodeProductID(homeID uint32, nodeID uint8) (result string) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = node.ProductID
+	})
+	return
+}
+
+func (n *Network) SetNodeManufacturerName(homeID uint32, nodeID uint8, manufacturerName string) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		node.ManufacturerName = manufacturerName
+		n.notifyNodeLocked(goopenzwave.NotificationTypeNodeNaming, node.ID)
+	})
+}
+
+func (n *Network) SetNodeProductName(homeID uint32, nodeID uint8, productName string) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		node.ProductName = productName
+		n.notifyNodeLocked(goopenzwave.NotificationTypeNodeNaming, node.ID)
+	})
+}
+
+func (n *Network) SetNodeName(homeID uint32, nodeID uint8, nodeName string) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		node.Name = nodeName
+		n.notifyNodeLocked(goopenzwave.NotificationTypeNodeNaming, node.ID)
+	})
+}
+
+func (n *Network) SetNodeLocation(homeID uint32, nodeID uint8, location string) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		node.Location = location
+		n.notifyNodeLocked(goopenzwave.NotificationTypeNodeNaming, node.ID)
+	})
+}
+
+// setNodeLevelLocked sets the level of the node's switch value, as the Basic
+// command class would.
+func (n *Network) setNodeLevelLocked(node *Node, level uint8) {
+	value := node.levelValue()
+	if value == nil {
+		return
+	}
+	switch value.Type {
+	case goopenzwave.ValueIDTypeBool:
+		n.setLocked(value, level > 0)
+	case goopenzwave.ValueIDTypeByte:
+		if level > 99 {
+			level = 99
+		}
+		n.setLocked(value, level)
+	}
+}
+
+func (n *Network) SetNodeOn(homeID uint32, nodeID uint8) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		n.setNodeLevelLocked(node, 0xff)
+	})
+}
+
+func (n *Network) SetNodeOff(homeID uint32, nodeID uint8) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		n.setNodeLevelLocked(node, 0)
+	})
+}
+
+func (n *Network) SetNodeLevel(homeID uint32, nodeID uint8, level uint8) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		n.setNodeLevelLocked(node, level)
+	})
+}
+
+func (n *Network) IsNodeInfoReceived(homeID uint32, nodeID uint8) (result bool) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = node.queried
+	})
+	return
+}
+
+func (n *Network) GetNodeClassInformation(homeID uint32, nodeID uint8, commandClassID uint8) (result bool, className string, classVersion uint8) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		classVersion = node.commandClassVersion(commandClassID)
+		if classVersion == 0 {
+			return
+		}
+		result = true
+		className = goopenzwave.CommandClass(commandClassID).String()
+	})
+	return
+}
+
+func (n *Network) IsNodeAwake(homeID uint32, nodeID uint8) (result bool) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = !node.Asleep
+	})
+	return
+}
+
+func (n *Network) IsNodeFailed(homeID uint32, nodeID uint8) (result bool) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = node.Dead
+	})
+	return
+}
+
+func (n *Network) GetNodeQueryStage(homeID uint32, nodeID uint8) (result string) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = node.queryStage()
+	})
+	return
+}
+
+func (n *Network) GetNodeDeviceType(homeID uint32, nodeID uint8) (result uint16) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = node.DeviceType
+	})
+	return
+}
+
+func (n *Network) GetNodeDeviceTypeString(homeID uint32, nodeID uint8) (result string) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = goopenzwave.NodeDeviceType(node.DeviceType).String()
+	})
+	return
+}
+
+func (n *Network) GetNodeRole(homeID uint32, nodeID uint8) (result uint8) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = node.Role
+	})
+	return
+}
+
+func (n *Network) GetNodeRoleString(homeID uint32, nodeID uint8) (result string) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = goopenzwave.NodeRole(node.Role).String()
+	})
+	return
+}
+
+func (n *Network) GetNodePlusType(homeID uint32, nodeID uint8) (result uint8) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = node.PlusType
+	})
+	return
+}
+
+func (n *Network) GetNodePlusTypeString(homeID uint32, nodeID uint8) (result string) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = goopenzwave.NodePlusType(node.PlusType).String()
+	})
+	return
+}
+
+//
+// Switch all.
+//
+
+// switchAllLocked sets the level of every node whose Switch All value permits
+// it.
+func (n *Network) switchAllLocked(on bool) {
+	for _, node := range n.sortedNodesLocked() {
+		value := node.findValue(ccSwitchAll, 1, 0)
+		if value == nil {
+			continue
+		}
+		mode, _ := value.Data.(int32)
+		switch {
+		case on && (mode == 2 || mode == 3):
+			n.setNodeLevelLocked(node, 0xff)
+		case !on && (mode == 1 || mode == 3):
+			n.setNodeLevelLocked(node, 0)
+		}
+	}
+}
+
+func (n *Network) SwitchAllOn(homeID uint32) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if homeID == n.homeID {
+		n.switchAllLocked(true)
+	}
+}
+
+func (n *Network) SwitchAllOff(homeID uint32) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if homeID == n.homeID {
+		n.switchAllLocked(false)
+	}
+}
+
+//
+// Configuration parameters.
+//
+
+func (n *Network) SetNodeConfigParam(homeID uint32, nodeID uint8, param uint8, value int32, size uint8) (result bool) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = true
+		target := node.findValue(ccConfiguration, 1, param)
+		if target == nil {
+			return
+		}
+		switch target.Type {
+		case goopenzwave.ValueIDTypeBool:
+			n.setLocked(target, value != 0)
+		case goopenzwave.ValueIDTypeByte:
+			n.setLocked(target, byte(value))
+		case goopenzwave.ValueIDTypeShort:
+			n.setLocked(target, int16(value))
+		case goopenzwave.ValueIDTypeInt:
+			n.setLocked(target, value)
+		case goopenzwave.ValueIDTypeList:
+			n.setLocked(target, value)
+		}
+	})
+	return
+}
+
+func (n *Network) RequestNodeConfigParam(homeID uint32, nodeID uint8, param uint8) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		if value := node.findValue(ccConfiguration, 1, param); value != nil {
+			n.refreshLocked(value)
+		}
+	})
+}
+
+func (n *Network) RequestNodeAllConfigParam(homeID uint32, nodeID uint8) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		for _, value := range node.Values {
+			if value.CommandClassID == ccConfiguration {
+				n.refreshLocked(value)
+			}
+		}
+	})
+}
+
+func (n *Network) GetNodeStatistics(homeID uint32, nodeID uint8) (stats goopenzwave.NodeStatistics) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		stats = node.statisticsLocked()
+	})
+	return
+}
+
+//
+// Groups.
+//
+
+func (n *Network) GetNumGroups(homeID uint32, nodeID uint8) (result uint8) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		result = uint8(len(node.Groups))
+	})
+	return
+}
+
+func (n *Network) GetAssociations(homeID uint32, nodeID uint8, groupIDx uint8) (result []Association) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		if group := node.group(groupIDx); group != nil && len(group.Members) > 0 {
+			result = append([]Association(nil), group.Members...)
+		}
+	})
+	return
+}
+
+func (n *Network) GetMaxAssociations(homeID uint32, nodeID uint8, groupIDx uint8) (result uint8) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		if group := node.group(groupIDx); group != nil {
+			result = group.MaxAssociations
+		}
+	})
+	return
+}
+
+func (n *Network) GetGroupLabel(homeID uint32, nodeID uint8, groupIDx uint8) (result string) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		if group := node.group(groupIDx); group != nil {
+			result = group.Label
+		}
+	})
+	return
+}
+
+// notifyGroupLocked queues a Group notification for the node's group.
+func (n *Network) notifyGroupLocked(node *Node, groupIDx uint8) {
+	n.notifyLocked(&goopenzwave.Notification{
+		Type:     goopenzwave.NotificationTypeGroup,
+		HomeID:   n.homeID,
+		NodeID:   node.ID,
+		GroupIDX: &groupIDx,
+	})
+}
+
+func (n *Network) AddAssociation(homeID uint32, nodeID uint8, groupIDx uint8, targetNodeID uint8, instance uint8) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		group := node.group(groupIDx)
+		if group == nil {
+			return
+		}
+		n.sendLocked(node, ccAssociation, func() {
+			member := Association{NodeID: targetNodeID, Instance: instance}
+			for _, existing := range group.Members {
+				if existing == member {
+					n.notifyGroupLocked(node, groupIDx)
+					return
+				}
+			}
+			if len(group.Members) < int(group.MaxAssociations) {
+				group.Members = append(group.Members, member)
+			}
+			n.notifyGroupLocked(node, groupIDx)
+		})
+	})
+}
+
+func (n *Network) RemoveAssociation(homeID uint32, nodeID uint8, groupIDx uint8, targetNodeID uint8, instance uint8) {
+	n.withNode(homeID, nodeID, func(node *Node) {
+		group := node.group(groupIDx)
+		if group == nil {
+			return
+		}
+		n.sendLocked(node, ccAssociation, func() {
+			member := Association{NodeID: targetNodeID, Instance: instance}
+			for i, existing := range group.Members {
+				if existing == member {
+					group.Members = append(group.Members[:i], group.Members[i+1:]...)
+					break
+				}
+			}
+			n.notifyGroupLocked(node, groupIDx)
+		})
+	})
+}
@@ -0,0 +1,345 @@
+package sim
+
+import (
+	"sort"
+
+	"github.com/jimjibone/goopenzwave"
+)
+
+// scene is a simulated OpenZWave scene. Its values are held in the same form
+// as Value.Data.
+type scene struct {
+	label  string
+	values map[uint64]interface{}
+	order  []uint64
+}
+
+// sceneValueLocked returns the scene and the value, or nils if either does not
+// exist.
+func (n *Network) sceneValueLocked(sceneID uint8, homeID uint32, valueID uint64) (*scene, *Value) {
+	s := n.scenes[sceneID]
+	value := n.valueLocked(homeID, valueID)
+	if s == nil || value == nil {
+		return nil, nil
+	}
+	return s, value
+}
+
+// addSceneData adds data for the value to the scene if the value is of the
+// type and is not already part of the scene.
+func (n *Network) addSceneData(sceneID uint8, homeID uint32, valueID uint64, typ goopenzwave.ValueIDType, data interface{}) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	s, value := n.sceneValueLocked(sceneID, homeID, valueID)
+	if s == nil || value.Type != typ {
+		return false
+	}
+	if _, found := s.values[valueID]; found {
+		return false
+	}
+	s.values[valueID] = data
+	s.order = append(s.order, valueID)
+	return true
+}
+
+// setSceneData replaces the data for the value in the scene if the value is of
+// the type and is already part of the scene.
+func (n *Network) setSceneData(sceneID uint8, homeID uint32, valueID uint64, typ goopenzwave.ValueIDType, data interface{}) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	s, value := n.sceneValueLocked(sceneID, homeID, valueID)
+	if s == nil || value.Type != typ {
+		return false
+	}
+	if _, found := s.values[valueID]; !found {
+		return false
+	}
+	s.values[valueID] = data
+	return true
+}
+
+// getSceneData returns the data for the value in the scene if the value is of
+// the type.
+func (n *Network) getSceneData(sceneID uint8, homeID uint32, valueID uint64, typ goopenzwave.ValueIDType) (interface{}, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	s, value := n.sceneValueLocked(sceneID, homeID, valueID)
+	if s == nil || value.Type != typ {
+		return nil, false
+	}
+	data, found := s.values[valueID]
+	return data, found
+}
+
+// parseSceneData parses the string into data for the value, or selects a list
+// item by its label.
+func (n *Network) parseSceneData(homeID uint32, valueID uint64, s string) (typ goopenzwave.ValueIDType, data interface{}, ok bool) {
+	n.withValue(homeID, valueID, func(value *Value) {
+		typ = value.Type
+		data, ok = parseData(value, s)
+	})
+	return
+}
+
+func (n *Network) GetNumScenes() uint8 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return uint8(len(n.scenes))
+}
+
+func (n *Network) GetAllScenes() []uint8 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var sceneIDs []uint8
+	for sceneID := range n.scenes {
+		sceneIDs = append(sceneIDs, sceneID)
+	}
+	sort.Slice(sceneIDs, func(i, j int) bool {
+		return sceneIDs[i] < sceneIDs[j]
+	})
+	return sceneIDs
+}
+
+func (n *Network) RemoveAllScenes(homeID uint32) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if homeID != n.homeID {
+		return
+	}
+	for _, s := range n.scenes {
+		s.values = make(map[uint64]interface{})
+		s.order = nil
+	}
+}
+
+func (n *Network) CreateScene() uint8 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for i := 0; i < 255; i++ {
+		sceneID := n.nextSceneID
+		n.nextSceneID++
+		if n.nextSceneID == 0 {
+			n.nextSceneID = 1
+		}
+		if _, found := n.scenes[sceneID]; !found {
+			n.scenes[sceneID] = &scene{values: make(map[uint64]interface{})}
+			return sceneID
+		}
+	}
+	return 0
+}
+
+func (n *Network) RemoveScene(sceneID uint8) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if _, found := n.scenes[sceneID]; !found {
+		return false
+	}
+	delete(n.scenes, sceneID)
+	return true
+}
+
+func (n *Network) AddSceneValueBool(sceneID uint8, homeID uint32, valueID uint64, value bool) bool {
+	return n.addSceneData(sceneID, homeID, valueID, goopenzwave.ValueIDTypeBool, value)
+}
+
+func (n *Network) AddSceneValueUint8(sceneID uint8, homeID uint32, valueID uint64, value uint8) bool {
+	return n.addSceneData(sceneID, homeID, valueID, goopenzwave.ValueIDTypeByte, value)
+}
+
+func (n *Network) AddSceneValueFloat(sceneID uint8, homeID uint32, valueID uint64, value float32) bool {
+	typ, data, ok := n.parseSceneData(homeID, valueID, floatString(value))
+	return ok && typ == goopenzwave.ValueIDTypeDecimal && n.addSceneData(sceneID, homeID, valueID, typ, data)
+}
+
+func (n *Network) AddSceneValueInt32(sceneID uint8, homeID uint32, valueID uint64, value int32) bool {
+	return n.addSceneData(sceneID, homeID, valueID, goopenzwave.ValueIDTypeInt, value)
+}
+
+func (n *Network) AddSceneValueInt16(sceneID uint8, homeID uint32, valueID uint64, value int16) bool {
+	return n.addSceneData(sceneID, homeID, valueID, goopenzwave.ValueIDTypeShort, value)
+}
+
+func (n *Network) AddSceneValueString(sceneID uint8, homeID uint32, valueID uint64, value string) bool {
+	typ, data, ok := n.parseSceneData(homeID, valueID, value)
+	return ok && n.addSceneData(sceneID, homeID, valueID, typ, data)
+}
+
+func (n *Network) AddSceneValueListSelectionString(sceneID uint8, homeID uint32, valueID uint64, value string) bool {
+	typ, data, ok := n.parseSceneData(homeID, valueID, value)
+	return ok && typ == goopenzwave.ValueIDTypeList && n.addSceneData(sceneID, homeID, valueID, typ, data)
+}
+
+func (n *Network) AddSceneValueListSelectionInt32(sceneID uint8, homeID uint32, valueID uint64, value int32) bool {
+	return n.addSceneData(sceneID, homeID, valueID, goopenzwave.ValueIDTypeList, value)
+}
+
+func (n *Network) RemoveSceneValue(sceneID uint8, homeID uint32, valueID uint64) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	s, _ := n.sceneValueLocked(sceneID, homeID, valueID)
+	if s == nil {
+		return false
+	}
+	if _, found := s.values[valueID]; !found {
+		return false
+	}
+	delete(s.values, valueID)
+	for i, id := range s.order {
+		if id == valueID {
+			s.order = append(s.order[:i:i], s.order[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+func (n *Network) SceneGetValues(sceneID uint8) []*goopenzwave.ValueID {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	s := n.scenes[sceneID]
+	if s == nil {
+		return nil
+	}
+	var valueIDs []*goopenzwave.ValueID
+	for _, valueID := range s.order {
+		if value := n.values[valueID]; value != nil {
+			valueIDs = append(valueIDs, value.valueID())
+		}
+	}
+	return valueIDs
+}
+
+func (n *Network) GetSceneValueAsBool(sceneID uint8, homeID uint32, valueID uint64) (bool, bool) {
+	data, ok := n.getSceneData(sceneID, homeID, valueID, goopenzwave.ValueIDTypeBool)
+	value, _ := data.(bool)
+	return value, ok
+}
+
+func (n *Network) GetSceneValueAsByte(sceneID uint8, homeID uint32, valueID uint64) (byte, bool) {
+	data, ok := n.getSceneData(sceneID, homeID, valueID, goopenzwave.ValueIDTypeByte)
+	value, _ := data.(byte)
+	return value, ok
+}
+
+func (n *Network) GetSceneValueAsFloat(sceneID uint8, homeID uint32, valueID uint64) (float32, bool) {
+	data, ok := n.getSceneData(sceneID, homeID, valueID, goopenzwave.ValueIDTypeDecimal)
+	value, _ := data.(goopenzwave.Decimal)
+	return float32(value.Float64()), ok
+}
+
+func (n *Network) GetSceneValueAsInt(sceneID uint8, homeID uint32, valueID uint64) (int32, bool) {
+	data, ok := n.getSceneData(sceneID, homeID, valueID, goopenzwave.ValueIDTypeInt)
+	value, _ := data.(int32)
+	return value, ok
+}
+
+func (n *Network) GetSceneValueAsShort(sceneID uint8, homeID uint32, valueID uint64) (int16, bool) {
+	data, ok := n.getSceneData(sceneID, homeID, valueID, goopenzwave.ValueIDTypeShort)
+	value, _ := data.(int16)
+	return value, ok
+}
+
+func (n *Network) GetSceneValueAsString(sceneID uint8, homeID uint32, valueID uint64) (result string, ok bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	s, value := n.sceneValueLocked(sceneID, homeID, valueID)
+	if s == nil {
+		return "", false
+	}
+	data, found := s.values[valueID]
+	if !found {
+		return "", false
+	}
+	return formatData(value, data), true
+}
+
+func (n *Network) GetSceneValueListSelectionString(sceneID uint8, homeID uint32, valueID uint64) (string, bool) {
+	if _, ok := n.getSceneData(sceneID, homeID, valueID, goopenzwave.ValueIDTypeList); !ok {
+		return "", false
+	}
+	return n.GetSceneValueAsString(sceneID, homeID, valueID)
+}
+
+func (n *Network) GetSceneValueListSelectionInt32(sceneID uint8, homeID uint32, valueID uint64) (int32, bool) {
+	data, ok := n.getSceneData(sceneID, homeID, valueID, goopenzwave.ValueIDTypeList)
+	value, _ := data.(int32)
+	return value, ok
+}
+
+func (n *Network) SetSceneValueBool(sceneID uint8, homeID uint32, valueID uint64, value bool) bool {
+	return n.setSceneData(sceneID, homeID, valueID, goopenzwave.ValueIDTypeBool, value)
+}
+
+func (n *Network) SetSceneValueUint8(sceneID uint8, homeID uint32, valueID uint64, value uint8) bool {
+	return n.setSceneData(sceneID, homeID, valueID, goopenzwave.ValueIDTypeByte, value)
+}
+
+func (n *Network) SetSceneValueFloat(sceneID uint8, homeID uint32, valueID uint64, value float32) bool {
+	typ, data, ok := n.parseSceneData(homeID, valueID, floatString(value))
+	return ok && typ == goopenzwave.ValueIDTypeDecimal && n.setSceneData(sceneID, homeID, valueID, typ, data)
+}
+
+func (n *Network) SetSceneValueInt32(sceneID uint8, homeID uint32, valueID uint64, value int32) bool {
+	return n.setSceneData(sceneID, homeID, valueID, goopenzwave.ValueIDTypeInt, value)
+}
+
+func (n *Network) SetSceneValueInt16(sceneID uint8, homeID uint32, valueID uint64, value int16) bool {
+	return n.setSceneData(sceneID, homeID, valueID, goopenzwave.ValueIDTypeShort, value)
+}
+
+func (n *Network) SetSceneValueString(sceneID uint8, homeID uint32, valueID uint64, value string) bool {
+	typ, data, ok := n.parseSceneData(homeID, valueID, value)
+	return ok && n.setSceneData(sceneID, homeID, valueID, typ, data)
+}
+
+func (n *Network) SetSceneValueListSelectionString(sceneID uint8, homeID uint32, valueID uint64, value string) bool {
+	typ, data, ok := n.parseSceneData(homeID, valueID, value)
+	return ok && typ == goopenzwave.ValueIDTypeList && n.setSceneData(sceneID, homeID, valueID, typ, data)
+}
+
+func (n *Network) SetSceneValueListSelectionInt32(sceneID uint8, homeID uint32, valueID uint64, value int32) bool {
+	return n.setSceneData(sceneID, homeID, valueID, goopenzwave.ValueIDTypeList, value)
+}
+
+func (n *Network) GetSceneLabel(sceneID uint8) string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if s := n.scenes[sceneID]; s != nil {
+		return s.label
+	}
+	return ""
+}
+
+func (n *Network) SetSceneLabel(sceneID uint8, value string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if s := n.scenes[sceneID]; s != nil {
+		s.label = value
+	}
+}
+
+func (n *Network) SceneExists(sceneID uint8) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	_, found := n.scenes[sceneID]
+	return found
+}
+
+func (n *Network) ActivateScene(sceneID uint8) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	s := n.scenes[sceneID]
+	if s == nil {
+		return false
+	}
+	for _, valueID := range s.order {
+		data, found := s.values[valueID]
+		value := n.values[valueID]
+		if !found || value == nil {
+			continue
+		}
+		n.setLocked(value, data)
+	}
+	return true
+}
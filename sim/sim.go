@@ -0,0 +1,228 @@
+// Package sim provides a simulated, in-memory Z-Wave network which implements
+// the goopenzwave.Backend interface. It can be installed with
+// goopenzwave.SetBackend in place of the OpenZWave library so that
+// applications can be exercised end-to-end without any Z-Wave hardware.
+//
+// The simulated network generates the same sequence of Notifications that
+// OpenZWave does when a driver is added (DriverReady, NodeAdded, ValueAdded,
+// EssentialNodeQueriesComplete, NodeQueriesComplete, AllNodesQueried...) and
+// sends ValueChanged notifications when values are set. Faults such as dead
+// nodes, sleeping nodes and message timeouts can be injected at any time.
+package sim
+
+import (
+	"sync"
+
+	"github.com/jimjibone/goopenzwave"
+)
+
+// DefaultHomeID is the Home ID used by networks created with New.
+const DefaultHomeID uint32 = 0xc0ffee00
+
+// DefaultControllerPath is the controller path that a Network responds to when
+// no other path has been set.
+const DefaultControllerPath = "/dev/ttySIM0"
+
+var _ goopenzwave.Backend = (*Network)(nil)
+
+// Network is a simulated Z-Wave network behind a single controller. Create one
+// with New, add nodes to it with Add and then install it with
+// goopenzwave.SetBackend.
+type Network struct {
+	mu sync.Mutex
+
+	homeID         uint32
+	controllerPath string
+	controllerID   uint8
+	nodes          map[uint8]*Node
+	values         map[uint64]*Value
+
+	optionsCreated bool
+	optionsLocked  bool
+	options        map[string]interface{}
+	managerCreated bool
+	driverAdded    bool
+	awakeQueried   bool
+	allQueried     bool
+
+	pollInterval         int32
+	intervalBetweenPolls bool
+
+	scenes      map[uint8]*scene
+	nextSceneID uint8
+
+	command *controllerCommand
+
+	stats goopenzwave.DriverStatistics
+
+	logHandler goopenzwave.LogHandler
+
+	watcher    goopenzwave.NotificationHandler
+	queue      []*goopenzwave.Notification
+	delivering bool
+	cond       *sync.Cond
+	stop       chan struct{}
+}
+
+// New creates a new simulated network with the DefaultHomeID and
+// DefaultControllerPath. It contains only the controller itself, which is node
+// 1. Use Add to add more devices.
+func New() *Network {
+	return NewWithHomeID(DefaultHomeID)
+}
+
+// NewWithHomeID creates a new simulated network, as New does, but using the
+// given Home ID.
+func NewWithHomeID(homeID uint32) *Network {
+	n := &Network{
+		homeID:         homeID,
+		controllerPath: DefaultControllerPath,
+		controllerID:   1,
+		nodes:          make(map[uint8]*Node),
+		values:         make(map[uint64]*Value),
+		options:        make(map[string]interface{}),
+		scenes:         make(map[uint8]*scene),
+		nextSceneID:    1,
+		pollInterval:   30000,
+	}
+	n.cond = sync.NewCond(&n.mu)
+	n.addNodeLocked(NewController(n.controllerID))
+	return n
+}
+
+// HomeID returns the Home ID of the simulated network.
+func (n *Network) HomeID() uint32 {
+	return n.homeID
+}
+
+// SetControllerPath sets the controller path that AddDriver will accept for
+// this network.
+func (n *Network) SetControllerPath(path string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.controllerPath = path
+}
+
+// Add adds the node to the network. If the driver has already been added
+// the node is announced as if it had just been included, otherwise it will be
+// announced when the driver is added. It returns false if a node with the same
+// ID already exists.
+func (n *Network) Add(node *Node) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if _, found := n.nodes[node.ID]; found {
+		return false
+	}
+	n.addNodeLocked(node)
+	if n.driverAdded {
+		n.announceNodeLocked(node)
+		n.queryNodeLocked(node)
+	}
+	return true
+}
+
+// Node returns the node with the given ID, or nil if there is no such node.
+// The returned Node must not be modified directly while the network is in use,
+// use the fault injection methods on Network instead.
+func (n *Network) Node(nodeID uint8) *Node {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.nodes[nodeID]
+}
+
+// Flush blocks until every notification generated so far has been delivered to
+// the installed watcher.
+func (n *Network) Flush() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for n.watcher != nil && (len(n.queue) > 0 || n.delivering) {
+		n.cond.Wait()
+	}
+}
+
+// addNodeLocked registers the node and its values with the network.
+func (n *Network) addNodeLocked(node *Node) {
+	node.homeID = n.homeID
+	n.nodes[node.ID] = node
+	for _, value := range node.Values {
+		value.node = node
+		n.values[value.ID()] = value
+	}
+}
+
+// removeNodeLocked removes the node and its values from the network.
+func (n *Network) removeNodeLocked(node *Node) {
+	for _, value := range node.Values {
+		delete(n.values, value.ID())
+	}
+	delete(n.nodes, node.ID)
+}
+
+// notifyLocked queues a notification for delivery to the watcher.
+func (n *Network) notifyLocked(notification *goopenzwave.Notification) {
+	if n.watcher == nil {
+		return
+	}
+	n.queue = append(n.queue, notification)
+	n.cond.Broadcast()
+}
+
+// notifyNodeLocked queues a notification of the given type for a node.
+func (n *Network) notifyNodeLocked(typ goopenzwave.NotificationType, nodeID uint8) {
+	n.notifyLocked(&goopenzwave.Notification{
+		Type:   typ,
+		HomeID: n.homeID,
+		NodeID: nodeID,
+	})
+}
+
+// notifyValueLocked queues a value notification of the given type.
+func (n *Network) notifyValueLocked(typ goopenzwave.NotificationType, value *Value) {
+	n.notifyLocked(&goopenzwave.Notification{
+		Type:    typ,
+		HomeID:  n.homeID,
+		NodeID:  value.node.ID,
+		ValueID: value.valueID(),
+	})
+}
+
+// notifyCodeLocked queues a Notification type notification with the code.
+func (n *Network) notifyCodeLocked(nodeID uint8, code goopenzwave.NotificationCode) {
+	n.notifyLocked(&goopenzwave.Notification{
+		Type:         goopenzwave.NotificationTypeNotification,
+		HomeID:       n.homeID,
+		NodeID:       nodeID,
+		Notification: &code,
+	})
+}
+
+// deliver is run in its own goroutine while a watcher is installed, passing
+// queued notifications to the watcher in order. It mimics the OpenZWave
+// notification thread.
+func (n *Network) deliver(watcher goopenzwave.NotificationHandler, stop chan struct{}) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for {
+		for len(n.queue) == 0 {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			n.cond.Wait()
+		}
+		select {
+		case <-stop:
+			return
+		default:
+		}
+		notification := n.queue[0]
+		n.queue = n.queue[1:]
+		n.delivering = true
+		n.mu.Unlock()
+		watcher(notification)
+		n.mu.Lock()
+		n.delivering = false
+		n.cond.Broadcast()
+	}
+}
@@ -0,0 +1,646 @@
+package sim
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jimjibone/goopenzwave"
+)
+
+// maxSwitchPoints is the maximum number of switch points held by a schedule.
+const maxSwitchPoints = 9
+
+// maxSendAttempts is the number of times OpenZWave sends a message before
+// giving up on it.
+const maxSendAttempts = 3
+
+// simulatedRTT is the round trip time of a message to an awake node.
+const simulatedRTT = 20 * time.Millisecond
+
+// valueLocked returns the value with the ID on the network with the Home ID,
+// or nil if there is no such value.
+func (n *Network) valueLocked(homeID uint32, valueID uint64) *Value {
+	if homeID != n.homeID {
+		return nil
+	}
+	return n.values[valueID]
+}
+
+// withValue calls fn with the value while holding the network lock. It
+// returns false without calling fn if the value does not exist.
+func (n *Network) withValue(homeID uint32, valueID uint64, fn func(value *Value)) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	value := n.valueLocked(homeID, valueID)
+	if value == nil {
+		return false
+	}
+	fn(value)
+	return true
+}
+
+// sendLocked simulates sending a message for the command class to the node.
+// If the node is dead, or a timeout has been injected, a Timeout notification
+// is sent instead. If the node is asleep the message is held until it next
+// wakes up. Otherwise fn is called to apply the effects of the message. Pass a
+// commandClassID of 0 for messages which are not counted against a command
+// class in the node statistics.
+func (n *Network) sendLocked(node *Node, commandClassID uint8, fn func()) {
+	n.transmitLocked(node, &message{commandClassID: commandClassID, queued: time.Now(), fn: fn})
+}
+
+// transmitLocked sends the message to the node, see sendLocked.
+func (n *Network) transmitLocked(node *Node, msg *message) {
+	switch {
+	case node.Dead:
+		n.timeoutLocked(node, msg)
+	case node.timeouts > 0:
+		node.timeouts--
+		n.timeoutLocked(node, msg)
+	case node.Asleep:
+		node.pending = append(node.pending, msg)
+	default:
+		n.stats.WriteCount++
+		n.stats.ACKCount++
+		n.stats.SOFCount++
+		n.stats.ReadCount++
+		now := time.Now()
+		rtt := simulatedRTT + now.Sub(msg.queued)
+		node.stats.SentCount++
+		node.stats.LastSent = now
+		node.stats.LastRequestRTT = rtt
+		node.stats.AverageRequestRTT = averageRTT(node.stats.AverageRequestRTT, rtt)
+		node.stats.LastResponseRTT = rtt + simulatedRTT
+		node.stats.AverageResponseRTT = averageRTT(node.stats.AverageResponseRTT, rtt+simulatedRTT)
+		node.countLocked(msg.commandClassID, true)
+		node.receivedLocked(msg.commandClassID, now)
+		msg.fn()
+	}
+}
+
+// timeoutLocked records a message to the node which was not acknowledged,
+// after the usual retries, and sends a Timeout notification.
+func (n *Network) timeoutLocked(node *Node, msg *message) {
+	n.stats.WriteCount += maxSendAttempts
+	n.stats.Retries += maxSendAttempts - 1
+	n.stats.NoACK++
+	n.stats.Dropped++
+	node.stats.SentCount++
+	node.stats.SentFailed++
+	node.stats.Retries += maxSendAttempts - 1
+	node.stats.LastSent = time.Now()
+	node.countLocked(msg.commandClassID, true)
+	n.notifyCodeLocked(node.ID, goopenzwave.NotificationCodeTimeout)
+}
+
+// averageRTT returns the new average round trip time after rtt, calculated in
+// the same way as OpenZWave.
+func averageRTT(average, rtt time.Duration) time.Duration {
+	if average == 0 {
+		return rtt
+	}
+	return (average + rtt) / 2
+}
+
+// setLocked sends new data for the value to its node. It returns false if the
+// value is read-only.
+func (n *Network) setLocked(value *Value, data interface{}) bool {
+	if value.ReadOnly {
+		return false
+	}
+	n.sendLocked(value.node, value.CommandClassID, func() {
+		n.updateLocked(value, data)
+	})
+	return true
+}
+
+// refreshLocked requests the current data of the value from its node.
+func (n *Network) refreshLocked(value *Value) {
+	n.sendLocked(value.node, value.CommandClassID, func() {
+		value.set = true
+		n.notifyValueLocked(goopenzwave.NotificationTypeValueRefreshed, value)
+	})
+}
+
+// updateLocked stores data reported by the node for the value and sends
+// ValueChanged, or ValueRefreshed if the data is unchanged.
+func (n *Network) updateLocked(value *Value, data interface{}) {
+	value.set = true
+	if reflect.DeepEqual(value.Data, data) {
+		n.notifyValueLocked(goopenzwave.NotificationTypeValueRefreshed, value)
+		return
+	}
+	value.Data = data
+	n.notifyValueLocked(goopenzwave.NotificationTypeValueChanged, value)
+}
+
+// formatData returns data, held in the form used by the value, as a string in
+// the same format as OpenZWave.
+func formatData(value *Value, data interface{}) string {
+	switch d := data.(type) {
+	case bool:
+		if d {
+			return "True"
+		}
+		return "False"
+	case byte:
+		return strconv.Itoa(int(d))
+	case goopenzwave.Decimal:
+		if rounded, err := d.Rescale(value.Precision); err == nil {
+			return rounded.String()
+		}
+		return d.String()
+	case int32:
+		if value.Type == goopenzwave.ValueIDTypeList {
+			if d >= 0 && int(d) < len(value.Items) {
+				return value.Items[d]
+			}
+			return ""
+		}
+		return strconv.Itoa(int(d))
+	case int16:
+		return strconv.Itoa(int(d))
+	case string:
+		return d
+	case uint32:
+		return strconv.FormatUint(uint64(d), 10)
+	case []byte:
+		parts := make([]string, len(d))
+		for i := range d {
+			parts[i] = fmt.Sprintf("0x%02x", d[i])
+		}
+		return strings.Join(parts, " ")
+	}
+	return ""
+}
+
+// parseData parses the string into the form used by the value.
+func parseData(value *Value, s string) (interface{}, bool) {
+	switch value.Type {
+	case goopenzwave.ValueIDTypeBool, goopenzwave.ValueIDTypeButton:
+		b, err := strconv.ParseBool(strings.ToLower(s))
+		return b, err == nil
+	case goopenzwave.ValueIDTypeByte:
+		i, err := strconv.ParseUint(s, 10, 8)
+		return byte(i), err == nil
+	case goopenzwave.ValueIDTypeDecimal:
+		d, err := goopenzwave.ParseDecimal(s)
+		if err != nil {
+			return nil, false
+		}
+		// The device rounds the number to its precision.
+		d, err = d.Rescale(value.Precision)
+		return d, err == nil
+	case goopenzwave.ValueIDTypeInt:
+		i, err := strconv.ParseInt(s, 10, 32)
+		return int32(i), err == nil
+	case goopenzwave.ValueIDTypeShort:
+		i, err := strconv.ParseInt(s, 10, 16)
+		return int16(i), err == nil
+	case goopenzwave.ValueIDTypeString:
+		return s, true
+	case goopenzwave.ValueIDTypeList:
+		return listIndex(value, s)
+	case goopenzwave.ValueIDTypeBitSet:
+		i, err := strconv.ParseUint(s, 10, 32)
+		return uint32(i) & value.BitMask, err == nil
+	case goopenzwave.ValueIDTypeRaw:
+		var raw []byte
+		for _, field := range strings.Fields(s) {
+			b, err := strconv.ParseUint(field, 0, 8)
+			if err != nil {
+				return nil, false
+			}
+			raw = append(raw, byte(b))
+		}
+		return raw, true
+	}
+	return nil, false
+}
+
+// floatString formats the float as the shortest decimal which reads back as
+// it, to be parsed into a decimal value.
+func floatString(f float32) string {
+	return strconv.FormatFloat(float64(f), 'f', -1, 32)
+}
+
+// listIndex returns the index of the item with the label in a list value.
+func listIndex(value *Value, label string) (interface{}, bool) {
+	for i, item := range value.Items {
+		if item == label {
+			return int32(i), true
+		}
+	}
+	return nil, false
+}
+
+//
+// Values.
+//
+
+func (n *Network) GetValueLabel(homeID uint32, valueID uint64) (result string) {
+	n.withValue(homeID, valueID, func(value *Value) {
+		result = value.Label
+	})
+	return
+}
+
+func (n *Network) SetValueLabel(homeID uint32, valueID uint64, label string) {
+	n.withValue(homeID, valueID, func(value *Value) {
+		value.Label = label
+	})
+}
+
+func (n *Network) GetValueUnits(homeID uint32, valueID uint64) (result string) {
+	n.withValue(homeID, valueID, func(value *Value) {
+		result = value.Units
+	})
+	return
+}
+
+func (n *Network) SetValueUnits(homeID uint32, valueID uint64, units string) {
+	n.withValue(homeID, valueID, func(value *Value) {
+		value.Units = units
+	})
+}
+
+func (n *Network) GetValueHelp(homeID uint32, valueID uint64) (result string) {
+	n.withValue(homeID, valueID, func(value *Value) {
+		result = value.Help
+	})
+	return
+}
+
+func (n *Network) SetValueHelp(homeID uint32, valueID uint64, help string) {
+	n.withValue(homeID, valueID, func(value *Value) {
+		value.Help = help
+	})
+}
+
+func (n *Network) GetValueMin(homeID uint32, valueID uint64) (result int32) {
+	n.withValue(homeID, valueID, func(value *Value) {
+		result = value.Min
+	})
+	return
+}
+
+func (n *Network) GetValueMax(homeID uint32, valueID uint64) (result int32) {
+	n.withValue(homeID, valueID, func(value *Value) {
+		result = value.Max
+	})
+	return
+}
+
+func (n *Network) IsValueReadOnly(homeID uint32, valueID uint64) (result bool) {
+	n.withValue(homeID, valueID, func(value *Value) {
+		result = value.ReadOnly
+	})
+	return
+}
+
+func (n *Network) IsValueWriteOnly(homeID uint32, valueID uint64) (result bool) {
+	n.withValue(homeID, valueID, func(value *Value) {
+		result = value.WriteOnly
+	})
+	return
+}
+
+func (n *Network) IsValueSet(homeID uint32, valueID uint64) (result bool) {
+	n.withValue(homeID, valueID, func(value *Value) {
+		result = value.set
+	})
+	return
+}
+
+func (n *Network) IsValuePolled(homeID uint32, valueID uint64) bool {
+	return n.IsPolled(homeID, valueID)
+}
+
+// getData returns the data of the value if it is one of the types.
+func (n *Network) getData(homeID uint32, valueID uint64, types ...goopenzwave.ValueIDType) (data interface{}, ok bool) {
+	n.withValue(homeID, valueID, func(value *Value) {
+		for _, typ := range types {
+			if value.Type == typ {
+				data, ok = value.Data, true
+				return
+			}
+		}
+	})
+	return
+}
+
+func (n *Network) GetValueAsBool(homeID uint32, valueID uint64) (bool, bool) {
+	data, ok := n.getData(homeID, valueID, goopenzwave.ValueIDTypeBool, goopenzwave.ValueIDTypeButton)
+	value, _ := data.(bool)
+	return value, ok
+}
+
+func (n *Network) GetValueAsByte(homeID uint32, valueID uint64) (byte, bool) {
+	data, ok := n.getData(homeID, valueID, goopenzwave.ValueIDTypeByte)
+	value, _ := data.(byte)
+	return value, ok
+}
+
+func (n *Network) GetValueAsFloat(homeID uint32, valueID uint64) (float32, bool) {
+	data, ok := n.getData(homeID, valueID, goopenzwave.ValueIDTypeDecimal)
+	value, _ := data.(goopenzwave.Decimal)
+	return float32(value.Float64()), ok
+}
+
+func (n *Network) GetValueAsInt(homeID uint32, valueID uint64) (int32, bool) {
+	data, ok := n.getData(homeID, valueID, goopenzwave.ValueIDTypeInt)
+	value, _ := data.(int32)
+	return value, ok
+}
+
+func (n *Network) GetValueAsShort(homeID uint32, valueID uint64) (int16, bool) {
+	data, ok := n.getData(homeID, valueID, goopenzwave.ValueIDTypeShort)
+	value, _ := data.(int16)
+	return value, ok
+}
+
+func (n *Network) GetValueAsString(homeID uint32, valueID uint64) (result string, ok bool) {
+	ok = n.withValue(homeID, valueID, func(value *Value) {
+		result = formatData(value, value.Data)
+	})
+	return
+}
+
+func (n *Network) GetValueAsRaw(homeID uint32, valueID uint64) ([]byte, bool) {
+	data, ok := n.getData(homeID, valueID, goopenzwave.ValueIDTypeRaw)
+	value, _ := data.([]byte)
+	return append([]byte(nil), value...), ok
+}
+
+func (n *Network) GetValueListSelectionAsString(homeID uint32, valueID uint64) (result string, ok bool) {
+	n.withValue(homeID, valueID, func(value *Value) {
+		if value.Type == goopenzwave.ValueIDTypeList {
+			result, ok = formatData(value, value.Data), true
+		}
+	})
+	return
+}
+
+func (n *Network) GetValueListSelectionAsInt32(homeID uint32, valueID uint64) (int32, bool) {
+	data, ok := n.getData(homeID, valueID, goopenzwave.ValueIDTypeList)
+	value, _ := data.(int32)
+	return value, ok
+}
+
+func (n *Network) GetValueListItems(homeID uint32, valueID uint64) (result []string, ok bool) {
+	n.withValue(homeID, valueID, func(value *Value) {
+		if value.Type == goopenzwave.ValueIDTypeList {
+			result, ok = append([]string(nil), value.Items...), true
+		}
+	})
+	return
+}
+
+func (n *Network) GetValueFloatPrecision(homeID uint32, valueID uint64) (result uint8, ok bool) {
+	n.withValue(homeID, valueID, func(value *Value) {
+		if value.Type == goopenzwave.ValueIDTypeDecimal {
+			result, ok = value.Precision, true
+		}
+	})
+	return
+}
+
+// bitLocked returns the bit of the mask for the position in a BitSet value,
+// numbered from 1, or false if the bit is not valid.
+func bitLocked(value *Value, pos uint8) (uint32, bool) {
+	if value.Type != goopenzwave.ValueIDTypeBitSet || pos < 1 || pos > value.Size*8 {
+		return 0, false
+	}
+	bit := uint32(1) << (pos - 1)
+	return bit, value.BitMask&bit != 0
+}
+
+func (n *Network) GetValueAsBitSet(homeID uint32, valueID uint64, pos uint8) (result bool, ok bool) {
+	n.withValue(homeID, valueID, func(value *Value) {
+		var bit uint32
+		if bit, ok = bitLocked(value, pos); ok {
+			data, _ := value.Data.(uint32)
+			result = data&bit != 0
+		}
+	})
+	return
+}
+
+func (n *Network) GetBitMask(homeID uint32, valueID uint64) (result int32, ok bool) {
+	n.withValue(homeID, valueID, func(value *Value) {
+		if value.Type == goopenzwave.ValueIDTypeBitSet {
+			result, ok = int32(value.BitMask), true
+		}
+	})
+	return
+}
+
+func (n *Network) GetBitSetSize(homeID uint32, valueID uint64) (result uint8, ok bool) {
+	n.withValue(homeID, valueID, func(value *Value) {
+		if value.Type == goopenzwave.ValueIDTypeBitSet {
+			result, ok = value.Size, true
+		}
+	})
+	return
+}
+
+// setData sets the data of the value if it is of the type.
+func (n *Network) setData(homeID uint32, valueID uint64, typ goopenzwave.ValueIDType, data interface{}) (result bool) {
+	n.withValue(homeID, valueID, func(value *Value) {
+		if value.Type == typ {
+			result = n.setLocked(value, data)
+		}
+	})
+	return
+}
+
+func (n *Network) SetValueBool(homeID uint32, valueID uint64, value bool) bool {
+	return n.setData(homeID, valueID, goopenzwave.ValueIDTypeBool, value)
+}
+
+func (n *Network) SetValueUint8(homeID uint32, valueID uint64, value uint8) bool {
+	return n.setData(homeID, valueID, goopenzwave.ValueIDTypeByte, value)
+}
+
+func (n *Network) SetValueFloat(homeID uint32, valueID uint64, f float32) (result bool) {
+	n.withValue(homeID, valueID, func(value *Value) {
+		if value.Type != goopenzwave.ValueIDTypeDecimal {
+			return
+		}
+		if data, ok := parseData(value, floatString(f)); ok {
+			result = n.setLocked(value, data)
+		}
+	})
+	return
+}
+
+func (n *Network) SetValueInt32(homeID uint32, valueID uint64, value int32) bool {
+	return n.setData(homeID, valueID, goopenzwave.ValueIDTypeInt, value)
+}
+
+func (n *Network) SetValueInt16(homeID uint32, valueID uint64, value int16) bool {
+	return n.setData(homeID, valueID, goopenzwave.ValueIDTypeShort, value)
+}
+
+func (n *Network) SetValueBytes(homeID uint32, valueID uint64, value []byte) bool {
+	return n.setData(homeID, valueID, goopenzwave.ValueIDTypeRaw, append([]byte(nil), value...))
+}
+
+func (n *Network) SetValueString(homeID uint32, valueID uint64, s string) (result bool) {
+	n.withValue(homeID, valueID, func(value *Value) {
+		if data, ok := parseData(value, s); ok {
+			result = n.setLocked(value, data)
+		}
+	})
+	return
+}
+
+func (n *Network) SetValueListSelection(homeID uint32, valueID uint64, selection string) (result bool) {
+	n.withValue(homeID, valueID, func(value *Value) {
+		if value.Type != goopenzwave.ValueIDTypeList {
+			return
+		}
+		if data, ok := listIndex(value, selection); ok {
+			result = n.setLocked(value, data)
+		}
+	})
+	return
+}
+
+func (n *Network) SetValueBitSet(homeID uint32, valueID uint64, pos uint8, set bool) (result bool) {
+	n.withValue(homeID, valueID, func(value *Value) {
+		bit, ok := bitLocked(value, pos)
+		if ok == false {
+			return
+		}
+		data, _ := value.Data.(uint32)
+		if set {
+			data |= bit
+		} else {
+			data &^= bit
+		}
+		result = n.setLocked(value, data)
+	})
+	return
+}
+
+// SetBitMask only changes the mask held by OpenZWave, so nothing is sent to the
+// node.
+func (n *Network) SetBitMask(homeID uint32, valueID uint64, mask uint32) (result bool) {
+	n.withValue(homeID, valueID, func(value *Value) {
+		if value.Type == goopenzwave.ValueIDTypeBitSet {
+			value.BitMask = mask
+			result = true
+		}
+	})
+	return
+}
+
+func (n *Network) RefreshValue(homeID uint32, valueID uint64) bool {
+	return n.withValue(homeID, valueID, func(value *Value) {
+		n.refreshLocked(value)
+	})
+}
+
+func (n *Network) SetChangeVerified(homeID uint32, valueID uint64, verify bool) {
+	n.withValue(homeID, valueID, func(value *Value) {
+		value.verified = verify
+	})
+}
+
+func (n *Network) GetChangeVerified(homeID uint32, valueID uint64) (result bool) {
+	n.withValue(homeID, valueID, func(value *Value) {
+		result = value.verified
+	})
+	return
+}
+
+func (n *Network) PressButton(homeID uint32, valueID uint64) bool {
+	return n.setData(homeID, valueID, goopenzwave.ValueIDTypeButton, true)
+}
+
+func (n *Network) ReleaseButton(homeID uint32, valueID uint64) bool {
+	return n.setData(homeID, valueID, goopenzwave.ValueIDTypeButton, false)
+}
+
+//
+// Climate control schedules.
+//
+
+// withSchedule calls fn with the switch points of a schedule value.
+func (n *Network) withSchedule(homeID uint32, valueID uint64, fn func(value *Value, points []SwitchPoint)) (result bool) {
+	n.withValue(homeID, valueID, func(value *Value) {
+		if value.Type != goopenzwave.ValueIDTypeSchedule {
+			return
+		}
+		points, _ := value.Data.([]SwitchPoint)
+		fn(value, points)
+		result = true
+	})
+	return
+}
+
+func (n *Network) GetNumSwitchPoints(homeID uint32, valueID uint64) (result uint8) {
+	n.withSchedule(homeID, valueID, func(value *Value, points []SwitchPoint) {
+		result = uint8(len(points))
+	})
+	return
+}
+
+func (n *Network) SetSwitchPoint(homeID uint32, valueID uint64, hours, minutes uint8, setback int8) (result bool) {
+	n.withSchedule(homeID, valueID, func(value *Value, points []SwitchPoint) {
+		point := SwitchPoint{Hours: hours, Minutes: minutes, Setback: setback}
+		for i := range points {
+			if points[i].Hours == hours && points[i].Minutes == minutes {
+				points[i] = point
+				result = true
+				return
+			}
+		}
+		if len(points) >= maxSwitchPoints {
+			return
+		}
+		points = append(points, point)
+		sort.Slice(points, func(i, j int) bool {
+			if points[i].Hours != points[j].Hours {
+				return points[i].Hours < points[j].Hours
+			}
+			return points[i].Minutes < points[j].Minutes
+		})
+		value.Data = points
+		result = true
+	})
+	return
+}
+
+func (n *Network) RemoveSwitchPoint(homeID uint32, valueID uint64, hours, minutes uint8) (result bool) {
+	n.withSchedule(homeID, valueID, func(value *Value, points []SwitchPoint) {
+		for i := range points {
+			if points[i].Hours == hours && points[i].Minutes == minutes {
+				value.Data = append(points[:i:i], points[i+1:]...)
+				result = true
+				return
+			}
+		}
+	})
+	return
+}
+
+func (n *Network) ClearSwitchPoints(homeID uint32, valueID uint64) {
+	n.withSchedule(homeID, valueID, func(value *Value, points []SwitchPoint) {
+		value.Data = []SwitchPoint{}
+	})
+}
+
+func (n *Network) GetSwitchPoint(homeID uint32, valueID uint64, idx uint8) (hours uint8, minutes uint8, setback int8, ok bool) {
+	n.withSchedule(homeID, valueID, func(value *Value, points []SwitchPoint) {
+		if int(idx) < len(points) {
+			hours, minutes, setback, ok = points[idx].Hours, points[idx].Minutes, points[idx].Setback, true
+		}
+	})
+	return
+}
@@ -0,0 +1,442 @@
+package goopenzwave
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Store keeps a copy of the nodes and values of a Manager's networks, along
+// with their metadata, updated from the notifications. Reads from the Store
+// return snapshots and never call into OpenZWave, so they are cheap enough to
+// render a UI from. It is safe for concurrent use.
+//
+// The Store is fed by its own Subscription, so it should be created before the
+// drivers are added in order to see every node and value. The Subscription's
+// queue grows rather than dropping notifications, so the Store is complete
+// even after the flood of notifications sent when a driver is added; Stats
+// reports how far behind it is.
+type Store struct {
+	manager *Manager
+	sub     *Subscription
+
+	mu      sync.RWMutex
+	nodes   map[storeKey]*storeNode
+	version uint64
+	updated time.Time
+}
+
+// storeKey identifies a node in the Store.
+type storeKey struct {
+	homeID uint32
+	nodeID uint8
+}
+
+// storeNode is the state of a node held by the Store.
+type storeNode struct {
+	state  NodeState
+	values map[uint64]*ValueState
+}
+
+// NodeState is a snapshot of a node held by a Store.
+type NodeState struct {
+	HomeID           uint32
+	NodeID           uint8
+	Name             string
+	Location         string
+	ManufacturerName string
+	ProductName      string
+	Type             string
+	Listening        bool
+	Awake            bool
+	Dead             bool
+	Ready            bool // All of the node's queries are complete.
+
+	Added   time.Time // When the node was first seen.
+	Updated time.Time // When the node, or one of its values, last changed.
+	Changes uint64    // The number of times the node or its values changed.
+
+	Values []ValueState // Ordered by command class, instance and index.
+}
+
+// ValueState is a snapshot of a value held by a Store.
+type ValueState struct {
+	ValueID   *ValueID
+	Label     string
+	Units     string
+	Help      string
+	Min       int32
+	Max       int32
+	ReadOnly  bool
+	WriteOnly bool
+	Value     Value // nil if the value could not be read.
+
+	Added   time.Time // When the value was first seen.
+	Updated time.Time // When the value last changed.
+	Changes uint64    // The number of ValueChanged notifications for the value.
+}
+
+// StoreSnapshot is a consistent snapshot of every node held by a Store.
+type StoreSnapshot struct {
+	Version uint64    // Increased by every change to the Store.
+	Updated time.Time // When the Store last changed.
+	Nodes   []NodeState
+}
+
+// NewStore creates a Store for the nodes and values of this Manager. Close the
+// Store when it is no longer needed.
+func (m *Manager) NewStore() *Store {
+	s := &Store{
+		manager: m,
+		nodes:   make(map[storeKey]*storeNode),
+	}
+	s.sub = m.subscribeHandler(NotificationFilter{}, s.handle, OverflowGrow)
+	return s
+}
+
+// Close stops the Store from being updated. The state it holds can still be
+// read.
+func (s *Store) Close() {
+	s.sub.Unsubscribe()
+}
+
+// Stats returns the statistics of the Store's notification queue.
+func (s *Store) Stats() QueueStats {
+	return s.sub.Stats()
+}
+
+// Version returns a number which is increased by every change to the Store, so
+// that a UI can cheaply check whether it needs to be redrawn.
+func (s *Store) Version() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.version
+}
+
+// Snapshot returns a copy of every node held by the Store, ordered by Home ID
+// and node ID.
+func (s *Store) Snapshot() StoreSnapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	snapshot := StoreSnapshot{
+		Version: s.version,
+		Updated: s.updated,
+		Nodes:   make([]NodeState, 0, len(s.nodes)),
+	}
+	for _, node := range s.nodes {
+		snapshot.Nodes = append(snapshot.Nodes, node.snapshot())
+	}
+	sort.Slice(snapshot.Nodes, func(i, j int) bool {
+		a, b := snapshot.Nodes[i], snapshot.Nodes[j]
+		if a.HomeID != b.HomeID {
+			return a.HomeID < b.HomeID
+		}
+		return a.NodeID < b.NodeID
+	})
+	return snapshot
+}
+
+// Nodes returns a copy of every node held by the Store. See Snapshot.
+func (s *Store) Nodes() []NodeState {
+	return s.Snapshot().Nodes
+}
+
+// Node returns a copy of the node, or false if the Store does not hold it.
+func (s *Store) Node(homeID uint32, nodeID uint8) (NodeState, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	node, ok := s.nodes[storeKey{homeID, nodeID}]
+	if ok == false {
+		return NodeState{}, false
+	}
+	return node.snapshot(), true
+}
+
+// Value returns a copy of the value, or false if the Store does not hold it.
+func (s *Store) Value(homeID uint32, valueID uint64) (ValueState, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for key, node := range s.nodes {
+		if key.homeID != homeID {
+			continue
+		}
+		if value, ok := node.values[valueID]; ok {
+			return value.snapshot(), true
+		}
+	}
+	return ValueState{}, false
+}
+
+// snapshot returns a copy of the node and its values.
+func (n *storeNode) snapshot() NodeState {
+	state := n.state
+	state.Values = make([]ValueState, 0, len(n.values))
+	for _, value := range n.values {
+		state.Values = append(state.Values, value.snapshot())
+	}
+	sort.Slice(state.Values, func(i, j int) bool {
+		a, b := state.Values[i].ValueID, state.Values[j].ValueID
+		if a.CommandClassID != b.CommandClassID {
+			return a.CommandClassID < b.CommandClassID
+		}
+		if a.Instance != b.Instance {
+			return a.Instance < b.Instance
+		}
+		if a.Index != b.Index {
+			return a.Index < b.Index
+		}
+		return a.ID < b.ID
+	})
+	return state
+}
+
+// snapshot returns a copy of the value, sharing nothing with the Store.
+func (v *ValueState) snapshot() ValueState {
+	state := *v
+	valueID := *v.ValueID
+	state.ValueID = &valueID
+	state.Value = copyValue(v.Value)
+	return state
+}
+
+// copyValue returns a copy of the value which shares no memory with it.
+func copyValue(value Value) Value {
+	switch value := value.(type) {
+	case ListValue:
+		value.Items = append([]string(nil), value.Items...)
+		return value
+	case RawValue:
+		return append(RawValue(nil), value...)
+	case ScheduleValue:
+		return append(ScheduleValue(nil), value...)
+	}
+	return value
+}
+
+// handle updates the Store from the notification. Metadata is read from the
+// Manager before the Store is locked, so that readers are never blocked on
+// OpenZWave.
+func (s *Store) handle(notification *Notification) {
+	now := time.Now()
+	key := storeKey{notification.HomeID, notification.NodeID}
+
+	switch notification.Type {
+	case NotificationTypeNodeNew, NotificationTypeNodeAdded, NotificationTypeNodeNaming,
+		NotificationTypeNodeProtocolInfo, NotificationTypeEssentialNodeQueriesComplete,
+		NotificationTypeNodeQueriesComplete:
+		info := s.readNode(key)
+		s.update(now, func() bool {
+			node := s.node(key, now)
+			info.Added = node.state.Added
+			info.Changes = node.state.Changes
+			info.Dead = node.state.Dead
+			info.Ready = node.state.Ready || notification.Type == NotificationTypeNodeQueriesComplete
+			node.state = info
+			s.touch(node, now)
+			return true
+		})
+
+	case NotificationTypeNodeRemoved:
+		s.update(now, func() bool {
+			if _, ok := s.nodes[key]; ok == false {
+				return false
+			}
+			delete(s.nodes, key)
+			return true
+		})
+
+	case NotificationTypeDriverRemoved, NotificationTypeDriverReset:
+		s.update(now, func() bool {
+			changed := false
+			for k := range s.nodes {
+				if k.homeID == notification.HomeID {
+					delete(s.nodes, k)
+					changed = true
+				}
+			}
+			return changed
+		})
+
+	case NotificationTypeValueAdded:
+		if notification.ValueID == nil {
+			return
+		}
+		info := s.readValue(notification.ValueID)
+		s.update(now, func() bool {
+			node := s.node(key, now)
+			info.Added = now
+			info.Updated = now
+			node.values[info.ValueID.ID] = &info
+			s.touch(node, now)
+			return true
+		})
+
+	case NotificationTypeValueChanged, NotificationTypeValueRefreshed:
+		if notification.ValueID == nil {
+			return
+		}
+		// The value is read in full if the Store missed it being added, e.g.
+		// because the notification was dropped.
+		var info ValueState
+		if s.hasValue(key, notification.ValueID.ID) {
+			if value, err := notification.ValueID.Get(); err == nil {
+				info.Value = value
+			}
+		} else {
+			info = s.readValue(notification.ValueID)
+		}
+		s.update(now, func() bool {
+			node := s.node(key, now)
+			state, ok := node.values[notification.ValueID.ID]
+			if ok == false {
+				if info.ValueID == nil {
+					// Removed since it was checked.
+					return false
+				}
+				info.Added = now
+				state = &info
+				node.values[info.ValueID.ID] = state
+			} else if notification.Type == NotificationTypeValueRefreshed && sameValue(state.Value, info.Value) {
+				return false
+			}
+			state.Value = info.Value
+			state.Updated = now
+			state.Changes++
+			s.touch(node, now)
+			return true
+		})
+
+	case NotificationTypeValueRemoved:
+		if notification.ValueID == nil {
+			return
+		}
+		s.update(now, func() bool {
+			node, ok := s.nodes[key]
+			if ok == false {
+				return false
+			}
+			if _, ok := node.values[notification.ValueID.ID]; ok == false {
+				return false
+			}
+			delete(node.values, notification.ValueID.ID)
+			s.touch(node, now)
+			return true
+		})
+
+	case NotificationTypeNotification:
+		if notification.Notification == nil {
+			return
+		}
+		s.update(now, func() bool {
+			node, ok := s.nodes[key]
+			if ok == false {
+				return false
+			}
+			switch *notification.Notification {
+			case NotificationCodeAwake:
+				node.state.Awake = true
+			case NotificationCodeSleep:
+				node.state.Awake = false
+			case NotificationCodeDead:
+				node.state.Dead = true
+			case NotificationCodeAlive:
+				node.state.Dead = false
+			default:
+				return false
+			}
+			s.touch(node, now)
+			return true
+		})
+	}
+}
+
+// update runs fn with the Store locked, and records a change if fn returns
+// true.
+func (s *Store) update(now time.Time, fn func() bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if fn() {
+		s.version++
+		s.updated = now
+	}
+}
+
+// node returns the node, adding it if it is not yet held. The Store must be
+// locked.
+func (s *Store) node(key storeKey, now time.Time) *storeNode {
+	node, ok := s.nodes[key]
+	if ok == false {
+		node = &storeNode{
+			state: NodeState{
+				HomeID: key.homeID,
+				NodeID: key.nodeID,
+				Added:  now,
+			},
+			values: make(map[uint64]*ValueState),
+		}
+		s.nodes[key] = node
+	}
+	return node
+}
+
+// touch records a change to the node. The Store must be locked.
+func (s *Store) touch(node *storeNode, now time.Time) {
+	node.state.Updated = now
+	node.state.Changes++
+}
+
+// readNode reads the node's metadata from the Manager.
+func (s *Store) readNode(key storeKey) NodeState {
+	m := s.manager
+	return NodeState{
+		HomeID:           key.homeID,
+		NodeID:           key.nodeID,
+		Name:             m.GetNodeName(key.homeID, key.nodeID),
+		Location:         m.GetNodeLocation(key.homeID, key.nodeID),
+		ManufacturerName: m.GetNodeManufacturerName(key.homeID, key.nodeID),
+		ProductName:      m.GetNodeProductName(key.homeID, key.nodeID),
+		Type:             m.GetNodeType(key.homeID, key.nodeID),
+		Listening:        m.IsNodeListeningDevice(key.homeID, key.nodeID),
+		Awake:            m.IsNodeAwake(key.homeID, key.nodeID),
+	}
+}
+
+// readValue reads the value and its metadata from the Manager.
+func (s *Store) readValue(v *ValueID) ValueState {
+	valueID := *v
+	value, err := v.Get()
+	if err != nil {
+		value = nil
+	}
+	return ValueState{
+		ValueID:   &valueID,
+		Label:     v.GetLabel(),
+		Units:     v.GetUnits(),
+		Help:      v.GetHelp(),
+		Min:       v.GetMin(),
+		Max:       v.GetMax(),
+		ReadOnly:  v.IsReadOnly(),
+		WriteOnly: v.IsWriteOnly(),
+		Value:     value,
+	}
+}
+
+// hasValue returns true if the Store holds the value.
+func (s *Store) hasValue(key storeKey, valueID uint64) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	node, ok := s.nodes[key]
+	if ok == false {
+		return false
+	}
+	_, ok = node.values[valueID]
+	return ok
+}
+
+// sameValue returns true if the two values are equal.
+func sameValue(a, b Value) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Type() == b.Type() && a.String() == b.String()
+}
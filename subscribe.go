@@ -0,0 +1,228 @@
+package goopenzwave
+
+import (
+	"sync"
+)
+
+// SubscriptionCapacity is the number of notifications each Subscription can
+// hold while its handler or channel reader is busy. When a Subscription's queue
+// is full its oldest notification is dropped; this does not affect any other
+// Subscription or the NotificationHandler passed to Start.
+const SubscriptionCapacity = 256
+
+// NotificationFilter selects the notifications delivered to a Subscription. A
+// Notification matches if it matches every field that is set; the zero
+// NotificationFilter matches every Notification. The CommandClassID, Genres and
+// ValueID fields only match notifications which have a ValueID.
+type NotificationFilter struct {
+	HomeID         uint32             // Match this home, or any if 0.
+	NodeID         uint8              // Match this node, or any if 0.
+	Types          []NotificationType // Match any of these types, or any if empty.
+	CommandClassID uint8              // Match this command class, or any if 0.
+	Genres         []ValueIDGenre     // Match any of these genres, or any if empty.
+	ValueID        uint64             // Match this ValueID.ID, or any if 0.
+}
+
+// Match returns true if the notification is selected by the filter.
+func (f NotificationFilter) Match(notification *Notification) bool {
+	if f.HomeID != 0 && notification.HomeID != f.HomeID {
+		return false
+	}
+	if f.NodeID != 0 && notification.NodeID != f.NodeID {
+		return false
+	}
+	if len(f.Types) > 0 && !containsType(f.Types, notification.Type) {
+		return false
+	}
+	if f.CommandClassID == 0 && len(f.Genres) == 0 && f.ValueID == 0 {
+		return true
+	}
+	v := notification.ValueID
+	if v == nil {
+		return false
+	}
+	if f.CommandClassID != 0 && v.CommandClassID != f.CommandClassID {
+		return false
+	}
+	if len(f.Genres) > 0 && !containsGenre(f.Genres, v.Genre) {
+		return false
+	}
+	if f.ValueID != 0 && v.ID != f.ValueID {
+		return false
+	}
+	return true
+}
+
+func containsType(types []NotificationType, t NotificationType) bool {
+	for _, typ := range types {
+		if typ == t {
+			return true
+		}
+	}
+	return false
+}
+
+func containsGenre(genres []ValueIDGenre, g ValueIDGenre) bool {
+	for _, genre := range genres {
+		if genre == g {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscription is a registration for the notifications of a Manager selected
+// by a NotificationFilter, created by Subscribe or SubscribeChan. Each
+// Subscription has its own NotificationQueue and goroutine, so a slow
+// subscriber only delays its own notifications.
+//
+// The same *Notification is delivered to every matching Subscription and must
+// not be modified.
+type Subscription struct {
+	// C delivers the notifications of a Subscription created by
+	// SubscribeChan. It is closed after Unsubscribe. It is nil for a
+	// Subscription created by Subscribe.
+	C <-chan *Notification
+
+	manager *Manager
+	filter  NotificationFilter
+	queue   *NotificationQueue
+	c       chan *Notification
+	done    chan struct{}
+	once    sync.Once
+}
+
+// Subscribe registers the handler to be called on its own goroutine with every
+// Notification selected by the filter, in addition to the NotificationHandler
+// passed to Start. Subscriptions remain registered while the Manager is
+// stopped and restarted, until Unsubscribe is called or the Manager is
+// destroyed. The Subscription returned once the Manager has been destroyed is
+// already unsubscribed and its handler is never called.
+func (m *Manager) Subscribe(filter NotificationFilter, handler NotificationHandler) *Subscription {
+	return m.subscribeHandler(filter, handler, OverflowDropOldest)
+}
+
+// subscribeHandler is Subscribe with the OverflowPolicy of the Subscription's
+// queue.
+func (m *Manager) subscribeHandler(filter NotificationFilter, handler NotificationHandler, policy OverflowPolicy) *Subscription {
+	s := &Subscription{
+		manager: m,
+		filter:  filter,
+		done:    make(chan struct{}),
+	}
+	s.queue = NewNotificationQueue(func(notification *Notification) {
+		select {
+		case <-s.done:
+		default:
+			handler(notification)
+		}
+	}, SubscriptionCapacity, policy)
+	if m.subscribe(s) == false {
+		s.Unsubscribe()
+	}
+	return s
+}
+
+// SubscribeChan registers a Subscription which delivers every Notification
+// selected by the filter on its C channel. The channel is closed once the
+// Subscription has been unsubscribed, or straight away if the Manager has been
+// destroyed.
+func (m *Manager) SubscribeChan(filter NotificationFilter) *Subscription {
+	c := make(chan *Notification)
+	s := &Subscription{
+		C:       c,
+		manager: m,
+		filter:  filter,
+		c:       c,
+		done:    make(chan struct{}),
+	}
+	s.queue = NewNotificationQueue(func(notification *Notification) {
+		select {
+		case <-s.done:
+		case c <- notification:
+		}
+	}, SubscriptionCapacity, OverflowDropOldest)
+	if m.subscribe(s) == false {
+		s.Unsubscribe()
+	}
+	return s
+}
+
+// Unsubscribe stops the delivery of notifications to the Subscription. Any
+// notifications still queued are discarded. It is safe to call Unsubscribe
+// more than once, and from the Subscription's own handler.
+func (s *Subscription) Unsubscribe() {
+	s.once.Do(func() {
+		s.manager.unsubscribe(s)
+		close(s.done)
+		go func() {
+			s.queue.Close()
+			if s.c != nil {
+				close(s.c)
+			}
+		}()
+	})
+}
+
+// Filter returns the NotificationFilter of the Subscription.
+func (s *Subscription) Filter() NotificationFilter {
+	return s.filter
+}
+
+// Stats returns the counters of the Subscription's queue.
+func (s *Subscription) Stats() QueueStats {
+	return s.queue.Stats()
+}
+
+// subscribe adds the Subscription to the Manager, returning false if the
+// Manager has been destroyed. The slice of subscriptions is replaced rather
+// than modified so that publish can range over it without holding the lock.
+func (m *Manager) subscribe(s *Subscription) bool {
+	m.subsMu.Lock()
+	defer m.subsMu.Unlock()
+	if m.subsClosed {
+		return false
+	}
+	subs := make([]*Subscription, len(m.subs), len(m.subs)+1)
+	copy(subs, m.subs)
+	m.subs = append(subs, s)
+	return true
+}
+
+// unsubscribe removes the Subscription from the Manager.
+func (m *Manager) unsubscribe(s *Subscription) {
+	m.subsMu.Lock()
+	defer m.subsMu.Unlock()
+	subs := make([]*Subscription, 0, len(m.subs))
+	for _, sub := range m.subs {
+		if sub != s {
+			subs = append(subs, sub)
+		}
+	}
+	m.subs = subs
+}
+
+// unsubscribeAll unsubscribes every Subscription of the Manager and stops any
+// more from being added.
+func (m *Manager) unsubscribeAll() {
+	m.subsMu.Lock()
+	m.subsClosed = true
+	subs := m.subs
+	m.subsMu.Unlock()
+	for _, s := range subs {
+		s.Unsubscribe()
+	}
+}
+
+// publish queues the notification for each Subscription whose filter selects
+// it.
+func (m *Manager) publish(notification *Notification) {
+	m.subsMu.Lock()
+	subs := m.subs
+	m.subsMu.Unlock()
+	for _, s := range subs {
+		if s.filter.Match(notification) {
+			s.queue.Handle(notification)
+		}
+	}
+}
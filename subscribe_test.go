@@ -0,0 +1,51 @@
+package goopenzwave_test
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/jimjibone/goopenzwave"
+	"github.com/jimjibone/goopenzwave/sim"
+)
+
+func TestSubscribeAfterDestroy(t *testing.T) {
+	goopenzwave.SetBackend(sim.New())
+	options := goopenzwave.CreateOptions("", "", "")
+	defer options.Destroy()
+	if err := options.Lock(); err != nil {
+		t.Fatal(err)
+	}
+	manager, err := goopenzwave.NewManager(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.Destroy(); err != nil {
+		t.Fatal(err)
+	}
+
+	before := runtime.NumGoroutine()
+	for i := 0; i < 100; i++ {
+		sub := manager.SubscribeChan(goopenzwave.NotificationFilter{})
+		select {
+		case _, ok := <-sub.C:
+			if ok {
+				t.Fatal("notification received after Destroy")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("SubscribeChan channel not closed after Destroy")
+		}
+		manager.Subscribe(goopenzwave.NotificationFilter{}, func(notification *goopenzwave.Notification) {
+			t.Error("handler called after Destroy")
+		})
+	}
+
+	// The goroutines of the subscriptions exit once they are closed.
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("%d goroutines leaked by Subscribe after Destroy", after-before)
+	}
+}
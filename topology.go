@@ -0,0 +1,198 @@
+package goopenzwave
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// MaxNodeID is the largest node ID allowed in a Z-Wave network.
+const MaxNodeID = 232
+
+// Topology is a graph of the nodes in a Z-Wave network and the neighbors that
+// each node can reach directly. Use WriteDOT to draw it with Graphviz, or
+// WriteJSON to export it.
+type Topology struct {
+	HomeID uint32         `json:"homeId"`
+	Nodes  []TopologyNode `json:"nodes"`
+	Links  []TopologyLink `json:"links"`
+}
+
+// TopologyNode is a node in a Topology.
+type TopologyNode struct {
+	NodeID            uint8   `json:"nodeId"`
+	Name              string  `json:"name,omitempty"`
+	Type              string  `json:"type,omitempty"`
+	Controller        bool    `json:"controller,omitempty"`
+	Listening         bool    `json:"listening"`
+	FrequentListening bool    `json:"frequentListening,omitempty"`
+	Routing           bool    `json:"routing"`
+	Sleeping          bool    `json:"sleeping"` // The node is a battery device which sleeps.
+	Awake             bool    `json:"awake"`
+	Failed            bool    `json:"failed,omitempty"`
+	Neighbors         []uint8 `json:"neighbors"`
+}
+
+// MarshalJSON encodes the node with its Neighbors as an array of numbers, rather
+// than the base64 string used by default for a []uint8.
+func (n TopologyNode) MarshalJSON() ([]byte, error) {
+	type node TopologyNode
+	neighbors := make([]int, len(n.Neighbors))
+	for i, id := range n.Neighbors {
+		neighbors[i] = int(id)
+	}
+	return json.Marshal(struct {
+		node
+		Neighbors []int `json:"neighbors"`
+	}{node(n), neighbors})
+}
+
+// UnmarshalJSON decodes a node encoded by MarshalJSON.
+func (n *TopologyNode) UnmarshalJSON(data []byte) error {
+	type node TopologyNode
+	v := struct {
+		*node
+		Neighbors []int `json:"neighbors"`
+	}{node: (*node)(n)}
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return err
+	}
+	n.Neighbors = nil
+	for _, id := range v.Neighbors {
+		n.Neighbors = append(n.Neighbors, uint8(id))
+	}
+	return nil
+}
+
+// TopologyLink is a link between two nodes in a Topology. The link is mutual
+// if each node reports the other as a neighbor, otherwise only From reports To
+// as a neighbor.
+type TopologyLink struct {
+	From   uint8 `json:"from"`
+	To     uint8 `json:"to"`
+	Mutual bool  `json:"mutual"`
+}
+
+// Topology builds the Topology of the network with the Home ID from the
+// neighbors reported by each node. The nodes are found by checking every node
+// ID, so nodes which have not yet reported their protocol information are
+// only included if they are the neighbor of another node.
+func (m *Manager) Topology(homeID uint32) *Topology {
+	neighbors := make(map[uint8][]uint8)
+	found := make(map[uint8]bool)
+	for nodeID := 1; nodeID <= MaxNodeID; nodeID++ {
+		id := uint8(nodeID)
+		if m.GetNodeBasicType(homeID, id) == 0 {
+			continue
+		}
+		found[id] = true
+		neighbors[id] = m.GetNodeNeighbors(homeID, id)
+		for _, neighbor := range neighbors[id] {
+			found[neighbor] = true
+		}
+	}
+
+	t := &Topology{HomeID: homeID}
+	controllerID := m.GetControllerNodeID(homeID)
+	for nodeID := 1; nodeID <= MaxNodeID; nodeID++ {
+		id := uint8(nodeID)
+		if found[id] == false {
+			continue
+		}
+		listening := m.IsNodeListeningDevice(homeID, id)
+		frequentListening := m.IsNodeFrequentListeningDevice(homeID, id)
+		t.Nodes = append(t.Nodes, TopologyNode{
+			NodeID:            id,
+			Name:              m.GetNodeName(homeID, id),
+			Type:              m.GetNodeType(homeID, id),
+			Controller:        id == controllerID,
+			Listening:         listening,
+			FrequentListening: frequentListening,
+			Routing:           m.IsNodeRoutingDevice(homeID, id),
+			Sleeping:          !listening && !frequentListening,
+			Awake:             m.IsNodeAwake(homeID, id),
+			Failed:            m.IsNodeFailed(homeID, id),
+			Neighbors:         neighbors[id],
+		})
+		for _, neighbor := range neighbors[id] {
+			mutual := containsNodeID(neighbors[neighbor], id)
+			if mutual && neighbor < id {
+				// Already added from the neighbor's side.
+				continue
+			}
+			t.Links = append(t.Links, TopologyLink{From: id, To: neighbor, Mutual: mutual})
+		}
+	}
+	return t
+}
+
+// containsNodeID returns true if the node ID is in the slice.
+func containsNodeID(nodeIDs []uint8, nodeID uint8) bool {
+	for _, id := range nodeIDs {
+		if id == nodeID {
+			return true
+		}
+	}
+	return false
+}
+
+// Node returns the TopologyNode with the node ID, or nil if it is not in the
+// Topology.
+func (t *Topology) Node(nodeID uint8) *TopologyNode {
+	for i := range t.Nodes {
+		if t.Nodes[i].NodeID == nodeID {
+			return &t.Nodes[i]
+		}
+	}
+	return nil
+}
+
+// WriteJSON writes the Topology to w as indented JSON.
+func (t *Topology) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(t)
+}
+
+// WriteDOT writes the Topology to w as an undirected Graphviz DOT graph. The
+// controller is drawn as a double circle, routing nodes as boxes and sleeping
+// nodes dashed. Failed nodes are drawn in red. Links reported by only one of
+// the two nodes are dashed.
+func (t *Topology) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "graph \"0x%08x\" {\n", t.HomeID)
+	for _, node := range t.Nodes {
+		label := fmt.Sprintf("%d", node.NodeID)
+		if node.Name != "" {
+			label = fmt.Sprintf("%d: %s", node.NodeID, node.Name)
+		}
+		attrs := []string{fmt.Sprintf("label=%q", label)}
+		switch {
+		case node.Controller:
+			attrs = append(attrs, "shape=doublecircle")
+		case node.Routing && node.Listening:
+			attrs = append(attrs, "shape=box")
+		default:
+			attrs = append(attrs, "shape=ellipse")
+		}
+		if node.Sleeping {
+			attrs = append(attrs, "style=dashed")
+		}
+		if node.Failed {
+			attrs = append(attrs, "color=red")
+		}
+		fmt.Fprintf(&b, "\t%d [%s];\n", node.NodeID, strings.Join(attrs, ", "))
+	}
+	for _, link := range t.Links {
+		if link.Mutual {
+			fmt.Fprintf(&b, "\t%d -- %d;\n", link.From, link.To)
+		} else {
+			fmt.Fprintf(&b, "\t%d -- %d [style=dashed];\n", link.From, link.To)
+		}
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
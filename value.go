@@ -0,0 +1,528 @@
+package goopenzwave
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Value is the value of a ValueID, as returned by ValueID.Get and passed to
+// ValueID.Set. It is one of BoolValue, ByteValue, DecimalValue, IntValue,
+// ShortValue, StringValue, ListValue, RawValue, ButtonValue, ScheduleValue or
+// BitSetValue, according to the Type of the ValueID.
+type Value interface {
+	// Type returns the ValueIDType that the value is for.
+	Type() ValueIDType
+	String() string
+
+	isValue()
+}
+
+// BoolValue is the value of a ValueIDTypeBool ValueID.
+type BoolValue bool
+
+// ByteValue is the value of a ValueIDTypeByte ValueID.
+type ByteValue uint8
+
+// DecimalValue is the value of a ValueIDTypeDecimal ValueID, held exactly as
+// a Decimal with the number of decimal places reported by the device.
+type DecimalValue Decimal
+
+// IntValue is the value of a ValueIDTypeInt ValueID.
+type IntValue int32
+
+// ShortValue is the value of a ValueIDTypeShort ValueID.
+type ShortValue int16
+
+// StringValue is the value of a ValueIDTypeString ValueID.
+type StringValue string
+
+// ListValue is the value of a ValueIDTypeList ValueID. Selected is the label of
+// the selected item and Index its position in Items. When setting a list,
+// Selected is used if it is set, otherwise the item at Index is selected.
+type ListValue struct {
+	Selected string
+	Index    int
+	Items    []string
+}
+
+// RawValue is the value of a ValueIDTypeRaw ValueID.
+type RawValue []byte
+
+// ButtonValue is the value of a ValueIDTypeButton ValueID: true while the
+// button is pressed.
+type ButtonValue bool
+
+// SwitchPoint is a switch point in a ScheduleValue.
+type SwitchPoint struct {
+	Hours   uint8
+	Minutes uint8
+	Setback int8
+}
+
+// ScheduleValue is the value of a ValueIDTypeSchedule ValueID.
+type ScheduleValue []SwitchPoint
+
+// BitSetValue is the value of a ValueIDTypeBitSet ValueID. Bit 1 is the least
+// significant bit of Bits. Mask holds the bits which are valid for the device
+// and Size is the size of the value in bytes; neither is used when setting.
+type BitSetValue struct {
+	Bits uint32
+	Mask uint32
+	Size uint8
+}
+
+func (BoolValue) Type() ValueIDType     { return ValueIDTypeBool }
+func (ByteValue) Type() ValueIDType     { return ValueIDTypeByte }
+func (DecimalValue) Type() ValueIDType  { return ValueIDTypeDecimal }
+func (IntValue) Type() ValueIDType      { return ValueIDTypeInt }
+func (ShortValue) Type() ValueIDType    { return ValueIDTypeShort }
+func (StringValue) Type() ValueIDType   { return ValueIDTypeString }
+func (ListValue) Type() ValueIDType     { return ValueIDTypeList }
+func (RawValue) Type() ValueIDType      { return ValueIDTypeRaw }
+func (ButtonValue) Type() ValueIDType   { return ValueIDTypeButton }
+func (ScheduleValue) Type() ValueIDType { return ValueIDTypeSchedule }
+func (BitSetValue) Type() ValueIDType   { return ValueIDTypeBitSet }
+
+func (BoolValue) isValue()     {}
+func (ByteValue) isValue()     {}
+func (DecimalValue) isValue()  {}
+func (IntValue) isValue()      {}
+func (ShortValue) isValue()    {}
+func (StringValue) isValue()   {}
+func (ListValue) isValue()     {}
+func (RawValue) isValue()      {}
+func (ButtonValue) isValue()   {}
+func (ScheduleValue) isValue() {}
+func (BitSetValue) isValue()   {}
+
+func (v BoolValue) String() string {
+	return strconv.FormatBool(bool(v))
+}
+
+func (v ByteValue) String() string {
+	return strconv.FormatUint(uint64(v), 10)
+}
+
+func (v IntValue) String() string {
+	return strconv.FormatInt(int64(v), 10)
+}
+
+func (v ShortValue) String() string {
+	return strconv.FormatInt(int64(v), 10)
+}
+
+func (v StringValue) String() string {
+	return string(v)
+}
+
+func (v ListValue) String() string {
+	return v.Selected
+}
+
+func (v ButtonValue) String() string {
+	return strconv.FormatBool(bool(v))
+}
+
+func (v DecimalValue) String() string {
+	return Decimal(v).String()
+}
+
+func (v RawValue) String() string {
+	return fmt.Sprintf("%x", []byte(v))
+}
+
+func (v BitSetValue) String() string {
+	return fmt.Sprintf("%0*b", int(v.Size)*8, v.Bits)
+}
+
+// Bit returns the state of the bit, numbered from 1.
+func (v BitSetValue) Bit(pos uint8) bool {
+	if pos < 1 || pos > 32 {
+		return false
+	}
+	return v.Bits&(1<<(pos-1)) != 0
+}
+
+func (v ScheduleValue) String() string {
+	points := make([]string, len(v))
+	for i, p := range v {
+		points[i] = fmt.Sprintf("%02d:%02d %+d", p.Hours, p.Minutes, p.Setback)
+	}
+	return strings.Join(points, ", ")
+}
+
+// ErrValueReadOnly is returned by ValueID.Set for a read only value.
+var ErrValueReadOnly = errors.New("value is read only")
+
+// ValueTypeError is returned when a value of the wrong type is used with a
+// ValueID.
+type ValueTypeError struct {
+	ValueIDType ValueIDType // The Type of the ValueID.
+	Got         string      // The type of the value used.
+}
+
+func (e *ValueTypeError) Error() string {
+	return fmt.Sprintf("value of type %s used for %s ValueID", e.Got, e.ValueIDType)
+}
+
+// ValueRangeError is returned by ValueID.Set when a number is outside of the
+// Min and Max of the ValueID. Whole numbers are held with a Precision of 0.
+type ValueRangeError struct {
+	Value    Decimal
+	Min, Max int32
+}
+
+func (e *ValueRangeError) Error() string {
+	return fmt.Sprintf("value %s out of range [%d -> %d]", e.Value, e.Min, e.Max)
+}
+
+// Get returns the value, of the Value type matching the Type of the ValueID.
+// It returns an error if the value could not be read.
+func (v *ValueID) Get() (Value, error) {
+	m := v.mgr()
+	switch v.Type {
+	case ValueIDTypeBool:
+		value, err := m.GetValueAsBool(v.HomeID, v.ID)
+		return BoolValue(value), err
+	case ValueIDTypeByte:
+		value, err := m.GetValueAsByte(v.HomeID, v.ID)
+		return ByteValue(value), err
+	case ValueIDTypeDecimal:
+		value, err := m.GetValueAsDecimal(v.HomeID, v.ID)
+		return DecimalValue(value), err
+	case ValueIDTypeInt:
+		value, err := m.GetValueAsInt(v.HomeID, v.ID)
+		return IntValue(value), err
+	case ValueIDTypeShort:
+		value, err := m.GetValueAsShort(v.HomeID, v.ID)
+		return ShortValue(value), err
+	case ValueIDTypeString:
+		value, ok := m.backend.GetValueAsString(v.HomeID, v.ID)
+		if ok == false {
+			return StringValue(value), fmt.Errorf("string value was not obtained")
+		}
+		return StringValue(value), nil
+	case ValueIDTypeList:
+		items, err := m.GetValueListItems(v.HomeID, v.ID)
+		if err != nil {
+			return ListValue{}, err
+		}
+		selected, err := m.GetValueListSelectionAsString(v.HomeID, v.ID)
+		if err != nil {
+			return ListValue{}, err
+		}
+		list := ListValue{Selected: selected, Index: -1, Items: items}
+		for i, item := range items {
+			if item == selected {
+				list.Index = i
+				break
+			}
+		}
+		return list, nil
+	case ValueIDTypeRaw:
+		value, err := m.GetValueAsRaw(v.HomeID, v.ID)
+		return RawValue(value), err
+	case ValueIDTypeButton:
+		value, err := m.GetValueAsBool(v.HomeID, v.ID)
+		return ButtonValue(value), err
+	case ValueIDTypeSchedule:
+		count := m.backend.GetNumSwitchPoints(v.HomeID, v.ID)
+		schedule := make(ScheduleValue, 0, count)
+		for idx := uint8(0); idx < count; idx++ {
+			hours, minutes, setback, err := m.GetSwitchPoint(v.HomeID, v.ID, idx)
+			if err != nil {
+				return schedule, err
+			}
+			schedule = append(schedule, SwitchPoint{Hours: hours, Minutes: minutes, Setback: setback})
+		}
+		return schedule, nil
+	case ValueIDTypeBitSet:
+		size, err := m.GetBitSetSize(v.HomeID, v.ID)
+		if err != nil {
+			return BitSetValue{}, err
+		}
+		mask, err := m.GetBitMask(v.HomeID, v.ID)
+		if err != nil {
+			return BitSetValue{}, err
+		}
+		bitset := BitSetValue{Mask: mask, Size: size}
+		for pos := uint8(1); pos <= size*8; pos++ {
+			if mask&(1<<(pos-1)) == 0 {
+				continue
+			}
+			bit, err := m.GetValueAsBitSet(v.HomeID, v.ID, pos)
+			if err != nil {
+				return bitset, err
+			}
+			if bit {
+				bitset.Bits |= 1 << (pos - 1)
+			}
+		}
+		return bitset, nil
+	}
+	return nil, fmt.Errorf("unknown value type %s", v.Type)
+}
+
+// Set sets the value. The value must be of the Value type matching the Type of
+// the ValueID, and is checked against ReadOnly and, for numbers, the Min and
+// Max of the ValueID before being sent. Setting a ScheduleValue replaces
+// all of the switch points.
+//
+// As with the other Set methods, the command is assumed to succeed and the
+// value held by the node is updated directly. Use SetAndConfirm to wait for the
+// device to report the new value.
+func (v *ValueID) Set(value Value) error {
+	if value == nil {
+		return &ValueTypeError{ValueIDType: v.Type, Got: "nil"}
+	}
+	if value.Type() != v.Type {
+		return &ValueTypeError{ValueIDType: v.Type, Got: value.Type().String()}
+	}
+	m := v.mgr()
+	if m.IsValueReadOnly(v.HomeID, v.ID) {
+		return ErrValueReadOnly
+	}
+
+	switch value := value.(type) {
+	case BoolValue:
+		return m.SetValueBool(v.HomeID, v.ID, bool(value))
+	case ByteValue:
+		if err := v.checkRange(int64(value)); err != nil {
+			return err
+		}
+		return m.SetValueUint8(v.HomeID, v.ID, uint8(value))
+	case DecimalValue:
+		if err := v.checkDecimalRange(Decimal(value)); err != nil {
+			return err
+		}
+		return m.SetValueDecimal(v.HomeID, v.ID, Decimal(value))
+	case IntValue:
+		if err := v.checkRange(int64(value)); err != nil {
+			return err
+		}
+		return m.SetValueInt32(v.HomeID, v.ID, int32(value))
+	case ShortValue:
+		if err := v.checkRange(int64(value)); err != nil {
+			return err
+		}
+		return m.SetValueInt16(v.HomeID, v.ID, int16(value))
+	case StringValue:
+		return m.SetValueString(v.HomeID, v.ID, string(value))
+	case ListValue:
+		selected := value.Selected
+		if selected == "" {
+			items := value.Items
+			if items == nil {
+				var err error
+				items, err = m.GetValueListItems(v.HomeID, v.ID)
+				if err != nil {
+					return err
+				}
+			}
+			if value.Index < 0 || value.Index >= len(items) {
+				return fmt.Errorf("list index %d out of range", value.Index)
+			}
+			selected = items[value.Index]
+		}
+		return m.SetValueListSelection(v.HomeID, v.ID, selected)
+	case RawValue:
+		return m.SetValueBytes(v.HomeID, v.ID, []byte(value))
+	case ButtonValue:
+		if value {
+			return m.PressButton(v.HomeID, v.ID)
+		}
+		return m.ReleaseButton(v.HomeID, v.ID)
+	case ScheduleValue:
+		m.ClearSwitchPoints(v.HomeID, v.ID)
+		for _, p := range value {
+			if err := m.SetSwitchPoint(v.HomeID, v.ID, p.Hours, p.Minutes, p.Setback); err != nil {
+				return err
+			}
+		}
+		return nil
+	case BitSetValue:
+		// Each bit is sent separately, so only the bits which differ are set.
+		current, err := v.Get()
+		if err != nil {
+			return err
+		}
+		bitset := current.(BitSetValue)
+		if value.Bits&^bitset.Mask != 0 {
+			return fmt.Errorf("bits %b are not valid for the value", value.Bits&^bitset.Mask)
+		}
+		for pos := uint8(1); pos <= bitset.Size*8; pos++ {
+			bit := value.Bit(pos)
+			if bit == bitset.Bit(pos) {
+				continue
+			}
+			if err := m.SetValueBitSet(v.HomeID, v.ID, pos, bit); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return &ValueTypeError{ValueIDType: v.Type, Got: fmt.Sprintf("%T", value)}
+}
+
+// hasRange returns true if the Min and Max of the ValueID limit its values.
+// OpenZWave always sets them for Byte, Short and Int values, to the limits of
+// the type if the device gives none, but leaves both at 0 for a Decimal value
+// without a range.
+func (v *ValueID) hasRange(min, max int32) bool {
+	switch v.Type {
+	case ValueIDTypeByte, ValueIDTypeShort, ValueIDTypeInt:
+		return true
+	case ValueIDTypeDecimal:
+		return min != 0 || max != 0
+	}
+	return false
+}
+
+// checkRange returns a ValueRangeError if the whole number is outside of the
+// Min and Max of the ValueID.
+func (v *ValueID) checkRange(value int64) error {
+	min, max := v.GetMin(), v.GetMax()
+	if v.hasRange(min, max) == false {
+		return nil
+	}
+	if value < int64(min) || value > int64(max) {
+		return &ValueRangeError{Value: Decimal{Mantissa: value}, Min: min, Max: max}
+	}
+	return nil
+}
+
+// checkDecimalRange returns a ValueRangeError if the number is outside of the
+// Min and Max of the ValueID.
+func (v *ValueID) checkDecimalRange(value Decimal) error {
+	min, max := v.GetMin(), v.GetMax()
+	if v.hasRange(min, max) == false {
+		return nil
+	}
+	if value.cmpInt(int64(min)) < 0 || value.cmpInt(int64(max)) > 0 {
+		return &ValueRangeError{Value: value, Min: min, Max: max}
+	}
+	return nil
+}
+
+// Primitive is the set of Go types which Get and Set convert values to and
+// from.
+type Primitive interface {
+	bool | uint8 | float32 | Decimal | int32 | int16 | string | []byte
+}
+
+// Get returns the value of the ValueID as a T, which must match the Type of
+// the ValueID:
+//
+//	bool     Bool, Button
+//	uint8    Byte
+//	float32  Decimal, rounded to the nearest float32
+//	Decimal  Decimal
+//	int32    Int, or the index of the selected item of a List
+//	int16    Short
+//	string   String, or the label of the selected item of a List
+//	[]byte   Raw
+//
+// A ValueTypeError is returned for any other combination.
+func Get[T Primitive](v *ValueID) (T, error) {
+	var result T
+	value, err := v.Get()
+	if err != nil {
+		return result, err
+	}
+	var out interface{}
+	switch any(result).(type) {
+	case bool:
+		switch value := value.(type) {
+		case BoolValue:
+			out = bool(value)
+		case ButtonValue:
+			out = bool(value)
+		}
+	case uint8:
+		if value, ok := value.(ByteValue); ok {
+			out = uint8(value)
+		}
+	case float32:
+		if value, ok := value.(DecimalValue); ok {
+			out = float32(Decimal(value).Float64())
+		}
+	case Decimal:
+		if value, ok := value.(DecimalValue); ok {
+			out = Decimal(value)
+		}
+	case int32:
+		switch value := value.(type) {
+		case IntValue:
+			out = int32(value)
+		case ListValue:
+			out = int32(value.Index)
+		}
+	case int16:
+		if value, ok := value.(ShortValue); ok {
+			out = int16(value)
+		}
+	case string:
+		switch value := value.(type) {
+		case StringValue:
+			out = string(value)
+		case ListValue:
+			out = value.Selected
+		}
+	case []byte:
+		if value, ok := value.(RawValue); ok {
+			out = []byte(value)
+		}
+	}
+	if out == nil {
+		return result, &ValueTypeError{ValueIDType: v.Type, Got: fmt.Sprintf("%T", result)}
+	}
+	return out.(T), nil
+}
+
+// Set sets the ValueID to value, which must match the Type of the ValueID as
+// described for Get. The value is checked in the same way as ValueID.Set
+// before it is sent.
+func Set[T Primitive](v *ValueID, value T) error {
+	var in Value
+	switch value := any(value).(type) {
+	case bool:
+		switch v.Type {
+		case ValueIDTypeBool:
+			in = BoolValue(value)
+		case ValueIDTypeButton:
+			in = ButtonValue(value)
+		}
+	case uint8:
+		in = ByteValue(value)
+	case float32:
+		decimal, err := decimalFromFloat(value)
+		if err != nil {
+			return err
+		}
+		in = DecimalValue(decimal)
+	case Decimal:
+		in = DecimalValue(value)
+	case int32:
+		if v.Type == ValueIDTypeList {
+			in = ListValue{Index: int(value)}
+		} else {
+			in = IntValue(value)
+		}
+	case int16:
+		in = ShortValue(value)
+	case string:
+		if v.Type == ValueIDTypeList {
+			in = ListValue{Selected: value}
+		} else {
+			in = StringValue(value)
+		}
+	case []byte:
+		in = RawValue(value)
+	}
+	if in == nil || in.Type() != v.Type {
+		return &ValueTypeError{ValueIDType: v.Type, Got: fmt.Sprintf("%T", value)}
+	}
+	return v.Set(in)
+}
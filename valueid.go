@@ -1,10 +1,8 @@
 package goopenzwave
 
-// #include "gzw_valueid.h"
-// #include <stdlib.h>
-import "C"
 import (
 	"fmt"
+	"strings"
 )
 
 // ValueIDGenre defines a type for the valueid genre enum.
@@ -16,6 +14,11 @@ const (
 	ValueIDGenreConfig
 	ValueIDGenreSystem
 	ValueIDGenreCount
+
+	// ValueIDGenreUnknown is the genre of a ValueID whose genre is not known
+	// to this package, e.g. one added by a newer OpenZWave. The genre can
+	// still be read from the ID.
+	ValueIDGenreUnknown ValueIDGenre = -1
 )
 
 func (v ValueIDGenre) String() string {
@@ -34,6 +37,33 @@ func (v ValueIDGenre) String() string {
 	return "UNKNOWN"
 }
 
+// ValueIDGenreByName returns the genre with the name returned by String. Case
+// is ignored.
+func ValueIDGenreByName(name string) (ValueIDGenre, bool) {
+	for g := ValueIDGenreBasic; g <= ValueIDGenreCount; g++ {
+		if strings.EqualFold(g.String(), name) {
+			return g, true
+		}
+	}
+	return ValueIDGenreUnknown, false
+}
+
+// MarshalText encodes the genre as by String.
+func (v ValueIDGenre) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText decodes the genre as by ValueIDGenreByName. UNKNOWN decodes
+// to ValueIDGenreUnknown.
+func (v *ValueIDGenre) UnmarshalText(text []byte) error {
+	genre, ok := ValueIDGenreByName(string(text))
+	if ok == false && strings.EqualFold(string(text), ValueIDGenreUnknown.String()) == false {
+		return fmt.Errorf("unknown ValueID genre %q", text)
+	}
+	*v = genre
+	return nil
+}
+
 // ValueIDType defines a type for the valueid type enum.
 type ValueIDType int
 
@@ -48,7 +78,13 @@ const (
 	ValueIDTypeString
 	ValueIDTypeButton
 	ValueIDTypeRaw
-	ValueIDTypeMax
+	ValueIDTypeBitSet // OpenZWave 1.6 and later.
+	ValueIDTypeMax    = ValueIDTypeBitSet
+
+	// ValueIDTypeUnknown is the type of a ValueID whose type is not known to
+	// this package, e.g. one added by a newer OpenZWave. The type can still be
+	// read from the ID.
+	ValueIDTypeUnknown ValueIDType = -1
 )
 
 func (v ValueIDType) String() string {
@@ -71,16 +107,72 @@ func (v ValueIDType) String() string {
 		return "String"
 	case ValueIDTypeButton:
 		return "Button"
-	case ValueIDTypeRaw: // also ValueIDTypeMax
-		return "Raw/Max"
+	case ValueIDTypeRaw:
+		return "Raw"
+	case ValueIDTypeBitSet: // also ValueIDTypeMax
+		return "BitSet"
 	}
 	return "UNKNOWN"
 }
 
+// ValueIDTypeByName returns the type with the name returned by String. Case is
+// ignored.
+func ValueIDTypeByName(name string) (ValueIDType, bool) {
+	for t := ValueIDTypeBool; t <= ValueIDTypeMax; t++ {
+		if strings.EqualFold(t.String(), name) {
+			return t, true
+		}
+	}
+	return ValueIDTypeUnknown, false
+}
+
+// MarshalText encodes the type as by String.
+func (v ValueIDType) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+// UnmarshalText decodes the type as by ValueIDTypeByName. UNKNOWN decodes to
+// ValueIDTypeUnknown.
+func (v *ValueIDType) UnmarshalText(text []byte) error {
+	t, ok := ValueIDTypeByName(string(text))
+	if ok == false && strings.EqualFold(string(text), ValueIDTypeUnknown.String()) == false {
+		return fmt.Errorf("unknown ValueID type %q", text)
+	}
+	*v = t
+	return nil
+}
+
+// ValueFlags defines a type for the flags of a value, as returned by
+// ValueID.Flags.
+type ValueFlags uint8
+
+const (
+	ValueFlagReadOnly ValueFlags = 1 << iota
+	ValueFlagWriteOnly
+	ValueFlagSet
+	ValueFlagPolled
+	ValueFlagChangeVerified
+)
+
+func (f ValueFlags) String() string {
+	names := []string{"ReadOnly", "WriteOnly", "Set", "Polled", "ChangeVerified"}
+	var set []string
+	for i, name := range names {
+		if f&(1<<uint(i)) != 0 {
+			set = append(set, name)
+		}
+	}
+	if len(set) == 0 {
+		return "None"
+	}
+	return strings.Join(set, "|")
+}
+
 // ValueID contains all appropriate information available for a ValueID from the
-// OpenZWave library. You should not create a new ValueID manually, but receive
-// it from the goopenzwave package after a Notification has been received from
-// the OpenZWave library.
+// OpenZWave library. You should not normally create a new ValueID manually, but
+// receive it from the goopenzwave package after a Notification has been
+// received from the OpenZWave library. Use NewValueID to refer to a value whose
+// fields are known, e.g. when restoring saved settings.
 type ValueID struct {
 	HomeID         uint32
 	NodeID         uint8
@@ -90,59 +182,59 @@ type ValueID struct {
 	Index          uint8
 	Type           ValueIDType
 	ID             uint64
+
+	manager *Manager
 }
 
-// buildValueID creates a new valueid.ValueID from the C valueid_t (and
-// therefore C++ ValueID) value.
-func buildValueID(v C.valueid_t) *ValueID {
-	vid := &ValueID{
-		HomeID:         uint32(C.valueid_getHomeId(v)),
-		NodeID:         uint8(C.valueid_getNodeId(v)),
-		CommandClassID: uint8(C.valueid_getCommandClassId(v)),
-		Instance:       uint8(C.valueid_getInstance(v)),
-		Index:          uint8(C.valueid_getIndex(v)),
-		ID:             uint64(C.valueid_getId(v)),
+// NewValueID will create a new ValueID for the value with the fields on the
+// default Manager. The ID is packed from the fields in the same way as
+// OpenZWave. Use Manager.NewValueID for other Managers.
+//
+// It returns an error if the genre or type cannot be packed into the ID, such
+// as ValueIDGenreUnknown and ValueIDTypeUnknown.
+func NewValueID(homeID uint32, nodeID uint8, genre ValueIDGenre, commandClassID, instance, index uint8, typ ValueIDType) (*ValueID, error) {
+	id, err := encodeValueID(nodeID, genre, commandClassID, instance, index, typ)
+	if err != nil {
+		return nil, err
 	}
-
-	switch C.valueid_getGenre(v) {
-	case C.valueid_genre_basic:
-		vid.Genre = ValueIDGenreBasic
-	case C.valueid_genre_user:
-		vid.Genre = ValueIDGenreUser
-	case C.valueid_genre_config:
-		vid.Genre = ValueIDGenreConfig
-	case C.valueid_genre_system:
-		vid.Genre = ValueIDGenreSystem
-	case C.valueid_genre_count:
-		vid.Genre = ValueIDGenreCount
+	return &ValueID{
+		HomeID:         homeID,
+		NodeID:         nodeID,
+		Genre:          genre,
+		CommandClassID: commandClassID,
+		Instance:       instance,
+		Index:          index,
+		Type:           typ,
+		ID:             id,
+	}, nil
+}
+
+// encodeValueID packs the ValueID fields into the 64-bit identifier used by
+// OpenZWave. The genre is held in 2 bits and the type in 4, so any others
+// would corrupt the neighbouring fields.
+func encodeValueID(nodeID uint8, genre ValueIDGenre, commandClassID, instance, index uint8, typ ValueIDType) (uint64, error) {
+	if genre < ValueIDGenreBasic || genre > ValueIDGenreSystem {
+		return 0, fmt.Errorf("ValueID genre %s (%d) cannot be encoded", genre, int(genre))
 	}
-
-	switch C.valueid_getType(v) {
-	case C.valueid_type_bool:
-		vid.Type = ValueIDTypeBool
-	case C.valueid_type_byte:
-		vid.Type = ValueIDTypeByte
-	case C.valueid_type_decimal:
-		vid.Type = ValueIDTypeDecimal
-	case C.valueid_type_int:
-		vid.Type = ValueIDTypeInt
-	case C.valueid_type_list:
-		vid.Type = ValueIDTypeList
-	case C.valueid_type_schedule:
-		vid.Type = ValueIDTypeSchedule
-	case C.valueid_type_short:
-		vid.Type = ValueIDTypeShort
-	case C.valueid_type_string:
-		vid.Type = ValueIDTypeString
-	case C.valueid_type_button:
-		vid.Type = ValueIDTypeButton
-	case C.valueid_type_raw:
-		vid.Type = ValueIDTypeRaw
-		// case C.valueid_type_max:
-		// 	vid.Type = ValueIDTypeMax
+	if typ < ValueIDTypeBool || typ > ValueIDTypeMax {
+		return 0, fmt.Errorf("ValueID type %s (%d) cannot be encoded", typ, int(typ))
 	}
-
-	return vid
+	id := uint32(nodeID)<<24 |
+		uint32(genre)<<22 |
+		uint32(commandClassID)<<14 |
+		uint32(index)<<4 |
+		uint32(typ)
+	id1 := uint32(instance) << 24
+	return uint64(id1)<<32 | uint64(id), nil
+}
+
+// mgr returns the Manager that the ValueID was received from, or the default
+// Manager.
+func (v *ValueID) mgr() *Manager {
+	if v.manager != nil {
+		return v.manager
+	}
+	return defaultManager()
 }
 
 // IDString will create a string representation of the ID for use as a key.
@@ -205,128 +297,174 @@ func (v *ValueID) String() string {
 
 // GetLabel returns the user-friendly label for the value.
 func (v *ValueID) GetLabel() string {
-	return GetValueLabel(v.HomeID, v.ID)
+	return v.mgr().GetValueLabel(v.HomeID, v.ID)
 }
 
 // SetLabel sets the user-friendly label for the value.
 func (v *ValueID) SetLabel(label string) {
-	SetValueLabel(v.HomeID, v.ID, label)
+	v.mgr().SetValueLabel(v.HomeID, v.ID, label)
 }
 
 // GetUnits returns the units that the value is measured in.
 func (v *ValueID) GetUnits() string {
-	return GetValueUnits(v.HomeID, v.ID)
+	return v.mgr().GetValueUnits(v.HomeID, v.ID)
 }
 
 // SetUnits sets the units that the value is measured in.
 func (v *ValueID) SetUnits(units string) {
-	SetValueUnits(v.HomeID, v.ID, units)
+	v.mgr().SetValueUnits(v.HomeID, v.ID, units)
 }
 
 // GetHelp returns a help string describing the value's purpose and usage.
 func (v *ValueID) GetHelp() string {
-	return GetValueHelp(v.HomeID, v.ID)
+	return v.mgr().GetValueHelp(v.HomeID, v.ID)
 }
 
 // SetHelp sets a help string describing the value's purpose and usage.
 func (v *ValueID) SetHelp(help string) {
-	SetValueHelp(v.HomeID, v.ID, help)
+	v.mgr().SetValueHelp(v.HomeID, v.ID, help)
 }
 
 // GetMin returns the minimum that this value may contain.
 func (v *ValueID) GetMin() int32 {
-	return GetValueMin(v.HomeID, v.ID)
+	return v.mgr().GetValueMin(v.HomeID, v.ID)
 }
 
 // GetMax returns the maximum that this value may contain.
 func (v *ValueID) GetMax() int32 {
-	return GetValueMax(v.HomeID, v.ID)
+	return v.mgr().GetValueMax(v.HomeID, v.ID)
 }
 
 // IsReadOnly returns true if the value is read-only.
 func (v *ValueID) IsReadOnly() bool {
-	return IsValueReadOnly(v.HomeID, v.ID)
+	return v.mgr().IsValueReadOnly(v.HomeID, v.ID)
 }
 
 // IsWriteOnly returns true if the value is write-only.
 func (v *ValueID) IsWriteOnly() bool {
-	return IsValueWriteOnly(v.HomeID, v.ID)
+	return v.mgr().IsValueWriteOnly(v.HomeID, v.ID)
 }
 
 // IsSet returns true if the value has been set.
 func (v *ValueID) IsSet() bool {
-	return IsValueSet(v.HomeID, v.ID)
+	return v.mgr().IsValueSet(v.HomeID, v.ID)
 }
 
 // IsPolled returns true if the value is currently being polled.
 func (v *ValueID) IsPolled() bool {
-	return IsValuePolled(v.HomeID, v.ID)
+	return v.mgr().IsValuePolled(v.HomeID, v.ID)
+}
+
+// Flags returns the flags of the value.
+func (v *ValueID) Flags() ValueFlags {
+	m := v.mgr()
+	var flags ValueFlags
+	if m.IsValueReadOnly(v.HomeID, v.ID) {
+		flags |= ValueFlagReadOnly
+	}
+	if m.IsValueWriteOnly(v.HomeID, v.ID) {
+		flags |= ValueFlagWriteOnly
+	}
+	if m.IsValueSet(v.HomeID, v.ID) {
+		flags |= ValueFlagSet
+	}
+	if m.IsValuePolled(v.HomeID, v.ID) {
+		flags |= ValueFlagPolled
+	}
+	if m.GetChangeVerified(v.HomeID, v.ID) {
+		flags |= ValueFlagChangeVerified
+	}
+	return flags
 }
 
 // GetAsBool returns the value as a bool. It will also return an error if the
 // value is not a bool type.
 func (v *ValueID) GetAsBool() (bool, error) {
-	return GetValueAsBool(v.HomeID, v.ID)
+	return v.mgr().GetValueAsBool(v.HomeID, v.ID)
 }
 
 // GetAsByte returns the value as an 8-bit unsigned integer. It will also
 // return an error if the value is not of byte type.
 func (v *ValueID) GetAsByte() (byte, error) {
-	return GetValueAsByte(v.HomeID, v.ID)
+	return v.mgr().GetValueAsByte(v.HomeID, v.ID)
 }
 
 // GetAsFloat returns the value as a float. It will also return an error if
 // the value is not a decimal type.
 func (v *ValueID) GetAsFloat() (float32, error) {
-	return GetValueAsFloat(v.HomeID, v.ID)
+	return v.mgr().GetValueAsFloat(v.HomeID, v.ID)
+}
+
+// GetAsDecimal returns the value as an exact Decimal. It will also return an
+// error if the value is not a decimal type.
+func (v *ValueID) GetAsDecimal() (Decimal, error) {
+	return v.mgr().GetValueAsDecimal(v.HomeID, v.ID)
 }
 
 // GetAsInt returns the value as a 32-bit signed integer. It will also
 // return an error if the value is not of 32-bit signed integer type.
 func (v *ValueID) GetAsInt() (int32, error) {
-	return GetValueAsInt(v.HomeID, v.ID)
+	return v.mgr().GetValueAsInt(v.HomeID, v.ID)
 }
 
 // GetAsShort returns the value as a 16-bit signed integer. It will also
 // return an error if the value is not of 16-bit signed integer type.
 func (v *ValueID) GetAsShort() (int16, error) {
-	return GetValueAsShort(v.HomeID, v.ID)
+	return v.mgr().GetValueAsShort(v.HomeID, v.ID)
 }
 
 // GetAsString returns the value as a string, regardless of its actual
 // type.
 func (v *ValueID) GetAsString() string {
-	return GetValueAsString(v.HomeID, v.ID)
+	return v.mgr().GetValueAsString(v.HomeID, v.ID)
 }
 
 // GetAsRaw returns the value as a raw byte slice. It will also return an
 // error if the value is not of raw type.
 func (v *ValueID) GetAsRaw() ([]byte, error) {
-	return GetValueAsRaw(v.HomeID, v.ID)
+	return v.mgr().GetValueAsRaw(v.HomeID, v.ID)
 }
 
 // GetListSelectionAsString returns selected item from a list as a string.
 // It will also return an error if the value is not of list type.
 func (v *ValueID) GetListSelectionAsString() (string, error) {
-	return GetValueListSelectionAsString(v.HomeID, v.ID)
+	return v.mgr().GetValueListSelectionAsString(v.HomeID, v.ID)
 }
 
 // GetListSelectionAsInt32 returns selected item from a list as an integer.
 // It will also return an error if the value is not of list type.
 func (v *ValueID) GetListSelectionAsInt32() (int32, error) {
-	return GetValueListSelectionAsInt32(v.HomeID, v.ID)
+	return v.mgr().GetValueListSelectionAsInt32(v.HomeID, v.ID)
 }
 
 // GetListItems returns the list of items from a list value. It will also
 // return an error if the value is not of list type.
 func (v *ValueID) GetListItems() ([]string, error) {
-	return GetValueListItems(v.HomeID, v.ID)
+	return v.mgr().GetValueListItems(v.HomeID, v.ID)
 }
 
 // GetFloatPrecision returns the float value's precision. It will also
 // return an error if the value is not of decimal type.
 func (v *ValueID) GetFloatPrecision() (uint8, error) {
-	return GetValueFloatPrecision(v.HomeID, v.ID)
+	return v.mgr().GetValueFloatPrecision(v.HomeID, v.ID)
+}
+
+// GetBit returns the state of a bit of a BitSet value. Bits are numbered from
+// 1. It will also return an error if the value is not of BitSet type.
+func (v *ValueID) GetBit(pos uint8) (bool, error) {
+	return v.mgr().GetValueAsBitSet(v.HomeID, v.ID, pos)
+}
+
+// GetBitMask returns the mask of the bits of a BitSet value which are valid for
+// the device. It will also return an error if the value is not of BitSet type.
+func (v *ValueID) GetBitMask() (uint32, error) {
+	return v.mgr().GetBitMask(v.HomeID, v.ID)
+}
+
+// GetBitSetSize returns the size of a BitSet value in bytes. It will also
+// return an error if the value is not of BitSet type.
+func (v *ValueID) GetBitSetSize() (uint8, error) {
+	return v.mgr().GetBitSetSize(v.HomeID, v.ID)
 }
 
 // SetBool sets the state of a bool. It will return an error if the value
@@ -338,7 +476,7 @@ func (v *ValueID) GetFloatPrecision() (uint8, error) {
 // actually failed to get through. Notification callbacks will be sent in both
 // cases.
 func (v *ValueID) SetBool(value bool) error {
-	return SetValueBool(v.HomeID, v.ID, value)
+	return v.mgr().SetValueBool(v.HomeID, v.ID, value)
 }
 
 // SetUint8 sets the value of a byte. It will return an error if the value
@@ -350,20 +488,33 @@ func (v *ValueID) SetBool(value bool) error {
 // actually failed to get through. Notification callbacks will be sent in both
 // cases.
 func (v *ValueID) SetUint8(value uint8) error {
-	return SetValueUint8(v.HomeID, v.ID, value)
+	return v.mgr().SetValueUint8(v.HomeID, v.ID, value)
 }
 
 // SetFloat sets the value of a decimal. It will return an error if the
 // value is not of decimal type.
 //
-// It is usually better to handle decimal values using strings rather than
+// It is usually better to handle decimal values using SetDecimal rather than
 // floats, to avoid floating point accuracy issues. Due to the possibility of a
 // device being asleep, the command is assumed to succeed, and the value held by
 // the node is updated directly. This will be reverted by a future status
 // message from the device if the Z-Wave message actually failed to get through.
 // Notification callbacks will be sent in both cases.
 func (v *ValueID) SetFloat(value float32) error {
-	return SetValueFloat(v.HomeID, v.ID, value)
+	return v.mgr().SetValueFloat(v.HomeID, v.ID, value)
+}
+
+// SetDecimal sets the value of a decimal, rounded to the precision of the
+// device once it has reported one. See Manager.SetValueDecimal. It will return
+// an error if the value is not of decimal type.
+//
+// Due to the possibility of a device being asleep, the command is assumed to
+// succeed, and the value held by the node is updated directly. This will be
+// reverted by a future status message from the device if the Z-Wave message
+// actually failed to get through. Notification callbacks will be sent in both
+// cases.
+func (v *ValueID) SetDecimal(value Decimal) error {
+	return v.mgr().SetValueDecimal(v.HomeID, v.ID, value)
 }
 
 // SetInt32 sets the value of a 32-bit signed integer. It will return an
@@ -375,7 +526,7 @@ func (v *ValueID) SetFloat(value float32) error {
 // actually failed to get through. Notification callbacks will be sent in both
 // cases.
 func (v *ValueID) SetInt32(value int32) error {
-	return SetValueInt32(v.HomeID, v.ID, value)
+	return v.mgr().SetValueInt32(v.HomeID, v.ID, value)
 }
 
 // SetInt16 sets the value of a 16-bit signed integer. It will return an
@@ -387,7 +538,7 @@ func (v *ValueID) SetInt32(value int32) error {
 // actually failed to get through. Notification callbacks will be sent in both
 // cases.
 func (v *ValueID) SetInt16(value int16) error {
-	return SetValueInt16(v.HomeID, v.ID, value)
+	return v.mgr().SetValueInt16(v.HomeID, v.ID, value)
 }
 
 // SetBytes sets the value of a raw value. It will return an error if the
@@ -399,7 +550,7 @@ func (v *ValueID) SetInt16(value int16) error {
 // actually failed to get through. Notification callbacks will be sent in both
 // cases.
 func (v *ValueID) SetBytes(value []byte) error {
-	return SetValueBytes(v.HomeID, v.ID, value)
+	return v.mgr().SetValueBytes(v.HomeID, v.ID, value)
 }
 
 // SetString sets the value from a string, regardless of type. It will
@@ -412,7 +563,7 @@ func (v *ValueID) SetBytes(value []byte) error {
 // actually failed to get through. Notification callbacks will be sent in both
 // cases.
 func (v *ValueID) SetString(value string) error {
-	return SetValueString(v.HomeID, v.ID, value)
+	return v.mgr().SetValueString(v.HomeID, v.ID, value)
 }
 
 // SetListSelection sets the selected item in a list. It will return an
@@ -425,7 +576,26 @@ func (v *ValueID) SetString(value string) error {
 // actually failed to get through. Notification callbacks will be sent in both
 // cases.
 func (v *ValueID) SetListSelection(selectedItem string) error {
-	return SetValueListSelection(v.HomeID, v.ID, selectedItem)
+	return v.mgr().SetValueListSelection(v.HomeID, v.ID, selectedItem)
+}
+
+// SetBit sets the state of a bit of a BitSet value. Bits are numbered from 1.
+// It will return an error if the value is not of BitSet type or the bit is not
+// valid.
+//
+// Due to the possibility of a device being asleep, the command is assumed to
+// succeed, and the value held by the node is updated directly. This will be
+// reverted by a future status message from the device if the Z-Wave message
+// actually failed to get through. Notification callbacks will be sent in both
+// cases.
+func (v *ValueID) SetBit(pos uint8, value bool) error {
+	return v.mgr().SetValueBitSet(v.HomeID, v.ID, pos, value)
+}
+
+// SetBitMask sets the mask of the bits of a BitSet value which are valid for
+// the device. It will return an error if the value is not of BitSet type.
+func (v *ValueID) SetBitMask(mask uint32) error {
+	return v.mgr().SetBitMask(v.HomeID, v.ID, mask)
 }
 
 // Refresh refreshes the specified value from the Z-Wave network. It will
@@ -435,7 +605,7 @@ func (v *ValueID) SetListSelection(selectedItem string) error {
 // to retrieve the current value of the specified ValueID (just like a poll,
 // except only one-time, not recurring).
 func (v *ValueID) Refresh() bool {
-	return RefreshValue(v.HomeID, v.ID)
+	return v.mgr().RefreshValue(v.HomeID, v.ID)
 }
 
 // SetChangeVerified sets a flag indicating whether value changes noted upon a
@@ -443,7 +613,7 @@ func (v *ValueID) Refresh() bool {
 // value a second time whenever a change is observed. This helps to filter out
 // spurious data reported occasionally by some devices.
 func (v *ValueID) SetChangeVerified(verify bool) {
-	SetChangeVerified(v.HomeID, v.ID, verify)
+	v.mgr().SetChangeVerified(v.HomeID, v.ID, verify)
 }
 
 // GetChangeVerified returns true if value changes upon a refresh should be
@@ -451,7 +621,7 @@ func (v *ValueID) SetChangeVerified(verify bool) {
 // whenever a change is observed. This helps to filter out spurious data
 // reported occasionally by some devices.
 func (v *ValueID) GetChangeVerified() bool {
-	return GetChangeVerified(v.HomeID, v.ID)
+	return v.mgr().GetChangeVerified(v.HomeID, v.ID)
 }
 
 // PressButton starts an activity in a device. It will return an error if the
@@ -460,7 +630,7 @@ func (v *ValueID) GetChangeVerified() bool {
 // Since buttons are write-only values that do not report a state, no
 // notification callbacks are sent.
 func (v *ValueID) PressButton() error {
-	return PressButton(v.HomeID, v.ID)
+	return v.mgr().PressButton(v.HomeID, v.ID)
 }
 
 // ReleaseButton stops an activity in a device. It will return an error if the
@@ -469,19 +639,19 @@ func (v *ValueID) PressButton() error {
 // Since buttons are write-only values that do not report a state, no
 // notification callbacks are sent.
 func (v *ValueID) ReleaseButton() error {
-	return ReleaseButton(v.HomeID, v.ID)
+	return v.mgr().ReleaseButton(v.HomeID, v.ID)
 }
 
 // EnablePoll enables the polling of a device's state. Returns true if polling
 // was enabled.
 func (v *ValueID) EnablePoll(intensity uint8) bool {
-	return EnablePoll(v.HomeID, v.ID, intensity)
+	return v.mgr().EnablePoll(v.HomeID, v.ID, intensity)
 }
 
 // DisablePoll disables the polling of a device's state. Returns true if polling
 // was disabled.
 func (v *ValueID) DisablePoll() bool {
-	return DisablePoll(v.HomeID, v.ID)
+	return v.mgr().DisablePoll(v.HomeID, v.ID)
 }
 
 // SetPollIntensity sets the frequency of polling.
@@ -491,10 +661,10 @@ func (v *ValueID) DisablePoll() bool {
 //  - 2 = every other time
 //  - etc.
 func (v *ValueID) SetPollIntensity(intensity uint8) {
-	SetPollIntensity(v.HomeID, v.ID, intensity)
+	v.mgr().SetPollIntensity(v.HomeID, v.ID, intensity)
 }
 
 // GetPollIntensity returns the polling intensity of a device's state.
 func (v *ValueID) GetPollIntensity() uint8 {
-	return GetPollIntensity(v.HomeID, v.ID)
+	return v.mgr().GetPollIntensity(v.HomeID, v.ID)
 }
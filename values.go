@@ -1,267 +1,228 @@
 package goopenzwave
 
-// #include "gzw_manager.h"
-// #include "gzw_valueid.h"
-// #include "zwbytes.h"
-// #include "zwlist.h"
-// #include <stdlib.h>
-import "C"
 import (
 	"fmt"
-	"unsafe"
 )
 
 // GetValueLabel returns the user-friendly label for the value.
-func GetValueLabel(homeID uint32, valueID uint64) string {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	cstr := C.manager_getValueLabel(cmanager, cvalueid)
-	gostr := C.GoString(cstr)
-	C.free(unsafe.Pointer(cstr))
-	return gostr
+func (m *Manager) GetValueLabel(homeID uint32, valueID uint64) string {
+	return m.backend.GetValueLabel(homeID, valueID)
 }
 
 // SetValueLabel sets the user-friendly label for the value.
-func SetValueLabel(homeID uint32, valueID uint64, value string) {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	cstr := C.CString(value)
-	C.manager_setValueLabel(cmanager, cvalueid, cstr)
-	C.free(unsafe.Pointer(cstr))
+func (m *Manager) SetValueLabel(homeID uint32, valueID uint64, value string) {
+	m.backend.SetValueLabel(homeID, valueID, value)
 }
 
 // GetValueUnits returns the units that the value is measured in.
-func GetValueUnits(homeID uint32, valueID uint64) string {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	cstr := C.manager_getValueUnits(cmanager, cvalueid)
-	gostr := C.GoString(cstr)
-	C.free(unsafe.Pointer(cstr))
-	return gostr
+func (m *Manager) GetValueUnits(homeID uint32, valueID uint64) string {
+	return m.backend.GetValueUnits(homeID, valueID)
 }
 
 // SetValueUnits sets the units that the value is measured in.
-func SetValueUnits(homeID uint32, valueID uint64, value string) {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	cstring := C.CString(value)
-	C.manager_setValueUnits(cmanager, cvalueid, cstring)
-	C.free(unsafe.Pointer(cstring))
+func (m *Manager) SetValueUnits(homeID uint32, valueID uint64, value string) {
+	m.backend.SetValueUnits(homeID, valueID, value)
 }
 
 // GetValueHelp returns a help string describing the value's purpose and usage.
-func GetValueHelp(homeID uint32, valueID uint64) string {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	cstr := C.manager_getValueHelp(cmanager, cvalueid)
-	gostr := C.GoString(cstr)
-	C.free(unsafe.Pointer(cstr))
-	return gostr
+func (m *Manager) GetValueHelp(homeID uint32, valueID uint64) string {
+	return m.backend.GetValueHelp(homeID, valueID)
 }
 
 // SetValueHelp sets a help string describing the value's purpose and usage.
-func SetValueHelp(homeID uint32, valueID uint64, value string) {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	cstring := C.CString(value)
-	C.manager_setValueHelp(cmanager, cvalueid, cstring)
-	C.free(unsafe.Pointer(cstring))
+func (m *Manager) SetValueHelp(homeID uint32, valueID uint64, value string) {
+	m.backend.SetValueHelp(homeID, valueID, value)
 }
 
 // GetValueMin returns the minimum that this value may contain.
-func GetValueMin(homeID uint32, valueID uint64) int32 {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	return int32(C.manager_getValueMin(cmanager, cvalueid))
+func (m *Manager) GetValueMin(homeID uint32, valueID uint64) int32 {
+	return m.backend.GetValueMin(homeID, valueID)
 }
 
 // GetValueMax returns the maximum that this value may contain.
-func GetValueMax(homeID uint32, valueID uint64) int32 {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	return int32(C.manager_getValueMax(cmanager, cvalueid))
+func (m *Manager) GetValueMax(homeID uint32, valueID uint64) int32 {
+	return m.backend.GetValueMax(homeID, valueID)
 }
 
 // IsValueReadOnly returns true if the value is read-only.
-func IsValueReadOnly(homeID uint32, valueID uint64) bool {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	return bool(C.manager_isValueReadOnly(cmanager, cvalueid))
+func (m *Manager) IsValueReadOnly(homeID uint32, valueID uint64) bool {
+	return m.backend.IsValueReadOnly(homeID, valueID)
 }
 
 // IsValueWriteOnly returns true if the value is write-only.
-func IsValueWriteOnly(homeID uint32, valueID uint64) bool {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	return bool(C.manager_isValueWriteOnly(cmanager, cvalueid))
+func (m *Manager) IsValueWriteOnly(homeID uint32, valueID uint64) bool {
+	return m.backend.IsValueWriteOnly(homeID, valueID)
 }
 
 // IsValueSet returns true if the value has been set.
-func IsValueSet(homeID uint32, valueID uint64) bool {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	return bool(C.manager_isValueSet(cmanager, cvalueid))
+func (m *Manager) IsValueSet(homeID uint32, valueID uint64) bool {
+	return m.backend.IsValueSet(homeID, valueID)
 }
 
 // IsValuePolled returns true if the value is currently being polled.
-func IsValuePolled(homeID uint32, valueID uint64) bool {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	return bool(C.manager_isValuePolled(cmanager, cvalueid))
+func (m *Manager) IsValuePolled(homeID uint32, valueID uint64) bool {
+	return m.backend.IsValuePolled(homeID, valueID)
 }
 
 // GetValueAsBool returns the value as a bool. It will also return an error if
 // the value is not a bool type.
-func GetValueAsBool(homeID uint32, valueID uint64) (bool, error) {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	var cbool C.bool
-	ok := bool(C.manager_getValueAsBool(cmanager, cvalueid, &cbool))
+func (m *Manager) GetValueAsBool(homeID uint32, valueID uint64) (bool, error) {
+	value, ok := m.backend.GetValueAsBool(homeID, valueID)
 	if ok == false {
-		return bool(cbool), fmt.Errorf("value is not of bool type")
+		return value, fmt.Errorf("value is not of bool type")
 	}
-	return bool(cbool), nil
+	return value, nil
 }
 
 // GetValueAsByte returns the value as an 8-bit unsigned integer. It will also
 // return an error if the value is not of byte type.
-func GetValueAsByte(homeID uint32, valueID uint64) (byte, error) {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	var cbyte C.uint8_t
-	ok := bool(C.manager_getValueAsByte(cmanager, cvalueid, &cbyte))
+func (m *Manager) GetValueAsByte(homeID uint32, valueID uint64) (byte, error) {
+	value, ok := m.backend.GetValueAsByte(homeID, valueID)
 	if ok == false {
-		return byte(cbyte), fmt.Errorf("value is not of byte type")
+		return value, fmt.Errorf("value is not of byte type")
 	}
-	return byte(cbyte), nil
+	return value, nil
 }
 
 // GetValueAsFloat returns the value as a float. It will also return an error if
 // the value is not a decimal type.
-func GetValueAsFloat(homeID uint32, valueID uint64) (float32, error) {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	var cfloat C.float
-	ok := bool(C.manager_getValueAsFloat(cmanager, cvalueid, &cfloat))
+func (m *Manager) GetValueAsFloat(homeID uint32, valueID uint64) (float32, error) {
+	value, ok := m.backend.GetValueAsFloat(homeID, valueID)
 	if ok == false {
-		return float32(cfloat), fmt.Errorf("value is not of decimal type")
+		return value, fmt.Errorf("value is not of decimal type")
 	}
-	return float32(cfloat), nil
+	return value, nil
+}
+
+// GetValueAsDecimal returns the value as an exact Decimal, with the number of
+// decimal places reported by the device. It will also return an error if the
+// value is not a decimal type.
+func (m *Manager) GetValueAsDecimal(homeID uint32, valueID uint64) (Decimal, error) {
+	precision, ok := m.backend.GetValueFloatPrecision(homeID, valueID)
+	if ok == false {
+		return Decimal{}, fmt.Errorf("value is not of decimal type")
+	}
+	value, ok := m.backend.GetValueAsString(homeID, valueID)
+	if ok == false {
+		return Decimal{}, fmt.Errorf("string value was not obtained")
+	}
+	decimal, err := ParseDecimal(value)
+	if err != nil {
+		return decimal, err
+	}
+	if decimal.Precision < precision {
+		return decimal.Rescale(precision)
+	}
+	return decimal, nil
 }
 
 // GetValueAsInt returns the value as a 32-bit signed integer. It will also
 // return an error if the value is not of 32-bit signed integer type.
-func GetValueAsInt(homeID uint32, valueID uint64) (int32, error) {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	var cint C.int32_t
-	ok := bool(C.manager_getValueAsInt(cmanager, cvalueid, &cint))
+func (m *Manager) GetValueAsInt(homeID uint32, valueID uint64) (int32, error) {
+	value, ok := m.backend.GetValueAsInt(homeID, valueID)
 	if ok == false {
-		return int32(cint), fmt.Errorf("value is not of 32-bit signed integer type")
+		return value, fmt.Errorf("value is not of 32-bit signed integer type")
 	}
-	return int32(cint), nil
+	return value, nil
 }
 
 // GetValueAsShort returns the value as a 16-bit signed integer. It will also
 // return an error if the value is not of 16-bit signed integer type.
-func GetValueAsShort(homeID uint32, valueID uint64) (int16, error) {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	var cshort C.int16_t
-	ok := bool(C.manager_getValueAsShort(cmanager, cvalueid, &cshort))
+func (m *Manager) GetValueAsShort(homeID uint32, valueID uint64) (int16, error) {
+	value, ok := m.backend.GetValueAsShort(homeID, valueID)
 	if ok == false {
-		return int16(cshort), fmt.Errorf("value is not of 16-bit signed integer type")
+		return value, fmt.Errorf("value is not of 16-bit signed integer type")
 	}
-	return int16(cshort), nil
+	return value, nil
 }
 
 // GetValueAsString returns the value as a string, regardless of its actual
 // type.
-func GetValueAsString(homeID uint32, valueID uint64) string {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	var cstr *C.char
-	_ = C.manager_getValueAsString(cmanager, cvalueid, &cstr)
-	gostr := C.GoString(cstr)
-	C.free(unsafe.Pointer(cstr))
-	return gostr
+func (m *Manager) GetValueAsString(homeID uint32, valueID uint64) string {
+	value, _ := m.backend.GetValueAsString(homeID, valueID)
+	return value
 }
 
 // GetValueAsRaw returns the value as a raw byte slice. It will also return an
 // error if the value is not of raw type.
-func GetValueAsRaw(homeID uint32, valueID uint64) ([]byte, error) {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	zwbytes := C.zwbytes_new()
-	ok := bool(C.manager_getValueAsRaw(cmanager, cvalueid, zwbytes))
+func (m *Manager) GetValueAsRaw(homeID uint32, valueID uint64) ([]byte, error) {
+	value, ok := m.backend.GetValueAsRaw(homeID, valueID)
 	if ok == false {
 		return nil, fmt.Errorf("value is not of raw type")
 	}
-	gobytes := make([]byte, zwbytes.size)
-	for i := 0; i < int(zwbytes.size); i++ {
-		gobytes[i] = byte(C.zwbytes_at(zwbytes, C.size_t(i)))
-	}
-	return gobytes, nil
+	return value, nil
 }
 
 // GetValueListSelectionAsString returns selected item from a list as a string.
 // It will also return an error if the value is not of list type.
-func GetValueListSelectionAsString(homeID uint32, valueID uint64) (string, error) {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	var cstr *C.char
-	ok := bool(C.manager_getValueListSelectionAsString(cmanager, cvalueid, &cstr))
-	gostr := C.GoString(cstr)
-	C.free(unsafe.Pointer(cstr))
+func (m *Manager) GetValueListSelectionAsString(homeID uint32, valueID uint64) (string, error) {
+	value, ok := m.backend.GetValueListSelectionAsString(homeID, valueID)
 	if ok == false {
-		return gostr, fmt.Errorf("value is not of list type")
+		return value, fmt.Errorf("value is not of list type")
 	}
-	return gostr, nil
+	return value, nil
 }
 
 // GetValueListSelectionAsInt32 returns selected item from a list as an integer.
 // It will also return an error if the value is not of list type.
-func GetValueListSelectionAsInt32(homeID uint32, valueID uint64) (int32, error) {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	var cint C.int32_t
-	ok := bool(C.manager_getValueListSelectionAsInt32(cmanager, cvalueid, &cint))
+func (m *Manager) GetValueListSelectionAsInt32(homeID uint32, valueID uint64) (int32, error) {
+	value, ok := m.backend.GetValueListSelectionAsInt32(homeID, valueID)
 	if ok == false {
-		return int32(cint), fmt.Errorf("value is not of list type")
+		return value, fmt.Errorf("value is not of list type")
 	}
-	return int32(cint), nil
+	return value, nil
 }
 
 // GetValueListItems returns the list of items from a list value. It will also
 // return an error if the value is not of list type.
-func GetValueListItems(homeID uint32, valueID uint64) ([]string, error) {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	var clist *C.zwlist_t
-	ok := bool(C.manager_getValueListItems(cmanager, cvalueid, &clist))
-	golist := make([]string, int(C.zwlist_size(clist)))
+func (m *Manager) GetValueListItems(homeID uint32, valueID uint64) ([]string, error) {
+	value, ok := m.backend.GetValueListItems(homeID, valueID)
 	if ok == false {
 		return nil, fmt.Errorf("value is not of list type")
 	}
-	for i := 0; i < int(C.zwlist_size(clist)); i++ {
-		cstr := C.zwlist_at(clist, C.size_t(i))
-		golist[i] = C.GoString(cstr)
-	}
-	C.zwlist_free(clist)
-	return golist, nil
+	return value, nil
 }
 
 // GetValueFloatPrecision returns the float value's precision. It will also
 // return an error if the value is not of decimal type.
-func GetValueFloatPrecision(homeID uint32, valueID uint64) (uint8, error) {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	var cprecision C.uint8_t
-	ok := bool(C.manager_getValueFloatPrecision(cmanager, cvalueid, &cprecision))
+func (m *Manager) GetValueFloatPrecision(homeID uint32, valueID uint64) (uint8, error) {
+	value, ok := m.backend.GetValueFloatPrecision(homeID, valueID)
+	if ok == false {
+		return value, fmt.Errorf("value is not of decimal type")
+	}
+	return value, nil
+}
+
+// GetValueAsBitSet returns the state of a bit in a BitSet value. Bits are
+// numbered from 1. It will also return an error if the value is not of BitSet
+// type, which requires OpenZWave 1.6.
+func (m *Manager) GetValueAsBitSet(homeID uint32, valueID uint64, pos uint8) (bool, error) {
+	value, ok := m.backend.GetValueAsBitSet(homeID, valueID, pos)
 	if ok == false {
-		return uint8(cprecision), fmt.Errorf("value is not of decimal type")
+		return value, fmt.Errorf("value is not of bitset type or bit %d is not valid", pos)
 	}
-	return uint8(cprecision), nil
+	return value, nil
+}
+
+// GetBitMask returns the mask of the bits in a BitSet value which are valid
+// for the device. It will also return an error if the value is not of BitSet
+// type.
+func (m *Manager) GetBitMask(homeID uint32, valueID uint64) (uint32, error) {
+	value, ok := m.backend.GetBitMask(homeID, valueID)
+	if ok == false {
+		return 0, fmt.Errorf("value is not of bitset type")
+	}
+	return uint32(value), nil
+}
+
+// GetBitSetSize returns the size of a BitSet value in bytes: 1, 2 or 4. It will
+// also return an error if the value is not of BitSet type.
+func (m *Manager) GetBitSetSize(homeID uint32, valueID uint64) (uint8, error) {
+	value, ok := m.backend.GetBitSetSize(homeID, valueID)
+	if ok == false {
+		return value, fmt.Errorf("value is not of bitset type")
+	}
+	return value, nil
 }
 
 // SetValueBool sets the state of a bool. It will return an error if the value
@@ -272,10 +233,8 @@ func GetValueFloatPrecision(homeID uint32, valueID uint64) (uint8, error) {
 // reverted by a future status message from the device if the Z-Wave message
 // actually failed to get through. Notification callbacks will be sent in both
 // cases.
-func SetValueBool(homeID uint32, valueID uint64, value bool) error {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	ok := bool(C.manager_setValueBool(cmanager, cvalueid, C.bool(value)))
+func (m *Manager) SetValueBool(homeID uint32, valueID uint64, value bool) error {
+	ok := m.backend.SetValueBool(homeID, valueID, value)
 	if ok == false {
 		return fmt.Errorf("value is not of bool type")
 	}
@@ -290,10 +249,8 @@ func SetValueBool(homeID uint32, valueID uint64, value bool) error {
 // reverted by a future status message from the device if the Z-Wave message
 // actually failed to get through. Notification callbacks will be sent in both
 // cases.
-func SetValueUint8(homeID uint32, valueID uint64, value uint8) error {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	ok := bool(C.manager_setValueUint8(cmanager, cvalueid, C.uint8_t(value)))
+func (m *Manager) SetValueUint8(homeID uint32, valueID uint64, value uint8) error {
+	ok := m.backend.SetValueUint8(homeID, valueID, value)
 	if ok == false {
 		return fmt.Errorf("value is not of byte type")
 	}
@@ -303,16 +260,44 @@ func SetValueUint8(homeID uint32, valueID uint64, value uint8) error {
 // SetValueFloat sets the value of a decimal. It will return an error if the
 // value is not of decimal type.
 //
-// It is usually better to handle decimal values using strings rather than
-// floats, to avoid floating point accuracy issues. Due to the possibility of a
+// It is usually better to handle decimal values using SetValueDecimal rather
+// than floats, to avoid floating point accuracy issues. Due to the possibility of a
 // device being asleep, the command is assumed to succeed, and the value held by
 // the node is updated directly. This will be reverted by a future status
 // message from the device if the Z-Wave message actually failed to get through.
 // Notification callbacks will be sent in both cases.
-func SetValueFloat(homeID uint32, valueID uint64, value float32) error {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	ok := bool(C.manager_setValueFloat(cmanager, cvalueid, C.float(value)))
+func (m *Manager) SetValueFloat(homeID uint32, valueID uint64, value float32) error {
+	ok := m.backend.SetValueFloat(homeID, valueID, value)
+	if ok == false {
+		return fmt.Errorf("value is not of decimal type")
+	}
+	return nil
+}
+
+// SetValueDecimal sets the value of a decimal without going through a float.
+// The value is rounded to the precision of the device, half away from zero,
+// before being sent. OpenZWave reports a precision of 0 until the device has
+// sent the value, so until then the value is sent with its own precision. It
+// will return an error if the value is not of decimal type.
+//
+// Due to the possibility of a device being asleep, the command is assumed to
+// succeed, and the value held by the node is updated directly. This will be
+// reverted by a future status message from the device if the Z-Wave message
+// actually failed to get through. Notification callbacks will be sent in both
+// cases.
+func (m *Manager) SetValueDecimal(homeID uint32, valueID uint64, value Decimal) error {
+	precision, ok := m.backend.GetValueFloatPrecision(homeID, valueID)
+	if ok == false {
+		return fmt.Errorf("value is not of decimal type")
+	}
+	if precision > 0 {
+		var err error
+		value, err = value.Rescale(precision)
+		if err != nil {
+			return err
+		}
+	}
+	ok = m.backend.SetValueString(homeID, valueID, value.String())
 	if ok == false {
 		return fmt.Errorf("value is not of decimal type")
 	}
@@ -327,10 +312,8 @@ func SetValueFloat(homeID uint32, valueID uint64, value float32) error {
 // reverted by a future status message from the device if the Z-Wave message
 // actually failed to get through. Notification callbacks will be sent in both
 // cases.
-func SetValueInt32(homeID uint32, valueID uint64, value int32) error {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	ok := bool(C.manager_setValueInt32(cmanager, cvalueid, C.int32_t(value)))
+func (m *Manager) SetValueInt32(homeID uint32, valueID uint64, value int32) error {
+	ok := m.backend.SetValueInt32(homeID, valueID, value)
 	if ok == false {
 		return fmt.Errorf("value is not of 32-bit signed integer type")
 	}
@@ -345,10 +328,8 @@ func SetValueInt32(homeID uint32, valueID uint64, value int32) error {
 // reverted by a future status message from the device if the Z-Wave message
 // actually failed to get through. Notification callbacks will be sent in both
 // cases.
-func SetValueInt16(homeID uint32, valueID uint64, value int16) error {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	ok := bool(C.manager_setValueInt16(cmanager, cvalueid, C.int16_t(value)))
+func (m *Manager) SetValueInt16(homeID uint32, valueID uint64, value int16) error {
+	ok := m.backend.SetValueInt16(homeID, valueID, value)
 	if ok == false {
 		return fmt.Errorf("value is not of 16-bit signed integer type")
 	}
@@ -363,16 +344,8 @@ func SetValueInt16(homeID uint32, valueID uint64, value int16) error {
 // reverted by a future status message from the device if the Z-Wave message
 // actually failed to get through. Notification callbacks will be sent in both
 // cases.
-func SetValueBytes(homeID uint32, valueID uint64, value []byte) error {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	zwbytes := C.zwbytes_new()
-	C.zwbytes_reserve(zwbytes, C.size_t(len(value)))
-	for i := range value {
-		C.zwbytes_set(zwbytes, C.size_t(i), C.uint8_t(value[i]))
-	}
-	ok := bool(C.manager_setValueBytes(cmanager, cvalueid, zwbytes))
-	C.zwbytes_free(zwbytes)
+func (m *Manager) SetValueBytes(homeID uint32, valueID uint64, value []byte) error {
+	ok := m.backend.SetValueBytes(homeID, valueID, value)
 	if ok == false {
 		return fmt.Errorf("value is not of raw type")
 	}
@@ -388,12 +361,8 @@ func SetValueBytes(homeID uint32, valueID uint64, value []byte) error {
 // reverted by a future status message from the device if the Z-Wave message
 // actually failed to get through. Notification callbacks will be sent in both
 // cases.
-func SetValueString(homeID uint32, valueID uint64, value string) error {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	cstring := C.CString(value)
-	ok := bool(C.manager_setValueString(cmanager, cvalueid, cstring))
-	C.free(unsafe.Pointer(cstring))
+func (m *Manager) SetValueString(homeID uint32, valueID uint64, value string) error {
+	ok := m.backend.SetValueString(homeID, valueID, value)
 	if ok == false {
 		return fmt.Errorf("could not parse string into correct type for value")
 	}
@@ -409,48 +378,65 @@ func SetValueString(homeID uint32, valueID uint64, value string) error {
 // reverted by a future status message from the device if the Z-Wave message
 // actually failed to get through. Notification callbacks will be sent in both
 // cases.
-func SetValueListSelection(homeID uint32, valueID uint64, selection string) error {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	cstring := C.CString(selection)
-	ok := bool(C.manager_setValueListSelection(cmanager, cvalueid, cstring))
-	C.free(unsafe.Pointer(cstring))
+func (m *Manager) SetValueListSelection(homeID uint32, valueID uint64, selection string) error {
+	ok := m.backend.SetValueListSelection(homeID, valueID, selection)
 	if ok == false {
 		return fmt.Errorf("value is not of list type or selection is not in the list")
 	}
 	return nil
 }
 
+// SetValueBitSet sets the state of a bit in a BitSet value. Bits are numbered
+// from 1. It will return an error if the value is not of BitSet type or the bit
+// is not valid.
+//
+// Due to the possibility of a device being asleep, the command is assumed to
+// succeed, and the value held by the node is updated directly. This will be
+// reverted by a future status message from the device if the Z-Wave message
+// actually failed to get through. Notification callbacks will be sent in both
+// cases.
+func (m *Manager) SetValueBitSet(homeID uint32, valueID uint64, pos uint8, value bool) error {
+	ok := m.backend.SetValueBitSet(homeID, valueID, pos, value)
+	if ok == false {
+		return fmt.Errorf("value is not of bitset type or bit %d is not valid", pos)
+	}
+	return nil
+}
+
+// SetBitMask sets the mask of the bits in a BitSet value which are valid for
+// the device. It will return an error if the value is not of BitSet type.
+func (m *Manager) SetBitMask(homeID uint32, valueID uint64, mask uint32) error {
+	ok := m.backend.SetBitMask(homeID, valueID, mask)
+	if ok == false {
+		return fmt.Errorf("value is not of bitset type")
+	}
+	return nil
+}
+
 // RefreshValue refreshes the specified value from the Z-Wave network. It will
 // return true if the driver and node were found, otherwise false.
 //
 // A call to this function causes the library to send a message to the network
 // to retrieve the current value of the specified ValueID (just like a poll,
 // except only one-time, not recurring).
-func RefreshValue(homeID uint32, valueID uint64) bool {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	return bool(C.manager_refreshValue(cmanager, cvalueid))
+func (m *Manager) RefreshValue(homeID uint32, valueID uint64) bool {
+	return m.backend.RefreshValue(homeID, valueID)
 }
 
 // SetChangeVerified sets a flag indicating whether value changes noted upon a
 // refresh should be verified. If so, the library will immediately refresh the
 // value a second time whenever a change is observed. This helps to filter out
 // spurious data reported occasionally by some devices.
-func SetChangeVerified(homeID uint32, valueID uint64, verify bool) {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	C.manager_setChangeVerified(cmanager, cvalueid, C.bool(verify))
+func (m *Manager) SetChangeVerified(homeID uint32, valueID uint64, verify bool) {
+	m.backend.SetChangeVerified(homeID, valueID, verify)
 }
 
 // GetChangeVerified returns true if value changes upon a refresh should be
 // verified. If so, the library will immediately refresh the value a second time
 // whenever a change is observed. This helps to filter out spurious data
 // reported occasionally by some devices.
-func GetChangeVerified(homeID uint32, valueID uint64) bool {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	return bool(C.manager_getChangeVerified(cmanager, cvalueid))
+func (m *Manager) GetChangeVerified(homeID uint32, valueID uint64) bool {
+	return m.backend.GetChangeVerified(homeID, valueID)
 }
 
 // PressButton starts an activity in a device. It will return an error if the
@@ -458,10 +444,8 @@ func GetChangeVerified(homeID uint32, valueID uint64) bool {
 //
 // Since buttons are write-only values that do not report a state, no
 // notification callbacks are sent.
-func PressButton(homeID uint32, valueID uint64) error {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	ok := bool(C.manager_pressButton(cmanager, cvalueid))
+func (m *Manager) PressButton(homeID uint32, valueID uint64) error {
+	ok := m.backend.PressButton(homeID, valueID)
 	if ok == false {
 		return fmt.Errorf("value is not of button type")
 	}
@@ -473,10 +457,8 @@ func PressButton(homeID uint32, valueID uint64) error {
 //
 // Since buttons are write-only values that do not report a state, no
 // notification callbacks are sent.
-func ReleaseButton(homeID uint32, valueID uint64) error {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	ok := bool(C.manager_releaseButton(cmanager, cvalueid))
+func (m *Manager) ReleaseButton(homeID uint32, valueID uint64) error {
+	ok := m.backend.ReleaseButton(homeID, valueID)
 	if ok == false {
 		return fmt.Errorf("value is not of button type")
 	}
@@ -489,10 +471,8 @@ func ReleaseButton(homeID uint32, valueID uint64) error {
 
 // GetNumSwitchPoints returns the number of switch points defined in a schedule.
 // It will return zero if the value if not of schedule type.
-func GetNumSwitchPoints(homeID uint32, valueID uint64) (uint8, error) {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	result := uint8(C.manager_getNumSwitchPoints(cmanager, cvalueid))
+func (m *Manager) GetNumSwitchPoints(homeID uint32, valueID uint64) (uint8, error) {
+	result := m.backend.GetNumSwitchPoints(homeID, valueID)
 	if result == 0 {
 		return result, fmt.Errorf("value is not of schedule type")
 	}
@@ -506,10 +486,8 @@ func GetNumSwitchPoints(homeID uint32, valueID uint64) (uint8, error) {
 // exists at the specified time in which case that switch point is updated with
 // the new setback value instead. A maximum of nine switch points can be set in
 // the schedule.
-func SetSwitchPoint(homeID uint32, valueID uint64, hours, minutes uint8, setback int8) error {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	ok := bool(C.manager_setSwitchPoint(cmanager, cvalueid, C.uint8_t(hours), C.uint8_t(minutes), C.int8_t(setback)))
+func (m *Manager) SetSwitchPoint(homeID uint32, valueID uint64, hours, minutes uint8, setback int8) error {
+	ok := m.backend.SetSwitchPoint(homeID, valueID, hours, minutes, setback)
 	if ok == false {
 		return fmt.Errorf("value is not of schedule type")
 	}
@@ -519,10 +497,8 @@ func SetSwitchPoint(homeID uint32, valueID uint64, hours, minutes uint8, setback
 // RemoveSwitchPoint removes a switch point from the schedule. It will return an
 // error if the value is not of schedule type or there is no switch point with
 // the specified time values.
-func RemoveSwitchPoint(homeID uint32, valueID uint64, hours, minutes uint8) error {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	ok := bool(C.manager_removeSwitchPoint(cmanager, cvalueid, C.uint8_t(hours), C.uint8_t(minutes)))
+func (m *Manager) RemoveSwitchPoint(homeID uint32, valueID uint64, hours, minutes uint8) error {
+	ok := m.backend.RemoveSwitchPoint(homeID, valueID, hours, minutes)
 	if ok == false {
 		return fmt.Errorf("value is not of schedule type or no switch point found with specified time values")
 	}
@@ -530,25 +506,18 @@ func RemoveSwitchPoint(homeID uint32, valueID uint64, hours, minutes uint8) erro
 }
 
 // ClearSwitchPoints clears all switch points from the schedule.
-func ClearSwitchPoints(homeID uint32, valueID uint64) {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	C.manager_clearSwitchPoints(cmanager, cvalueid)
+func (m *Manager) ClearSwitchPoints(homeID uint32, valueID uint64) {
+	m.backend.ClearSwitchPoints(homeID, valueID)
 }
 
 // GetSwitchPoint returns switch point data from the schedule. It will also
 // return an error if the value is not of schedule type.
 //
 // It retrieves the time and setback values from a switch point in the schedule.
-func GetSwitchPoint(homeID uint32, valueID uint64, idx uint8) (uint8, uint8, int8, error) {
-	cvalueid := C.valueid_create(C.uint32_t(homeID), C.uint64_t(valueID))
-	defer C.valueid_free(cvalueid)
-	var chours C.uint8_t
-	var cminutes C.uint8_t
-	var csetback C.int8_t
-	ok := bool(C.manager_getSwitchPoint(cmanager, cvalueid, C.uint8_t(idx), &chours, &cminutes, &csetback))
+func (m *Manager) GetSwitchPoint(homeID uint32, valueID uint64, idx uint8) (uint8, uint8, int8, error) {
+	hours, minutes, setback, ok := m.backend.GetSwitchPoint(homeID, valueID, idx)
 	if ok == false {
-		return uint8(chours), uint8(cminutes), int8(csetback), fmt.Errorf("value is not of schedule type")
+		return hours, minutes, setback, fmt.Errorf("value is not of schedule type")
 	}
-	return uint8(chours), uint8(cminutes), int8(csetback), nil
+	return hours, minutes, setback, nil
 }